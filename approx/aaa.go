@@ -0,0 +1,226 @@
+package approx
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// maxSweeps is the maximum number of sweeps of the one-sided Jacobi SVD
+const maxSweeps = 50
+
+// AAA is a rational function in the barycentric form
+//	r(x) = sum_k w_k f_k / (x - z_k) / sum_k w_k / (x - z_k)
+// with support points z_k, values f_k = r(z_k) and weights w_k, of
+// type (m-1, m-1) for m support points
+type AAA struct {
+	z, f, w []float64
+	maxErr  float64
+}
+
+// NewAAA returns the rational approximation of the data (xs[i], fs[i])
+// by the AAA (adaptive Antoulas-Anderson) algorithm of Nakatsukasa,
+// Sète and Trefethen. Support points are added greedily where the error
+// is largest; for each support set the weights minimize the linearized
+// error ||f d - n|| on the other samples, as the right singular vector
+// of the Loewner matrix for its smallest singular value. The samples
+// must be distinct; they need not be sorted. If the tolerance is not
+// reached with mmax support points, that approximation is returned with
+// an error.
+//
+// tol	: stop when max |f - r| <= tol max |f| (default 1e-13)
+// mmax	: the maximum number of support points (default 100)
+func NewAAA(xs, fs []float64, tol float64, mmax int) (*AAA, error) {
+	n := len(xs)
+	if n == 0 || len(fs) != n {
+		return nil, fmt.Errorf("len(xs) = %d, len(fs) = %d, want equal and > 0", n, len(fs))
+	}
+	sorted := append([]float64(nil), xs...)
+	sort.Float64s(sorted)
+	for i := 1; i < n; i++ {
+		if sorted[i] == sorted[i-1] {
+			return nil, fmt.Errorf("Duplicate sample point %g", sorted[i])
+		}
+	}
+	if tol <= 0.0 {
+		tol = 1.0e-13
+	}
+	if mmax <= 0 {
+		mmax = 100
+	}
+	fmax, mean := 0.0, 0.0
+	for _, f := range fs {
+		fmax = math.Max(fmax, math.Abs(f))
+		mean += f
+	}
+	mean /= float64(n)
+	r := make([]float64, n)
+	for i := range r {
+		r[i] = mean
+	}
+	free := make([]bool, n)
+	for i := range free {
+		free[i] = true
+	}
+	p := &AAA{}
+	for m := 1; m <= imin(mmax, imax(n-1, 1)); m++ {
+		j, e := -1, -1.0
+		for i := range fs {
+			if free[i] && math.Abs(fs[i]-r[i]) > e {
+				j, e = i, math.Abs(fs[i]-r[i])
+			}
+		}
+		free[j] = false
+		p.z = append(p.z, xs[j])
+		p.f = append(p.f, fs[j])
+		//-----------------------------------------------------
+		// Loewner matrix by columns: a[k][i] =
+		// (fs[i] - f_k) / (xs[i] - z_k) over the free samples i
+		//-----------------------------------------------------
+		a := make([][]float64, m)
+		for k := range a {
+			for i := range xs {
+				if free[i] {
+					a[k] = append(a[k], (fs[i]-p.f[k])/(xs[i]-p.z[k]))
+				}
+			}
+		}
+		p.w = minSingularVector(a)
+		p.maxErr = 0.0
+		for i := range xs {
+			r[i] = fs[i]
+			if free[i] {
+				r[i] = p.Eval(xs[i])
+				p.maxErr = math.Max(p.maxErr, math.Abs(fs[i]-r[i]))
+			}
+		}
+		if p.maxErr <= tol*fmax {
+			return p, nil
+		}
+	}
+	return p, fmt.Errorf("Not convergence in %4d support points within %10.3e", len(p.z), tol)
+}
+
+// minSingularVector returns the right singular vector of the matrix with
+// columns a for its smallest singular value by one-sided Jacobi
+// rotations; a is destroyed
+func minSingularVector(a [][]float64) []float64 {
+	m := len(a)
+	v := make([][]float64, m)
+	for j := range v {
+		v[j] = make([]float64, m)
+		v[j][j] = 1.0
+	}
+	const eps = 2.220446049250313e-16
+	for sweep := 0; sweep < maxSweeps; sweep++ {
+		rotated := false
+		for p := 0; p < m-1; p++ {
+			for q := p + 1; q < m; q++ {
+				alpha, beta, gamma := dot(a[p], a[p]), dot(a[q], a[q]), dot(a[p], a[q])
+				if gamma == 0.0 || math.Abs(gamma) <= eps*math.Sqrt(alpha*beta) {
+					continue
+				}
+				rotated = true
+				//-----------------------------------------------------
+				// the rotation makes columns p and q orthogonal
+				//-----------------------------------------------------
+				zeta := (beta - alpha) / (2.0 * gamma)
+				t := math.Copysign(1.0, zeta) / (math.Abs(zeta) + math.Sqrt(1.0+zeta*zeta))
+				c := 1.0 / math.Sqrt(1.0+t*t)
+				s := c * t
+				rotate(a[p], a[q], c, s)
+				rotate(v[p], v[q], c, s)
+			}
+		}
+		if !rotated {
+			break
+		}
+	}
+	j := 0
+	for k := 1; k < m; k++ {
+		if dot(a[k], a[k]) < dot(a[j], a[j]) {
+			j = k
+		}
+	}
+	return v[j]
+}
+
+// rotate sets (x, y) = (c x - s y, s x + c y)
+func rotate(x, y []float64, c, s float64) {
+	for i := range x {
+		x[i], y[i] = c*x[i]-s*y[i], s*x[i]+c*y[i]
+	}
+}
+
+// dot returns x . y
+func dot(x, y []float64) float64 {
+	s := 0.0
+	for i := range x {
+		s += x[i] * y[i]
+	}
+	return s
+}
+
+// Support returns the support points, the values and the weights
+func (p *AAA) Support() (z, f, w []float64) {
+	return append([]float64(nil), p.z...), append([]float64(nil), p.f...), append([]float64(nil), p.w...)
+}
+
+// MaxErr returns the largest error of r on the samples that are not
+// support points
+func (p *AAA) MaxErr() float64 {
+	return p.maxErr
+}
+
+// Eval returns r(x); at a support point it returns the value there
+func (p *AAA) Eval(x float64) float64 {
+	num, den := 0.0, 0.0
+	for k, zk := range p.z {
+		if x == zk {
+			return p.f[k]
+		}
+		c := p.w[k] / (x - zk)
+		num += c * p.f[k]
+		den += c
+	}
+	return num / den
+}
+
+// Poles returns the roots of sum_k w_k / (x - z_k) and the residues
+// n / d' there, n and d being the numerator and denominator sums
+func (p *AAA) Poles() (poles, residues []complex128, err error) {
+	poles, err = secularRoots(p.z, p.w)
+	if err != nil {
+		return nil, nil, err
+	}
+	residues = make([]complex128, len(poles))
+	for i, x := range poles {
+		var num, dd complex128
+		for k, zk := range p.z {
+			c := complex(p.w[k], 0.0) / (x - complex(zk, 0.0))
+			num += c * complex(p.f[k], 0.0)
+			dd -= c / (x - complex(zk, 0.0))
+		}
+		residues[i] = num / dd
+	}
+	return poles, residues, nil
+}
+
+// Zeros returns the roots of sum_k w_k f_k / (x - z_k) and the support
+// points with f_k = 0
+func (p *AAA) Zeros() ([]complex128, error) {
+	wf := make([]float64, len(p.w))
+	for k := range wf {
+		wf[k] = p.w[k] * p.f[k]
+	}
+	zeros, err := secularRoots(p.z, wf)
+	if err != nil {
+		return nil, err
+	}
+	for k, zk := range p.z {
+		if p.f[k] == 0.0 && p.w[k] != 0.0 {
+			zeros = append(zeros, complex(zk, 0.0))
+		}
+	}
+	return zeros, nil
+}
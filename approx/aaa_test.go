@@ -0,0 +1,155 @@
+package approx
+
+import (
+	"math"
+	"testing"
+)
+
+// linspace returns n equally spaced points on [a, b]
+func linspace(a, b float64, n int) []float64 {
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = a + (b-a)*float64(i)/float64(n-1)
+	}
+	return x
+}
+
+func TestNewAAA(t *testing.T) {
+	tests := []struct {
+		name    string
+		f       func(float64) float64
+		a, b    float64
+		maxM    int          // the most support points expected
+		poles   []complex128 // nil: not checked
+		res     []complex128
+		zeros   []complex128
+		wantErr bool
+	}{
+		{"mobius", func(x float64) float64 { return (x - 0.5) / (x - 2) }, -1, 1, 2,
+			[]complex128{2}, []complex128{1.5}, []complex128{0.5}, false},
+		{"runge", func(x float64) float64 { return 1 / (1 + 25*x*x) }, -1, 1, 3,
+			[]complex128{0.2i, -0.2i}, []complex128{-0.1i, 0.1i}, nil, false},
+		{"pole near", func(x float64) float64 { return math.Exp(x) / (x - 1.01) }, -1, 1, 20,
+			nil, nil, nil, false},
+		{"tan", func(x float64) float64 { return math.Tan(math.Pi / 2 * x) }, -0.99, 0.99, 20,
+			nil, nil, nil, false},
+		{"constant", func(x float64) float64 { return 2 }, -1, 1, 1,
+			[]complex128{}, []complex128{}, []complex128{}, false},
+		{"abs", math.Abs, -1, 1, 6, nil, nil, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			xs := linspace(tt.a, tt.b, 1000)
+			fs := make([]float64, len(xs))
+			for i, x := range xs {
+				fs[i] = tt.f(x)
+			}
+			mmax := 0
+			if tt.wantErr {
+				mmax = tt.maxM
+			}
+			r, err := NewAAA(xs, fs, 0, mmax)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewAAA() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			z, _, _ := r.Support()
+			if len(z) > tt.maxM {
+				t.Errorf("support points = %d, want <= %d", len(z), tt.maxM)
+			}
+			fmax := 0.0
+			for _, f := range fs {
+				fmax = math.Max(fmax, math.Abs(f))
+			}
+			if r.MaxErr() > 1e-13*fmax {
+				t.Errorf("MaxErr() = %v, want <= %v", r.MaxErr(), 1e-13*fmax)
+			}
+			for i := 0; i < 999; i++ {
+				x := (xs[i] + xs[i+1]) / 2
+				if got := r.Eval(x); math.Abs(got-tt.f(x)) > 1e-11*fmax {
+					t.Errorf("Eval(%v) = %v, want %v", x, got, tt.f(x))
+					break
+				}
+			}
+			if tt.poles == nil {
+				return
+			}
+			poles, res, err := r.Poles()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !sameRoots(poles, tt.poles, 1e-10) {
+				t.Errorf("Poles() = %v, want %v", poles, tt.poles)
+			}
+			if !sameRoots(res, tt.res, 1e-10) {
+				t.Errorf("residues = %v, want %v", res, tt.res)
+			}
+			if tt.zeros == nil {
+				return
+			}
+			zeros, err := r.Zeros()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !sameRoots(zeros, tt.zeros, 1e-10) {
+				t.Errorf("Zeros() = %v, want %v", zeros, tt.zeros)
+			}
+		})
+	}
+}
+
+func TestAAAPoles(t *testing.T) {
+	// tan(pi x / 2) sampled inside (-3, 3) has poles at +-1, +-3 with
+	// residues -2/pi
+	xs := linspace(-2.9, 2.9, 2000)
+	fs := make([]float64, 0, len(xs))
+	keep := xs[:0]
+	for _, x := range xs {
+		if math.Abs(math.Abs(x)-1) > 1e-3 {
+			keep = append(keep, x)
+			fs = append(fs, math.Tan(math.Pi/2*x))
+		}
+	}
+	r, err := NewAAA(keep, fs, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	poles, res, err := r.Poles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []float64{-1, 1} {
+		found := false
+		for i, p := range poles {
+			if math.Abs(real(p)-want) < 1e-9 && math.Abs(imag(p)) < 1e-9 {
+				found = true
+				if math.Abs(real(res[i])+2/math.Pi) > 1e-7 {
+					t.Errorf("residue at %v = %v, want %v", want, res[i], -2/math.Pi)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("Poles() = %v, missing %v", poles, want)
+		}
+	}
+}
+
+func TestNewAAAErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		xs, fs []float64
+	}{
+		{"empty", nil, nil},
+		{"mismatch", []float64{0, 1}, []float64{1}},
+		{"duplicate", []float64{0, 1, 0}, []float64{1, 2, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewAAA(tt.xs, tt.fs, 0, 0); err == nil {
+				t.Errorf("NewAAA() error = nil, want an error")
+			}
+		})
+	}
+}
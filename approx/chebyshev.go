@@ -0,0 +1,361 @@
+// Package approx approximates functions by cheap proxies: Chebyshev
+// series that can be evaluated, differentiated, integrated and solved
+// for their roots in place of an expensive function, and rational
+// functions (Padé, AAA) for functions with poles near the interval.
+package approx
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/shyang107/gnum"
+	"github.com/shyang107/gnum/solveeqs/eigen"
+)
+
+const (
+	maxDegree = 4096 // the largest degree tried by Approximate
+	maxSplit  = 20   // the deepest splitting of Roots
+)
+
+// Chebyshev is the Chebyshev series
+//	p(x) = sum_{k=0}^{n} c_k T_k(t),	t = (2x - a - b) / (b - a)
+// on [a, b]; outside [a, b] it is extrapolated
+type Chebyshev struct {
+	a, b float64
+	c    []float64
+}
+
+// Approximate returns the Chebyshev proxy of f on [a, b] accurate to
+// about tol relative to the largest coefficient. The degree is doubled
+// from 16, reusing the samples, until the trailing coefficients fall
+// below tol; then the negligible tail is chopped. If degree 4096 is not
+// enough, the proxy of that degree is returned with an error.
+//
+// tol	: the relative tolerance, tol <= 0 means 1e-13
+func Approximate(f func(float64) float64, a, b, tol float64) (*Chebyshev, error) {
+	if !(a < b) {
+		return nil, fmt.Errorf("Empty interval [%v, %v]", a, b)
+	}
+	if tol <= 0.0 {
+		tol = 1.0e-13
+	}
+	var fx []float64
+	for n := 16; ; n *= 2 {
+		fx = samples(f, a, b, n, fx)
+		p := &Chebyshev{a: a, b: b, c: coefficients(fx)}
+		scale := p.scale()
+		tail := 0.0
+		for k := n - 2; k <= n; k++ {
+			tail = math.Max(tail, math.Abs(p.c[k]))
+		}
+		if math.IsNaN(scale) || math.IsInf(scale, 0) {
+			return nil, fmt.Errorf("The function is not finite on [%v, %v]", a, b)
+		}
+		if tail <= tol*scale {
+			p.chop(tol * scale)
+			return p, nil
+		}
+		if n >= maxDegree {
+			return p, fmt.Errorf("Not convergence with degree %d within %10.3e: tail %10.3e", n, tol, tail/scale)
+		}
+	}
+}
+
+// Interpolant returns the Chebyshev series of degree n that interpolates
+// f at the n+1 Chebyshev points of the second kind on [a, b]
+func Interpolant(f func(float64) float64, a, b float64, n int) (*Chebyshev, error) {
+	if !(a < b) {
+		return nil, fmt.Errorf("Empty interval [%v, %v]", a, b)
+	}
+	if n < 0 {
+		return nil, fmt.Errorf("Negative degree %d", n)
+	}
+	if n == 0 {
+		return &Chebyshev{a: a, b: b, c: []float64{f(0.5 * (a + b))}}, nil
+	}
+	return &Chebyshev{a: a, b: b, c: coefficients(samples(f, a, b, n, nil))}, nil
+}
+
+// NewChebyshev returns the series with the coefficients c on [a, b]; c is
+// copied
+func NewChebyshev(a, b float64, c []float64) (*Chebyshev, error) {
+	if !(a < b) {
+		return nil, fmt.Errorf("Empty interval [%v, %v]", a, b)
+	}
+	if len(c) == 0 {
+		return nil, fmt.Errorf("At least one coefficient is required")
+	}
+	return &Chebyshev{a: a, b: b, c: append([]float64(nil), c...)}, nil
+}
+
+// samples returns f at the n+1 Chebyshev points cos(j pi / n) mapped to
+// [a, b]; prev, the samples for n/2, are reused at the even points
+func samples(f func(float64) float64, a, b float64, n int, prev []float64) []float64 {
+	fx := make([]float64, n+1)
+	for j := 0; j <= n; j++ {
+		if prev != nil && j%2 == 0 {
+			fx[j] = prev[j/2]
+			continue
+		}
+		t := math.Cos(math.Pi * float64(j) / float64(n))
+		fx[j] = f(0.5*(a+b) + 0.5*(b-a)*t)
+	}
+	return fx
+}
+
+// coefficients returns the Chebyshev coefficients of the interpolant of
+// the values fx at cos(j pi / n), j = 0..n, by the discrete cosine
+// transform
+//	c_k = (2/n) sum''_j fx_j cos(j k pi / n)
+// with the first and the last terms and coefficients halved
+func coefficients(fx []float64) []float64 {
+	n := len(fx) - 1
+	c := make([]float64, n+1)
+	for k := 0; k <= n; k++ {
+		s := 0.5 * (fx[0] + fx[n]*math.Cos(math.Pi*float64(k)))
+		for j := 1; j < n; j++ {
+			s += fx[j] * math.Cos(math.Pi*float64((j*k)%(2*n))/float64(n))
+		}
+		c[k] = 2.0 * s / float64(n)
+	}
+	c[0] *= 0.5
+	c[n] *= 0.5
+	return c
+}
+
+// scale returns the largest coefficient magnitude, at least the smallest
+// normal number
+func (p *Chebyshev) scale() float64 {
+	s := 0.0
+	for _, ck := range p.c {
+		if math.IsNaN(ck) {
+			return ck
+		}
+		s = math.Max(s, math.Abs(ck))
+	}
+	return math.Max(s, 2.2250738585072014e-308)
+}
+
+// chop drops the trailing coefficients below cut
+func (p *Chebyshev) chop(cut float64) {
+	n := len(p.c)
+	for n > 1 && math.Abs(p.c[n-1]) <= cut {
+		n--
+	}
+	p.c = p.c[:n]
+}
+
+// Domain returns the interval [a, b]
+func (p *Chebyshev) Domain() (a, b float64) {
+	return p.a, p.b
+}
+
+// Degree returns the degree n of the series
+func (p *Chebyshev) Degree() int {
+	return len(p.c) - 1
+}
+
+// Coefficients returns a copy of the coefficients c_k
+func (p *Chebyshev) Coefficients() []float64 {
+	return append([]float64(nil), p.c...)
+}
+
+// Eval returns p(x) by the Clenshaw recurrence
+func (p *Chebyshev) Eval(x float64) float64 {
+	return clenshaw(p.c, (2.0*x-p.a-p.b)/(p.b-p.a))
+}
+
+// clenshaw returns sum c_k T_k(t)
+func clenshaw(c []float64, t float64) float64 {
+	b1, b2 := 0.0, 0.0
+	for k := len(c) - 1; k >= 1; k-- {
+		b1, b2 = 2.0*t*b1-b2+c[k], b1
+	}
+	return t*b1 - b2 + c[0]
+}
+
+// Deriv returns the derivative p' as a Chebyshev series
+func (p *Chebyshev) Deriv() *Chebyshev {
+	n := len(p.c) - 1
+	d := &Chebyshev{a: p.a, b: p.b, c: make([]float64, imax(n, 1))}
+	if n == 0 {
+		return d
+	}
+	//-----------------------------------------------------
+	// d_{k-1} = d_{k+1} + 2 k c_k, d_0 halved
+	//-----------------------------------------------------
+	dk := make([]float64, n+2)
+	for k := n; k >= 1; k-- {
+		dk[k-1] = dk[k+1] + 2.0*float64(k)*p.c[k]
+	}
+	dk[0] *= 0.5
+	scale := 2.0 / (p.b - p.a)
+	for k := range d.c {
+		d.c[k] = scale * dk[k]
+	}
+	return d
+}
+
+// Integral returns the indefinite integral of p that vanishes at a as a
+// Chebyshev series
+func (p *Chebyshev) Integral() *Chebyshev {
+	n := len(p.c) - 1
+	c := func(k int) float64 {
+		if k > n {
+			return 0.0
+		}
+		return p.c[k]
+	}
+	q := &Chebyshev{a: p.a, b: p.b, c: make([]float64, n+2)}
+	scale := 0.5 * (p.b - p.a)
+	q.c[1] = scale * (c(0) - 0.5*c(2))
+	for k := 2; k <= n+1; k++ {
+		q.c[k] = scale * (c(k-1) - c(k+1)) / (2.0 * float64(k))
+	}
+	//-----------------------------------------------------
+	// T_k(-1) = (-1)^k fixes the constant
+	//-----------------------------------------------------
+	s := 0.0
+	for k := 1; k <= n+1; k++ {
+		if k%2 == 0 {
+			s += q.c[k]
+		} else {
+			s -= q.c[k]
+		}
+	}
+	q.c[0] = -s
+	return q
+}
+
+// Integrate returns the integral of p over [a, b], from the integrals
+// 2 / (1 - k^2) of the even T_k
+func (p *Chebyshev) Integrate() float64 {
+	s := 0.0
+	for k := 0; k < len(p.c); k += 2 {
+		s += p.c[k] * 2.0 / (1.0 - float64(k*k))
+	}
+	return 0.5 * (p.b - p.a) * s
+}
+
+// Roots returns the real roots of p in [a, b] in increasing order. A
+// series of degree above 50 is split and re-interpolated on the halves;
+// the roots of each piece are the real eigenvalues of its colleague
+// matrix, polished by a Newton step on p.
+func (p *Chebyshev) Roots() ([]float64, error) {
+	roots, err := p.roots(0)
+	if err != nil {
+		return nil, err
+	}
+	d := p.Deriv()
+	for i, x := range roots {
+		if dx := d.Eval(x); dx != 0.0 {
+			if xn := x - p.Eval(x)/dx; math.Abs(p.Eval(xn)) < math.Abs(p.Eval(x)) {
+				roots[i] = math.Max(p.a, math.Min(p.b, xn))
+			}
+		}
+	}
+	sort.Float64s(roots)
+	//-----------------------------------------------------
+	// merge the copies of a root found on both sides of a split
+	//-----------------------------------------------------
+	tol := 1.0e-10 * (p.b - p.a)
+	out := roots[:0]
+	for _, r := range roots {
+		if len(out) > 0 && r-out[len(out)-1] <= tol {
+			continue
+		}
+		out = append(out, r)
+	}
+	return out, nil
+}
+
+func (p *Chebyshev) roots(depth int) ([]float64, error) {
+	n := p.Degree()
+	if n > 50 && depth < maxSplit {
+		//-----------------------------------------------------
+		// the restriction to a half is a polynomial of the same
+		// degree, but needs fewer terms; split slightly off the
+		// middle to avoid symmetric roots
+		//-----------------------------------------------------
+		m := p.a + 0.5004103538*(p.b-p.a)
+		var all []float64
+		for _, ab := range [][2]float64{{p.a, m}, {m, p.b}} {
+			q, err := Interpolant(p.Eval, ab[0], ab[1], n)
+			if err != nil {
+				return nil, err
+			}
+			q.chop(1.0e-13 * q.scale())
+			d := depth
+			if q.Degree() >= n {
+				d = maxSplit
+			}
+			r, err := q.roots(d + 1)
+			if err != nil {
+				return nil, err
+			}
+			all = append(all, r...)
+		}
+		return all, nil
+	}
+	ts, err := colleagueRoots(p.c)
+	if err != nil {
+		return nil, err
+	}
+	roots := make([]float64, len(ts))
+	for i, t := range ts {
+		roots[i] = 0.5*(p.a+p.b) + 0.5*(p.b-p.a)*t
+	}
+	return roots, nil
+}
+
+// colleagueRoots returns the real roots in [-1, 1] of sum c_k T_k(t) as
+// the eigenvalues of the colleague matrix
+func colleagueRoots(c []float64) ([]float64, error) {
+	n := len(c) - 1
+	for n > 0 && c[n] == 0.0 {
+		n--
+	}
+	if n == 0 {
+		return nil, nil
+	}
+	//-----------------------------------------------------
+	// t T_0 = T_1, t T_k = (T_{k-1} + T_{k+1}) / 2 and
+	// T_n = -sum_{k<n} c_k T_k / c_n
+	//-----------------------------------------------------
+	m := gnum.NewMatrix(n, n, nil)
+	if n == 1 {
+		m.Set(0, 0, -c[0]/c[1])
+	} else {
+		m.Set(0, 1, 1.0)
+		for i := 1; i < n; i++ {
+			m.Set(i, i-1, 0.5)
+			if i < n-1 {
+				m.Set(i, i+1, 0.5)
+			}
+		}
+		for k := 0; k < n; k++ {
+			m.Set(n-1, k, m.At(n-1, k)-c[k]/(2.0*c[n]))
+		}
+	}
+	ev, err := eigen.General(m)
+	if err != nil {
+		return nil, err
+	}
+	const delta = 1.0e-8
+	var ts []float64
+	for _, e := range ev {
+		t := real(e)
+		if math.Abs(imag(e)) <= delta && t >= -1.0-delta && t <= 1.0+delta {
+			ts = append(ts, math.Max(-1.0, math.Min(1.0, t)))
+		}
+	}
+	return ts, nil
+}
+
+func imax(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,169 @@
+package approx
+
+import (
+	"math"
+	"testing"
+
+	"github.com/shyang107/gnum/integrate"
+)
+
+func TestApproximate(t *testing.T) {
+	tests := []struct {
+		name    string
+		f       func(float64) float64
+		a, b    float64
+		maxDeg  int
+		wantErr bool
+	}{
+		{"polynomial", func(x float64) float64 { return 3*x*x*x - x + 2 }, -2, 3, 3, false},
+		{"exp", math.Exp, 0, 2, 20, false},
+		{"runge", func(x float64) float64 { return 1 / (1 + 25*x*x) }, -1, 1, 200, false},
+		{"oscillatory", func(x float64) float64 { return math.Sin(50 * x) }, 0, 1, 100, false},
+		{"zero", func(x float64) float64 { return 0 }, 0, 1, 0, false},
+		{"abs", math.Abs, -1, 1, maxDegree, true},
+		{"empty", math.Exp, 1, 1, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := Approximate(tt.f, tt.a, tt.b, 0)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Approximate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if p.Degree() > tt.maxDeg {
+				t.Errorf("Approximate() degree = %d, want <= %d", p.Degree(), tt.maxDeg)
+			}
+			maxErr, fmax := 0.0, 1.0
+			for i := 0; i <= 1000; i++ {
+				x := tt.a + (tt.b-tt.a)*float64(i)/1000.0
+				maxErr = math.Max(maxErr, math.Abs(p.Eval(x)-tt.f(x)))
+				fmax = math.Max(fmax, math.Abs(tt.f(x)))
+			}
+			if maxErr > 1e-12*fmax {
+				t.Errorf("Approximate() max error = %v", maxErr)
+			}
+		})
+	}
+}
+
+func TestInterpolant(t *testing.T) {
+	p, err := Interpolant(math.Cos, 0, 1, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Degree() != 10 {
+		t.Errorf("Interpolant() degree = %d, want 10", p.Degree())
+	}
+	for _, x := range []float64{0, 0.5, 1} {
+		if math.Abs(p.Eval(x)-math.Cos(x)) > 1e-12 {
+			t.Errorf("Interpolant().Eval(%v) = %v, want %v", x, p.Eval(x), math.Cos(x))
+		}
+	}
+	q, err := Interpolant(math.Cos, 0, 1, 0)
+	if err != nil || q.Eval(0) != math.Cos(0.5) {
+		t.Errorf("Interpolant() of degree 0 = %v, %v", q, err)
+	}
+	if _, err := Interpolant(math.Cos, 0, 1, -1); err == nil {
+		t.Errorf("Interpolant() of negative degree: want an error")
+	}
+}
+
+func TestNewChebyshev(t *testing.T) {
+	// T_0 + 2 T_1 + 3 T_2 = 6t^2 + 2t - 2 on [-1, 1]
+	p, err := NewChebyshev(-1, 1, []float64{1, 2, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, x := range []float64{-1, 0.3, 1, 2} {
+		if got, want := p.Eval(x), 6*x*x+2*x-2; math.Abs(got-want) > 1e-14 {
+			t.Errorf("Eval(%v) = %v, want %v", x, got, want)
+		}
+	}
+	if a, b := p.Domain(); a != -1 || b != 1 {
+		t.Errorf("Domain() = %v, %v", a, b)
+	}
+	if c := p.Coefficients(); len(c) != 3 || c[2] != 3 {
+		t.Errorf("Coefficients() = %v", c)
+	}
+	if _, err := NewChebyshev(0, 1, nil); err == nil {
+		t.Errorf("NewChebyshev() without coefficients: want an error")
+	}
+}
+
+func TestChebyshevCalculus(t *testing.T) {
+	p, err := Approximate(func(x float64) float64 { return math.Exp(x) * math.Sin(3*x) }, -1, 2, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	df := func(x float64) float64 { return math.Exp(x) * (math.Sin(3*x) + 3*math.Cos(3*x)) }
+	// the antiderivative of e^x sin 3x
+	prim := func(x float64) float64 { return math.Exp(x) * (math.Sin(3*x) - 3*math.Cos(3*x)) / 10 }
+	d := p.Deriv()
+	q := p.Integral()
+	for _, x := range []float64{-1, -0.2, 0.9, 2} {
+		if got, want := d.Eval(x), df(x); math.Abs(got-want) > 1e-10 {
+			t.Errorf("Deriv().Eval(%v) = %v, want %v", x, got, want)
+		}
+		if got, want := q.Eval(x), prim(x)-prim(-1); math.Abs(got-want) > 1e-12 {
+			t.Errorf("Integral().Eval(%v) = %v, want %v", x, got, want)
+		}
+	}
+	want := prim(2) - prim(-1)
+	if got := p.Integrate(); math.Abs(got-want) > 1e-12 {
+		t.Errorf("Integrate() = %v, want %v", got, want)
+	}
+	//-----------------------------------------------------
+	// the proxy as a cheap integrand of Romberg
+	//-----------------------------------------------------
+	if got := integrate.RombergBatch(-1, 2, integrate.Sequential(p.Eval), 1e-10); math.Abs(got-want) > 1e-8 {
+		t.Errorf("RombergBatch(proxy) = %v, want %v", got, want)
+	}
+	if c := (&Chebyshev{a: 0, b: 1, c: []float64{5}}).Deriv(); c.Eval(0.3) != 0 {
+		t.Errorf("Deriv() of a constant = %v, want 0", c.Eval(0.3))
+	}
+}
+
+func TestChebyshevRoots(t *testing.T) {
+	tests := []struct {
+		name string
+		f    func(float64) float64
+		a, b float64
+		want []float64
+	}{
+		{"linear", func(x float64) float64 { return 2*x - 1 }, 0, 2, []float64{0.5}},
+		{"quadratic", func(x float64) float64 { return x*x - 2 }, -3, 3, []float64{-math.Sqrt2, math.Sqrt2}},
+		{"none", func(x float64) float64 { return x*x + 1 }, -1, 1, nil},
+		{"bessel-like", func(x float64) float64 { return math.Sin(x) - x*math.Cos(x) }, 1, 11,
+			// tan x = x
+			[]float64{4.493409457909064, 7.725251836937707, 10.904121659428899}},
+		{"many", func(x float64) float64 { return math.Sin(math.Pi * x) }, 0.5, 40.5, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := Approximate(tt.f, tt.a, tt.b, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := p.Roots()
+			if err != nil {
+				t.Fatalf("Roots() error = %v", err)
+			}
+			want := tt.want
+			if tt.name == "many" {
+				for k := 1; k <= 40; k++ {
+					want = append(want, float64(k))
+				}
+			}
+			if len(got) != len(want) {
+				t.Fatalf("Roots() = %v, want %v", got, want)
+			}
+			for i := range want {
+				if math.Abs(got[i]-want[i]) > 1e-10 {
+					t.Errorf("Roots()[%d] = %v, want %v", i, got[i], want[i])
+				}
+			}
+		})
+	}
+}
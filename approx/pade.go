@@ -0,0 +1,125 @@
+package approx
+
+import (
+	"fmt"
+
+	"github.com/shyang107/gnum"
+	"github.com/shyang107/gnum/solveeqs"
+)
+
+// Pade is the [m/n] Padé approximant
+//	r(x) = P(t) / Q(t),	t = x - x0,	Q(0) = 1,
+// of degrees m and n whose Taylor series at x0 agrees with that of f
+// through the term t^(m+n)
+type Pade struct {
+	x0   float64
+	p, q []float64 // ascending powers of t
+}
+
+// NewPade returns the [m/n] Padé approximant from the Taylor coefficients
+// c_k = f^(k)(x0) / k! of f at x0. The denominator solves the n x n
+// Toeplitz system
+//	sum_{j=1}^{n} q_j c_{k-j} = -c_k,	k = m+1, ..., m+n,
+// and the numerator is p_k = sum_{j=0}^{min(k,n)} q_j c_{k-j}. If the
+// system is singular the [m/n] approximant does not exist and an error
+// is returned; a lower n may be tried.
+//
+// c	: the Taylor coefficients, at least m+n+1 of them
+// x0	: the expansion point
+// m, n	: the degrees of the numerator and the denominator
+func NewPade(c []float64, x0 float64, m, n int) (*Pade, error) {
+	if m < 0 || n < 0 {
+		return nil, fmt.Errorf("Bad degrees [%d/%d]", m, n)
+	}
+	if len(c) < m+n+1 {
+		return nil, fmt.Errorf("len(c) = %d, want >= %d", len(c), m+n+1)
+	}
+	coef := func(k int) float64 {
+		if k < 0 {
+			return 0.0
+		}
+		return c[k]
+	}
+	q := make([]float64, n+1)
+	q[0] = 1.0
+	if n > 0 {
+		a := gnum.NewMatrix(n, n, nil)
+		b := make([]float64, n)
+		for i := 0; i < n; i++ {
+			k := m + 1 + i
+			for j := 1; j <= n; j++ {
+				a.Set(i, j-1, coef(k-j))
+			}
+			b[i] = -c[k]
+		}
+		x, err := solveeqs.Solve(a, b)
+		if err != nil {
+			return nil, fmt.Errorf("The [%d/%d] Padé approximant does not exist: %v", m, n, err)
+		}
+		copy(q[1:], x)
+	}
+	p := make([]float64, m+1)
+	for k := range p {
+		for j := 0; j <= imin(k, n); j++ {
+			p[k] += q[j] * c[k-j]
+		}
+	}
+	return &Pade{x0: x0, p: p, q: q}, nil
+}
+
+// Degree returns the degrees of the numerator and the denominator
+func (r *Pade) Degree() (m, n int) {
+	return len(r.p) - 1, len(r.q) - 1
+}
+
+// Numerator returns the coefficients of P in ascending powers of x - x0
+func (r *Pade) Numerator() []float64 {
+	return append([]float64(nil), r.p...)
+}
+
+// Denominator returns the coefficients of Q in ascending powers of x - x0
+func (r *Pade) Denominator() []float64 {
+	return append([]float64(nil), r.q...)
+}
+
+// Eval returns r(x)
+func (r *Pade) Eval(x float64) float64 {
+	t := x - r.x0
+	return horner(r.p, t) / horner(r.q, t)
+}
+
+// Poles returns the roots of Q and the residues P / Q' there; a common
+// root of P and Q is a removable singularity but is returned as well
+func (r *Pade) Poles() (poles, residues []complex128, err error) {
+	t, err := polyRoots(r.q)
+	if err != nil {
+		return nil, nil, err
+	}
+	dq := polyDeriv(r.q)
+	poles = make([]complex128, len(t))
+	residues = make([]complex128, len(t))
+	for i, ti := range t {
+		poles[i] = ti + complex(r.x0, 0.0)
+		residues[i] = polyEval(r.p, ti) / polyEval(dq, ti)
+	}
+	return poles, residues, nil
+}
+
+// Zeros returns the roots of P
+func (r *Pade) Zeros() ([]complex128, error) {
+	t, err := polyRoots(r.p)
+	if err != nil {
+		return nil, err
+	}
+	for i := range t {
+		t[i] += complex(r.x0, 0.0)
+	}
+	return t, nil
+}
+
+func imin(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,106 @@
+package approx
+
+import (
+	"math"
+	"testing"
+)
+
+// taylorExp returns the first n Taylor coefficients of exp at 0
+func taylorExp(n int) []float64 {
+	c := make([]float64, n)
+	c[0] = 1
+	for k := 1; k < n; k++ {
+		c[k] = c[k-1] / float64(k)
+	}
+	return c
+}
+
+func TestNewPade(t *testing.T) {
+	s3 := math.Sqrt(3)
+	tests := []struct {
+		name       string
+		c          []float64
+		x0         float64
+		m, n       int
+		p, q       []float64
+		poles, res []complex128
+		zeros      []complex128
+		f          func(float64) float64
+		x, fTol    float64
+		wantErr    bool
+	}{
+		{"exp [2/2]", taylorExp(5), 0, 2, 2,
+			[]float64{1, 0.5, 1.0 / 12}, []float64{1, -0.5, 1.0 / 12},
+			[]complex128{complex(3, s3), complex(3, -s3)},
+			nil,
+			[]complex128{complex(-3, s3), complex(-3, -s3)},
+			math.Exp, 0.5, 1e-4, false},
+		// tan x = (15x - x^3) / (15 - 6x^2)
+		{"tan [3/2]", []float64{0, 1, 0, 1.0 / 3, 0, 2.0 / 15}, 0, 3, 2,
+			[]float64{0, 1, 0, -1.0 / 15}, []float64{1, 0, -0.4},
+			[]complex128{complex(-math.Sqrt(2.5), 0), complex(math.Sqrt(2.5), 0)},
+			// -(15 - p^2) / 12
+			[]complex128{complex(-12.5/12, 0), complex(-12.5/12, 0)},
+			[]complex128{complex(-math.Sqrt(15), 0), 0, complex(math.Sqrt(15), 0)},
+			math.Tan, 0.7, 1e-3, false},
+		// log x at x0 = 1: the [1/1] approximant 2t / (2 + t)
+		{"log [1/1] at 1", []float64{0, 1, -0.5}, 1, 1, 1,
+			[]float64{0, 1}, []float64{1, 0.5},
+			[]complex128{-1}, []complex128{-4}, []complex128{1},
+			math.Log, 1.1, 1e-4, false},
+		{"taylor [3/0]", taylorExp(4), 0, 3, 0,
+			[]float64{1, 1, 0.5, 1.0 / 6}, []float64{1}, nil, nil, nil,
+			math.Exp, 0.1, 1e-5, false},
+		// 1 + x^2: the [1/1] system 0 q_1 = -1 is singular
+		{"singular", []float64{1, 0, 1}, 0, 1, 1, nil, nil, nil, nil, nil, nil, 0, 0, true},
+		{"short", []float64{1, 1}, 0, 1, 1, nil, nil, nil, nil, nil, nil, 0, 0, true},
+		{"negative", []float64{1, 1}, 0, -1, 1, nil, nil, nil, nil, nil, nil, 0, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewPade(tt.c, tt.x0, tt.m, tt.n)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewPade() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if m, n := r.Degree(); m != tt.m || n != tt.n {
+				t.Errorf("Degree() = %d, %d, want %d, %d", m, n, tt.m, tt.n)
+			}
+			for k, pk := range r.Numerator() {
+				if math.Abs(pk-tt.p[k]) > 1e-14 {
+					t.Errorf("Numerator()[%d] = %v, want %v", k, pk, tt.p[k])
+				}
+			}
+			for k, qk := range r.Denominator() {
+				if math.Abs(qk-tt.q[k]) > 1e-14 {
+					t.Errorf("Denominator()[%d] = %v, want %v", k, qk, tt.q[k])
+				}
+			}
+			if got := r.Eval(tt.x); math.Abs(got-tt.f(tt.x)) > tt.fTol {
+				t.Errorf("Eval(%v) = %v, want %v", tt.x, got, tt.f(tt.x))
+			}
+			poles, res, err := r.Poles()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !sameRoots(poles, tt.poles, 1e-12) {
+				t.Errorf("Poles() = %v, want %v", poles, tt.poles)
+			}
+			if tt.res != nil && !sameRoots(res, tt.res, 1e-12) {
+				t.Errorf("residues = %v, want %v", res, tt.res)
+			}
+			if tt.zeros == nil {
+				return
+			}
+			zeros, err := r.Zeros()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !sameRoots(zeros, tt.zeros, 1e-12) {
+				t.Errorf("Zeros() = %v, want %v", zeros, tt.zeros)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package approx
+
+import (
+	"github.com/shyang107/gnum"
+	"github.com/shyang107/gnum/solveeqs/eigen"
+)
+
+// Rational is a rational function r = p / q of a real variable; the
+// poles, residues and zeros are complex in general and come in
+// conjugate pairs
+type Rational interface {
+	Eval(x float64) float64
+	// Poles returns the poles of r and the residues of r there
+	Poles() (poles, residues []complex128, err error)
+	// Zeros returns the zeros of r
+	Zeros() ([]complex128, error)
+}
+
+// horner returns sum_k c_k x^k
+func horner(c []float64, x float64) float64 {
+	s := 0.0
+	for k := len(c) - 1; k >= 0; k-- {
+		s = s*x + c[k]
+	}
+	return s
+}
+
+// polyEval returns sum_k c_k z^k by Horner's rule
+func polyEval(c []float64, z complex128) complex128 {
+	var s complex128
+	for k := len(c) - 1; k >= 0; k-- {
+		s = s*z + complex(c[k], 0.0)
+	}
+	return s
+}
+
+// polyDeriv returns the coefficients of the derivative of sum_k c_k z^k
+func polyDeriv(c []float64) []float64 {
+	if len(c) < 2 {
+		return nil
+	}
+	d := make([]float64, len(c)-1)
+	for k := range d {
+		d[k] = float64(k+1) * c[k+1]
+	}
+	return d
+}
+
+// polyRoots returns the roots of sum_k c_k z^k as the eigenvalues of
+// the companion matrix; vanishing leading coefficients are dropped
+func polyRoots(c []float64) ([]complex128, error) {
+	n := len(c) - 1
+	for n > 0 && c[n] == 0.0 {
+		n--
+	}
+	if n <= 0 {
+		return nil, nil
+	}
+	m := gnum.NewMatrix(n, n, nil)
+	for i := 1; i < n; i++ {
+		m.Set(i, i-1, 1.0)
+	}
+	for i := 0; i < n; i++ {
+		m.Set(i, n-1, -c[i]/c[n])
+	}
+	return eigen.General(m)
+}
+
+// secularRoots returns the roots of sum_k w_k / (x - z_k) = 0 for
+// distinct z_k. Multiplied by x - z_m the equation is
+//	1 + sum_{k<m} u_k / (x - z_k) = 0,	u_k = w_k (z_k - z_m) / sum_k w_k,
+// whose roots are the eigenvalues of diag(z_k) - u (1, ..., 1)^T. If
+// sum_k w_k = 0 the degree drops and the folded equation
+// sum_{k<m} w_k (z_k - z_m) / (x - z_k) = 0 is solved instead.
+func secularRoots(z, w []float64) ([]complex128, error) {
+	z, w = nonzero(z, w)
+	for len(z) > 1 {
+		m := len(z) - 1
+		s := 0.0
+		for _, wk := range w {
+			s += wk
+		}
+		if s != 0.0 {
+			a := gnum.NewMatrix(m, m, nil)
+			for i := 0; i < m; i++ {
+				u := w[i] * (z[i] - z[m]) / s
+				for j := 0; j < m; j++ {
+					a.Set(i, j, -u)
+				}
+				a.Set(i, i, z[i]-u)
+			}
+			return eigen.General(a)
+		}
+		zz := make([]float64, m)
+		ww := make([]float64, m)
+		for k := 0; k < m; k++ {
+			zz[k] = z[k]
+			ww[k] = w[k] * (z[k] - z[m])
+		}
+		z, w = nonzero(zz, ww)
+	}
+	return nil, nil
+}
+
+// nonzero returns the z_k and w_k with w_k != 0
+func nonzero(z, w []float64) (zz, ww []float64) {
+	for k := range w {
+		if w[k] != 0.0 {
+			zz = append(zz, z[k])
+			ww = append(ww, w[k])
+		}
+	}
+	return zz, ww
+}
+
@@ -0,0 +1,99 @@
+package approx
+
+import (
+	"math"
+	"math/cmplx"
+	"sort"
+	"testing"
+)
+
+var (
+	_ Rational = (*Pade)(nil)
+	_ Rational = (*AAA)(nil)
+)
+
+// sameRoots reports whether got and want agree to tol as sets
+func sameRoots(got, want []complex128, tol float64) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	less := func(r []complex128) func(i, j int) bool {
+		return func(i, j int) bool {
+			if real(r[i]) != real(r[j]) {
+				return real(r[i]) < real(r[j])
+			}
+			return imag(r[i]) < imag(r[j])
+		}
+	}
+	g := append([]complex128(nil), got...)
+	w := append([]complex128(nil), want...)
+	sort.Slice(g, less(g))
+	sort.Slice(w, less(w))
+	for i := range g {
+		if cmplx.Abs(g[i]-w[i]) > tol*math.Max(1, cmplx.Abs(w[i])) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestPolyRoots(t *testing.T) {
+	tests := []struct {
+		name string
+		c    []float64
+		want []complex128
+	}{
+		{"constant", []float64{3}, nil},
+		{"linear", []float64{-1, 2}, []complex128{0.5}},
+		{"cubic", []float64{-6, 11, -6, 1}, []complex128{1, 2, 3}},
+		{"complex", []float64{1, 0, 1}, []complex128{1i, -1i}},
+		{"leading zeros", []float64{-2, 0, 1, 0, 0}, []complex128{complex(-math.Sqrt2, 0), complex(math.Sqrt2, 0)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := polyRoots(tt.c)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !sameRoots(got, tt.want, 1e-12) {
+				t.Errorf("polyRoots() = %v, want %v", got, tt.want)
+			}
+			for _, z := range got {
+				if cmplx.Abs(polyEval(tt.c, z)) > 1e-12 {
+					t.Errorf("p(%v) = %v, want 0", z, polyEval(tt.c, z))
+				}
+			}
+		})
+	}
+}
+
+func TestSecularRoots(t *testing.T) {
+	tests := []struct {
+		name string
+		z, w []float64
+		want []complex128
+	}{
+		// 1/x + 1/(x-1) = 0 at x = 1/2
+		{"two", []float64{0, 1}, []float64{1, 1}, []complex128{0.5}},
+		// 1/(x+1) - 2/x + 1/(x-1) = 0: the weights sum to 0, so the
+		// numerator 2 has no root
+		{"folded", []float64{-1, 0, 1}, []float64{1, -2, 1}, nil},
+		// 1/(x+1) + 1/(x-1) = 2x/(x^2-1) with an inert third point
+		{"zero weight", []float64{-1, 5, 1}, []float64{1, 0, 1}, []complex128{0}},
+		// 1/(x-1) - 1/(x+1) + 1/x = (x^2 + 2x - 1) / ...
+		{"quadratic", []float64{1, -1, 0}, []float64{1, -1, 1},
+			[]complex128{complex(-1-math.Sqrt2, 0), complex(-1+math.Sqrt2, 0)}},
+		{"single", []float64{2}, []float64{1}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := secularRoots(tt.z, tt.w)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !sameRoots(got, tt.want, 1e-12) {
+				t.Errorf("secularRoots() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
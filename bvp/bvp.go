@@ -0,0 +1,183 @@
+// Package bvp solves two-point boundary value problems
+//	y' = f(x, y), a <= x <= b,	bc(y(a), y(b)) = 0
+// of systems of ordinary differential equations: by single and multiple
+// shooting, which combine the initial value solvers of package ode with
+// Newton's method on the boundary conditions, and by collocation on a
+// mesh. Linear second order problems are solved by finite differences,
+// and the eigenvalues of Sturm-Liouville problems by finite differences
+// or by shooting on the eigenvalue with Brent's method.
+package bvp
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/shyang107/gnum"
+	"github.com/shyang107/gnum/ode"
+	"github.com/shyang107/gnum/solveeqs"
+)
+
+// eps is the machine epsilon of float64
+const eps = 2.220446049250313e-16
+
+// BC sets the residuals r of the n boundary conditions of y(a) = ya and
+// y(b) = yb; the problem is solved when r = 0
+type BC func(ya, yb, r []float64)
+
+// Settings controls the solvers; the zero value uses the defaults
+type Settings struct {
+	Tol     float64 // the tolerance of the largest residual (default 1e-8)
+	MaxIter int     // the maximum number of Newton iterations (default 50)
+	// Solver integrates the initial value problems of shooting (default
+	// ode.DormandPrince) with the settings IVP (default RelTol 1e-10,
+	// AbsTol 1e-12)
+	Solver func(f ode.Func, t0, t1 float64, y0 []float64, s *ode.Settings) (*ode.Solution, error)
+	IVP    *ode.Settings
+}
+
+// defaults returns a copy of s with the defaults filled in
+func (s *Settings) defaults() Settings {
+	var d Settings
+	if s != nil {
+		d = *s
+	}
+	if d.Tol <= 0.0 {
+		d.Tol = 1.0e-8
+	}
+	if d.MaxIter <= 0 {
+		d.MaxIter = 50
+	}
+	if d.Solver == nil {
+		d.Solver = ode.DormandPrince
+	}
+	if d.IVP == nil {
+		d.IVP = &ode.Settings{RelTol: 1.0e-10, AbsTol: 1.0e-12}
+	}
+	return d
+}
+
+// Solution is the solution of a boundary value problem on a mesh
+type Solution struct {
+	X        []float64   // the mesh, X[0] = a, increasing
+	Y        [][]float64 // the solution at X
+	F        [][]float64 // the derivative f(X, Y)
+	Iter     int         // the Newton iterations
+	Residual float64     // the largest residual of the last iterate
+}
+
+// newSolution returns the solution at x, y with the derivatives f(x, y)
+func newSolution(f ode.Func, x []float64, y [][]float64) *Solution {
+	s := &Solution{X: x, Y: y, F: make([][]float64, len(x))}
+	for i := range x {
+		s.F[i] = make([]float64, len(y[i]))
+		f(x[i], y[i], s.F[i])
+	}
+	return s
+}
+
+// At returns the solution at x in [X[0], X[len(X)-1]] by the cubic
+// Hermite interpolant of Y and F
+func (s *Solution) At(x float64) ([]float64, error) {
+	m := len(s.X)
+	if x < s.X[0] || x > s.X[m-1] {
+		return nil, fmt.Errorf("x = %g is outside [%g, %g]", x, s.X[0], s.X[m-1])
+	}
+	if m == 1 {
+		return append([]float64(nil), s.Y[0]...), nil
+	}
+	i := sort.Search(m-1, func(i int) bool { return s.X[i+1] >= x })
+	h := s.X[i+1] - s.X[i]
+	t := (x - s.X[i]) / h
+	h00 := (1.0 + 2.0*t) * (1.0 - t) * (1.0 - t)
+	h10 := t * (1.0 - t) * (1.0 - t)
+	h01 := t * t * (3.0 - 2.0*t)
+	h11 := t * t * (t - 1.0)
+	y := make([]float64, len(s.Y[i]))
+	for j := range y {
+		y[j] = h00*s.Y[i][j] + h*h10*s.F[i][j] + h01*s.Y[i+1][j] + h*h11*s.F[i+1][j]
+	}
+	return y, nil
+}
+
+//-----------------------------------------------------
+// Newton's method for the discretized problems
+//-----------------------------------------------------
+
+// residual sets r(u), or returns an error if it cannot be evaluated
+type residual func(u, r []float64) error
+
+// jacobian sets jac = dr/du at u, r = r(u)
+type jacobian func(u, r []float64, jac *gnum.Matrix) error
+
+// newton solves r(u) = 0 from the estimate u, overwritten by the
+// solution, by Newton's method; a step that does not reduce the largest
+// residual is halved up to 10 times.
+//
+// output
+//	iter	: the number of iterations
+//	norm	: the largest residual at u
+func newton(res residual, jac jacobian, u []float64, st *Settings) (iter int, norm float64, err error) {
+	n := len(u)
+	r := make([]float64, n)
+	rt := make([]float64, n)
+	ut := make([]float64, n)
+	j := gnum.NewMatrix(n, n, nil)
+	if err := res(u, r); err != nil {
+		return 0, math.Inf(1), err
+	}
+	norm = maxAbs(r)
+	for iter = 0; iter < st.MaxIter; iter++ {
+		if norm <= st.Tol {
+			return iter, norm, nil
+		}
+		if err := jac(u, r, j); err != nil {
+			return iter, norm, err
+		}
+		du, err := solveeqs.Solve(j, r)
+		if err != nil {
+			return iter, norm, err
+		}
+		//-----------------------------------------------------
+		// the damped step u - lambda du
+		//-----------------------------------------------------
+		lambda, accepted := 1.0, false
+		for k := 0; k <= 10; k++ {
+			for i := range ut {
+				ut[i] = u[i] - lambda*du[i]
+			}
+			if err := res(ut, rt); err == nil {
+				if nt := maxAbs(rt); nt < norm || nt <= st.Tol {
+					copy(u, ut)
+					copy(r, rt)
+					norm, accepted = nt, true
+					break
+				}
+			}
+			lambda *= 0.5
+		}
+		if !accepted {
+			return iter + 1, norm, fmt.Errorf("No descent of the residual %10.3e in iteration %d", norm, iter+1)
+		}
+	}
+	if norm <= st.Tol {
+		return iter, norm, nil
+	}
+	return iter, norm, fmt.Errorf("Not convergence in %4d iterations within %10.3e", st.MaxIter, st.Tol)
+}
+
+// maxAbs returns max |v_i|
+func maxAbs(v []float64) float64 {
+	m := 0.0
+	for _, x := range v {
+		m = math.Max(m, math.Abs(x))
+	}
+	return m
+}
+
+// fdStep returns the forward difference step of u with the relative
+// accuracy rel of the function values
+func fdStep(u, rel float64) float64 {
+	h := math.Sqrt(math.Max(rel, eps)) * math.Max(math.Abs(u), 1.0)
+	return (u + h) - u
+}
@@ -0,0 +1,72 @@
+package bvp
+
+import (
+	"math"
+	"testing"
+
+	"github.com/shyang107/gnum"
+)
+
+func TestSolutionAt(t *testing.T) {
+	// the cubic Hermite interpolant is exact for y = x^3 - x
+	f := func(x float64, y, dy []float64) { dy[0] = 3*x*x - 1 }
+	xs := []float64{-1, 0.5, 2}
+	ys := make([][]float64, len(xs))
+	for i, x := range xs {
+		ys[i] = []float64{x*x*x - x}
+	}
+	sol := newSolution(f, xs, ys)
+	for _, x := range []float64{-1, -0.3, 0.5, 1.7, 2} {
+		y, err := sol.At(x)
+		if err != nil || math.Abs(y[0]-(x*x*x-x)) > 1e-14 {
+			t.Errorf("At(%v) = %v, %v, want %v", x, y, err, x*x*x-x)
+		}
+	}
+	for _, x := range []float64{-1.1, 2.1} {
+		if _, err := sol.At(x); err == nil {
+			t.Errorf("At(%v) error = nil, want outside", x)
+		}
+	}
+}
+
+func TestNewton(t *testing.T) {
+	// x^2 + y^2 = 4, x y = 1
+	res := func(u, r []float64) error {
+		r[0] = u[0]*u[0] + u[1]*u[1] - 4
+		r[1] = u[0]*u[1] - 1
+		return nil
+	}
+	jac := func(u, r []float64, j *gnum.Matrix) error {
+		j.Set(0, 0, 2*u[0])
+		j.Set(0, 1, 2*u[1])
+		j.Set(1, 0, u[1])
+		j.Set(1, 1, u[0])
+		return nil
+	}
+	tests := []struct {
+		name    string
+		maxIter int
+		wantErr bool
+	}{
+		{"converged", 0, false},
+		{"too few iterations", 2, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := (&Settings{Tol: 1e-12, MaxIter: tt.maxIter}).defaults()
+			u := []float64{2, 0.2}
+			iter, norm, err := newton(res, jac, u, &st)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("newton() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			r := make([]float64, 2)
+			res(u, r)
+			if norm > 1e-12 || maxAbs(r) > 1e-12 || iter == 0 {
+				t.Errorf("newton() = %v after %d iterations, residual %v", u, iter, norm)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package bvp
+
+import (
+	"fmt"
+
+	"github.com/shyang107/gnum"
+	"github.com/shyang107/gnum/ode"
+)
+
+// Collocation solves the boundary value problem y' = f(x, y),
+// bc(y(a), y(b)) = 0 on the mesh a = x_0 < ... < x_N = b by collocation
+// with the cubic C1 polynomial at the ends and the midpoint of every
+// interval (the Lobatto IIIA formula of order 4 of bvp4c of MATLAB):
+//	y_(i+1) - y_i - h/6 (f_i + 4 f(x_i + h/2, y_(i+1/2)) + f_(i+1)) = 0,
+//	y_(i+1/2) = (y_i + y_(i+1))/2 - h/8 (f_(i+1) - f_i),
+// and bc(y_0, y_N) = 0. The nonlinear system is solved by Newton's method
+// with a Jacobian by forward differences, evaluating f only on the
+// intervals next to the perturbed value. The mesh is not refined; the
+// error is O(h^4).
+//
+// mesh	: the mesh, increasing, with at least 2 points
+// guess	: the estimate of y(x), at the mesh
+// s	: the settings, nil means the defaults; Solver and IVP are not used
+// output
+//	sol	: the solution at the mesh, whose At is the collocation polynomial
+func Collocation(f ode.Func, bc BC, mesh []float64, guess func(x float64) []float64, s *Settings) (*Solution, error) {
+	N := len(mesh) - 1
+	if N < 1 {
+		return nil, fmt.Errorf("Collocation requires at least 2 mesh points, got %d", len(mesh))
+	}
+	for i := 0; i < N; i++ {
+		if mesh[i+1] <= mesh[i] {
+			return nil, fmt.Errorf("The mesh must increase, got %g after %g", mesh[i+1], mesh[i])
+		}
+	}
+	st := s.defaults()
+	n := len(guess(mesh[0]))
+	if n == 0 {
+		return nil, fmt.Errorf("Empty initial estimate")
+	}
+	u := make([]float64, n*(N+1))
+	for i := 0; i <= N; i++ {
+		g := guess(mesh[i])
+		if len(g) != n {
+			return nil, fmt.Errorf("guess(%g) has %d components, want %d", mesh[i], len(g), n)
+		}
+		copy(u[i*n:], g)
+	}
+	c := &collocation{f: f, bc: bc, x: mesh, n: n, fx: make([]float64, n*(N+1)),
+		ym: make([]float64, n), fm: make([]float64, n)}
+	iter, norm, err := newton(c.residual, c.jacobian, u, &st)
+	ys := make([][]float64, N+1)
+	for i := range ys {
+		ys[i] = append([]float64(nil), u[i*n:(i+1)*n]...)
+	}
+	sol := newSolution(f, mesh, ys)
+	sol.Iter, sol.Residual = iter, norm
+	return sol, err
+}
+
+// collocation is the system of the collocation conditions
+type collocation struct {
+	f      ode.Func
+	bc     BC
+	x      []float64
+	n      int
+	fx     []float64 // f at the mesh
+	ym, fm []float64 // the midpoint of an interval
+}
+
+// interval sets the condition of the interval i in r from y and f at its
+// ends
+func (c *collocation) interval(i int, y0, y1, f0, f1, r []float64) {
+	h := c.x[i+1] - c.x[i]
+	for j := range c.ym {
+		c.ym[j] = 0.5*(y0[j]+y1[j]) - 0.125*h*(f1[j]-f0[j])
+	}
+	c.f(c.x[i]+0.5*h, c.ym, c.fm)
+	for j := range r {
+		r[j] = y1[j] - y0[j] - h/6.0*(f0[j]+4.0*c.fm[j]+f1[j])
+	}
+}
+
+// residual sets the conditions of the interval i in r[i n:(i+1) n] and
+// the boundary conditions in the last n; it keeps f at the mesh for
+// jacobian
+func (c *collocation) residual(u, r []float64) error {
+	n, N := c.n, len(c.x)-1
+	for i := 0; i <= N; i++ {
+		c.f(c.x[i], u[i*n:(i+1)*n], c.fx[i*n:(i+1)*n])
+	}
+	for i := 0; i < N; i++ {
+		c.interval(i, u[i*n:(i+1)*n], u[(i+1)*n:(i+2)*n], c.fx[i*n:(i+1)*n], c.fx[(i+1)*n:(i+2)*n], r[i*n:(i+1)*n])
+	}
+	c.bc(u[:n], u[N*n:], r[N*n:])
+	return nil
+}
+
+// jacobian sets the Jacobian of residual at u, after residual(u, r); the
+// value y_ij enters the intervals i - 1 and i and, at the ends, bc
+func (c *collocation) jacobian(u, r []float64, jac *gnum.Matrix) error {
+	n, N := c.n, len(c.x)-1
+	rows, _ := jac.Dims()
+	for i := 0; i < rows; i++ {
+		for j := 0; j < rows; j++ {
+			jac.Set(i, j, 0.0)
+		}
+	}
+	yt := make([]float64, n)
+	ft := make([]float64, n)
+	rt := make([]float64, n)
+	for i := 0; i <= N; i++ {
+		copy(yt, u[i*n:(i+1)*n])
+		for j := 0; j < n; j++ {
+			col := i*n + j
+			h := fdStep(yt[j], eps)
+			yt[j] += h
+			c.f(c.x[i], yt, ft)
+			if i > 0 {
+				c.interval(i-1, u[(i-1)*n:i*n], yt, c.fx[(i-1)*n:i*n], ft, rt)
+				for k := 0; k < n; k++ {
+					jac.Set((i-1)*n+k, col, (rt[k]-r[(i-1)*n+k])/h)
+				}
+			}
+			if i < N {
+				c.interval(i, yt, u[(i+1)*n:(i+2)*n], ft, c.fx[(i+1)*n:(i+2)*n], rt)
+				for k := 0; k < n; k++ {
+					jac.Set(i*n+k, col, (rt[k]-r[i*n+k])/h)
+				}
+			}
+			if i == 0 || i == N {
+				ya, yb := u[:n], u[N*n:]
+				if i == 0 {
+					ya = yt
+				} else {
+					yb = yt
+				}
+				c.bc(ya, yb, rt)
+				for k := 0; k < n; k++ {
+					jac.Set(N*n+k, col, (rt[k]-r[N*n+k])/h)
+				}
+			}
+			yt[j] = u[col]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package bvp
+
+import (
+	"math"
+	"testing"
+)
+
+// uniform returns n equal intervals of [a, b]
+func uniform(a, b float64, n int) []float64 {
+	x := make([]float64, n+1)
+	for i := range x {
+		x[i] = a + (b-a)*float64(i)/float64(n)
+	}
+	return x
+}
+
+func TestCollocation(t *testing.T) {
+	f, exact := layer(25)
+	tests := []struct {
+		name  string
+		f     func(x float64, y, dy []float64)
+		bc    BC
+		exact func(x float64) float64
+		guess func(x float64) []float64
+		n     int
+		tol   float64
+	}{
+		{"quadratic", quadratic, quadraticBC, quadraticExact, func(x float64) []float64 { return []float64{4 - 3*x, -3} }, 20, 1e-5},
+		{"boundary layer", f, layerBC, exact, func(x float64) []float64 { return []float64{1 - x, -1} }, 100, 1e-4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errAt := func(n int) float64 {
+				sol, err := Collocation(tt.f, tt.bc, uniform(0, 1, n), tt.guess, nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+				e := 0.0
+				for i := 0; i <= 4*n; i++ {
+					x := float64(i) / float64(4*n)
+					y, _ := sol.At(x)
+					e = math.Max(e, math.Abs(y[0]-tt.exact(x)))
+				}
+				return e
+			}
+			e1, e2 := errAt(tt.n), errAt(2*tt.n)
+			if e1 > tt.tol {
+				t.Errorf("error %v with %d intervals", e1, tt.n)
+			}
+			// the error is O(h^4)
+			if r := e1 / e2; r < 12 || r > 20 {
+				t.Errorf("error ratio %v, want about 16", r)
+			}
+		})
+	}
+	if _, err := Collocation(quadratic, quadraticBC, []float64{0}, nil, nil); err == nil {
+		t.Errorf("Collocation() error = nil, want at least 2 mesh points")
+	}
+}
@@ -0,0 +1,62 @@
+package bvp
+
+import (
+	"fmt"
+
+	"github.com/shyang107/gnum/solveeqs"
+)
+
+// Linear solves the linear second order boundary value problem
+//	y'' = p(x) y' + q(x) y + r(x),	y(a) = alpha, y(b) = beta
+// by central finite differences of order 2 on n equal intervals,
+//	-(1 + h p_i/2) y_(i-1) + (2 + h^2 q_i) y_i - (1 - h p_i/2) y_(i+1) = -h^2 r_i,
+// a tridiagonal system solved by solveeqs.Tridiag. It is diagonally
+// dominant, so stable, if q >= 0 and h |p| <= 2.
+//
+// p, q, r	: the coefficients, nil means 0
+// n	: the number of intervals, at least 2
+// output
+//	x	: the mesh a + i h, i = 0..n
+//	y	: the solution at x
+func Linear(p, q, r func(x float64) float64, a, b, alpha, beta float64, n int) (x, y []float64, err error) {
+	if n < 2 {
+		return nil, nil, fmt.Errorf("Linear requires at least 2 intervals, got %d", n)
+	}
+	if b <= a {
+		return nil, nil, fmt.Errorf("The interval [%g, %g] is empty", a, b)
+	}
+	h := (b - a) / float64(n)
+	x = make([]float64, n+1)
+	for i := range x {
+		x[i] = a + float64(i)*h
+	}
+	x[n] = b
+	m := n - 1
+	sub, dia, sup, rhs := make([]float64, m), make([]float64, m), make([]float64, m), make([]float64, m)
+	for k := 0; k < m; k++ {
+		xi := x[k+1]
+		pi, qi, ri := 0.0, 0.0, 0.0
+		if p != nil {
+			pi = p(xi)
+		}
+		if q != nil {
+			qi = q(xi)
+		}
+		if r != nil {
+			ri = r(xi)
+		}
+		sub[k] = -(1.0 + 0.5*h*pi)
+		dia[k] = 2.0 + h*h*qi
+		sup[k] = -(1.0 - 0.5*h*pi)
+		rhs[k] = -h * h * ri
+	}
+	rhs[0] -= sub[0] * alpha
+	rhs[m-1] -= sup[m-1] * beta
+	if err := solveeqs.TridiagInPlace(sub, dia, sup, rhs, nil); err != nil {
+		return nil, nil, err
+	}
+	y = make([]float64, n+1)
+	y[0], y[n] = alpha, beta
+	copy(y[1:], rhs)
+	return x, y, nil
+}
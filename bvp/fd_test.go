@@ -0,0 +1,52 @@
+package bvp
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLinear(t *testing.T) {
+	tests := []struct {
+		name    string
+		p, q, r func(x float64) float64
+		a, b    float64
+		exact   func(x float64) float64
+	}{
+		// y'' = -y
+		{"sine", nil, func(x float64) float64 { return -1 }, nil, 0, math.Pi / 2, math.Sin},
+		// y'' = y' + 2 y
+		{"exponential", func(x float64) float64 { return 1 }, func(x float64) float64 { return 2 }, nil, 0, 1,
+			func(x float64) float64 { return math.Exp(-x) }},
+		// y'' = 2
+		{"quadratic", nil, nil, func(x float64) float64 { return 2 }, -1, 2,
+			func(x float64) float64 { return x*x + 1 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errAt := func(n int) float64 {
+				x, y, err := Linear(tt.p, tt.q, tt.r, tt.a, tt.b, tt.exact(tt.a), tt.exact(tt.b), n)
+				if err != nil {
+					t.Fatal(err)
+				}
+				e := 0.0
+				for i := range x {
+					e = math.Max(e, math.Abs(y[i]-tt.exact(x[i])))
+				}
+				return e
+			}
+			e1, e2 := errAt(20), errAt(40)
+			if e1 > 1e-3 {
+				t.Errorf("error %v with 20 intervals", e1)
+			}
+			// the error is O(h^2), exact for a quadratic
+			if e1 > 1e-12 {
+				if r := e1 / e2; r < 3.5 || r > 4.5 {
+					t.Errorf("error ratio %v, want about 4", r)
+				}
+			}
+		})
+	}
+	if _, _, err := Linear(nil, nil, nil, 0, 1, 0, 1, 1); err == nil {
+		t.Errorf("Linear() error = nil, want at least 2 intervals")
+	}
+}
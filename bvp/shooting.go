@@ -0,0 +1,178 @@
+package bvp
+
+import (
+	"fmt"
+
+	"github.com/shyang107/gnum"
+	"github.com/shyang107/gnum/ode"
+)
+
+// Shoot solves the boundary value problem y' = f(x, y), bc(y(a), y(b)) = 0
+// by single shooting: Newton's method finds the initial value s = y(a)
+// for which the solution of the initial value problem from (a, s)
+// satisfies the boundary conditions. The Jacobian of the conditions with
+// respect to s is computed by forward differences, one integration per
+// component. Single shooting fails if the initial value problem is
+// unstable over [a, b]; then use MultipleShoot.
+//
+// ya	: the estimate of y(a)
+// s	: the settings, nil means the defaults
+// output
+//	sol	: the solution at the steps of the last integration
+func Shoot(f ode.Func, bc BC, a, b float64, ya []float64, s *Settings) (*Solution, error) {
+	return MultipleShoot(f, bc, []float64{a, b}, func(x float64) []float64 { return ya }, s)
+}
+
+// MultipleShoot solves the boundary value problem y' = f(x, y),
+// bc(y(a), y(b)) = 0 by multiple shooting on the nodes a = x_0 < x_1 <
+// ... < x_m = b: the unknowns are the values s_k at x_k, k < m, and Newton's
+// method solves the continuity conditions y_k(x_(k+1)) - s_(k+1) = 0 of
+// the solutions y_k of the initial value problems from (x_k, s_k)
+// together with bc(s_0, y_(m-1)(b)) = 0. Short intervals keep the
+// integrations and the Jacobian well conditioned where single shooting
+// is not.
+//
+// nodes	: the nodes x_k, increasing
+// guess	: the estimate of y(x), at the nodes
+// s	: the settings, nil means the defaults
+// output
+//	sol	: the solution at the steps of the last integrations
+func MultipleShoot(f ode.Func, bc BC, nodes []float64, guess func(x float64) []float64, s *Settings) (*Solution, error) {
+	m := len(nodes) - 1
+	if m < 1 {
+		return nil, fmt.Errorf("MultipleShoot requires at least 2 nodes, got %d", len(nodes))
+	}
+	for k := 0; k < m; k++ {
+		if nodes[k+1] <= nodes[k] {
+			return nil, fmt.Errorf("The nodes must increase, got %g after %g", nodes[k+1], nodes[k])
+		}
+	}
+	st := s.defaults()
+	n := len(guess(nodes[0]))
+	if n == 0 {
+		return nil, fmt.Errorf("Empty initial estimate")
+	}
+	u := make([]float64, n*m)
+	for k := 0; k < m; k++ {
+		g := guess(nodes[k])
+		if len(g) != n {
+			return nil, fmt.Errorf("guess(%g) has %d components, want %d", nodes[k], len(g), n)
+		}
+		copy(u[k*n:], g)
+	}
+	sh := &shooting{f: f, bc: bc, nodes: nodes, n: n, st: &st}
+	iter, norm, err := newton(sh.residual, sh.jacobian, u, &st)
+	//-----------------------------------------------------
+	// the solution: the steps of the segments, the end
+	// of each segment replaced by the start of the next
+	//-----------------------------------------------------
+	var xs []float64
+	var ys [][]float64
+	for k := 0; k < m; k++ {
+		sol, ierr := st.Solver(f, nodes[k], nodes[k+1], u[k*n:(k+1)*n], st.IVP)
+		if ierr != nil {
+			return nil, ierr
+		}
+		last := len(sol.T) - 1
+		if k == m-1 {
+			last++
+		}
+		xs = append(xs, sol.T[:last]...)
+		ys = append(ys, sol.Y[:last]...)
+	}
+	sol := newSolution(f, xs, ys)
+	sol.Iter, sol.Residual = iter, norm
+	return sol, err
+}
+
+// shooting is the system of the conditions of multiple shooting
+type shooting struct {
+	f     ode.Func
+	bc    BC
+	nodes []float64
+	n     int
+	st    *Settings
+}
+
+// end returns y(x_(k+1)) of the initial value problem from (x_k, s)
+func (sh *shooting) end(k int, s []float64) ([]float64, error) {
+	sol, err := sh.st.Solver(sh.f, sh.nodes[k], sh.nodes[k+1], s, sh.st.IVP)
+	if err != nil {
+		return nil, err
+	}
+	return sol.Y[len(sol.Y)-1], nil
+}
+
+// residual sets the continuity conditions of the segments k < m - 1 in
+// r[k n:(k+1) n] and the boundary conditions in the last n
+func (sh *shooting) residual(u, r []float64) error {
+	n, m := sh.n, len(sh.nodes)-1
+	for k := 0; k < m; k++ {
+		e, err := sh.end(k, u[k*n:(k+1)*n])
+		if err != nil {
+			return err
+		}
+		if k == m-1 {
+			sh.bc(u[:n], e, r[k*n:])
+			break
+		}
+		for i := 0; i < n; i++ {
+			r[k*n+i] = e[i] - u[(k+1)*n+i]
+		}
+	}
+	return nil
+}
+
+// jacobian sets the Jacobian of residual; the column of s_kj has the
+// derivative of y_k(x_(k+1)) in the block row k, -1 in the block row
+// k - 1, and that of bc if k is the first or the last segment
+func (sh *shooting) jacobian(u, r []float64, jac *gnum.Matrix) error {
+	n, m := sh.n, len(sh.nodes)-1
+	rows, _ := jac.Dims()
+	for i := 0; i < rows; i++ {
+		for j := 0; j < rows; j++ {
+			jac.Set(i, j, 0.0)
+		}
+	}
+	yb, err := sh.end(m-1, u[(m-1)*n:])
+	if err != nil {
+		return err
+	}
+	sk := make([]float64, n)
+	rb := make([]float64, n)
+	for k := 0; k < m; k++ {
+		copy(sk, u[k*n:(k+1)*n])
+		for j := 0; j < n; j++ {
+			c := k*n + j
+			h := fdStep(sk[j], sh.st.IVP.RelTol)
+			sk[j] += h
+			e, err := sh.end(k, sk)
+			if err != nil {
+				return err
+			}
+			if k < m-1 {
+				for i := 0; i < n; i++ {
+					jac.Set(k*n+i, c, (e[i]-r[k*n+i]-u[(k+1)*n+i])/h)
+				}
+			}
+			if k > 0 {
+				jac.Set((k-1)*n+j, c, -1.0)
+			}
+			if k == 0 || k == m-1 {
+				ya, ybk := u[:n], yb
+				if k == 0 {
+					ya = sk
+				}
+				if k == m-1 {
+					ybk = e
+				}
+				sh.bc(ya, ybk, rb)
+				for i := 0; i < n; i++ {
+					jac.Set((m-1)*n+i, c, (rb[i]-r[(m-1)*n+i])/h)
+				}
+			}
+			sk[j] = u[k*n+j]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package bvp
+
+import (
+	"math"
+	"testing"
+)
+
+// quadratic is y” = 1.5 y^2, y(0) = 4, y(1) = 1 with the solution
+// y = 4 / (1 + x)^2
+func quadratic(x float64, y, dy []float64) {
+	dy[0] = y[1]
+	dy[1] = 1.5 * y[0] * y[0]
+}
+
+func quadraticBC(ya, yb, r []float64) {
+	r[0] = ya[0] - 4
+	r[1] = yb[0] - 1
+}
+
+func quadraticExact(x float64) float64 { return 4 / ((1 + x) * (1 + x)) }
+
+// layer is y” = c^2 y, y(0) = 1, y(1) = 0 with the solution
+// y = sinh(c (1 - x)) / sinh c, growing as exp(c x) from x = 0
+func layer(c float64) (func(x float64, y, dy []float64), func(x float64) float64) {
+	return func(x float64, y, dy []float64) {
+			dy[0] = y[1]
+			dy[1] = c * c * y[0]
+		}, func(x float64) float64 {
+			return math.Sinh(c*(1-x)) / math.Sinh(c)
+		}
+}
+
+func layerBC(ya, yb, r []float64) {
+	r[0] = ya[0] - 1
+	r[1] = yb[0]
+}
+
+func TestShoot(t *testing.T) {
+	sol, err := Shoot(quadratic, quadraticBC, 0, 1, []float64{4, -7}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(sol.Y[0][1]+8) > 1e-7 || sol.Residual > 1e-8 || sol.Iter == 0 {
+		t.Errorf("y'(0) = %v, want -8, residual %v after %d iterations", sol.Y[0][1], sol.Residual, sol.Iter)
+	}
+	for _, x := range []float64{0, 0.25, 0.6, 1} {
+		if y, _ := sol.At(x); math.Abs(y[0]-quadraticExact(x)) > 1e-7 {
+			t.Errorf("At(%v) = %v, want %v", x, y[0], quadraticExact(x))
+		}
+	}
+	if sol.X[len(sol.X)-1] != 1 {
+		t.Errorf("X ends at %v, want 1", sol.X[len(sol.X)-1])
+	}
+}
+
+func TestMultipleShoot(t *testing.T) {
+	f, exact := layer(25)
+	guess := func(x float64) []float64 { return []float64{1 - x, -1} }
+	tests := []struct {
+		name  string
+		nodes []float64
+	}{
+		{"2 segments", []float64{0, 0.5, 1}},
+		{"10 segments", []float64{0, 0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8, 0.9, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sol, err := MultipleShoot(f, layerBC, tt.nodes, guess, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, x := range []float64{0.02, 0.1, 0.33, 0.5, 0.9} {
+				if y, _ := sol.At(x); math.Abs(y[0]-exact(x)) > 1e-7 {
+					t.Errorf("At(%v) = %v, want %v", x, y[0], exact(x))
+				}
+			}
+			for i := 1; i < len(sol.X); i++ {
+				if sol.X[i] <= sol.X[i-1] {
+					t.Fatalf("X[%d] = %v after %v", i, sol.X[i], sol.X[i-1])
+				}
+			}
+		})
+	}
+	if _, err := MultipleShoot(f, layerBC, []float64{0, 0.6, 0.5, 1}, guess, nil); err == nil {
+		t.Errorf("MultipleShoot() error = nil, want the nodes must increase")
+	}
+	if _, err := MultipleShoot(f, layerBC, []float64{0}, guess, nil); err == nil {
+		t.Errorf("MultipleShoot() error = nil, want at least 2 nodes")
+	}
+}
@@ -0,0 +1,206 @@
+package bvp
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/shyang107/gnum/nonlinear"
+	"github.com/shyang107/gnum/solveeqs/eigen"
+)
+
+// Boundary is the separated boundary condition A y + B y' = 0 at an end
+// of the interval; B = 0 is y = 0, and so is the zero value
+type Boundary struct {
+	A, B float64
+}
+
+// coef returns A and B, with A = 1 for the zero value
+func (bd Boundary) coef() (A, B float64) {
+	if bd.A == 0.0 && bd.B == 0.0 {
+		return 1.0, 0.0
+	}
+	return bd.A, bd.B
+}
+
+// SturmLiouville is the eigenvalue problem
+//	-(p y')' + q y = lambda w y,	a < x < b,
+// with the boundary conditions Left at a and Right at b, where p > 0 and
+// w > 0 on [a, b]. Its eigenvalues are real, simple and increase without
+// bound, and the k-th eigenfunction has k zeros in (a, b).
+type SturmLiouville struct {
+	P, Q, W     func(x float64) float64 // nil P and W are 1, nil Q is 0
+	A, B        float64
+	Left, Right Boundary
+}
+
+// coefs returns p, q and w at x
+func (sl *SturmLiouville) coefs(x float64) (p, q, w float64) {
+	p, q, w = 1.0, 0.0, 1.0
+	if sl.P != nil {
+		p = sl.P(x)
+	}
+	if sl.Q != nil {
+		q = sl.Q(x)
+	}
+	if sl.W != nil {
+		w = sl.W(x)
+	}
+	return p, q, w
+}
+
+// Eigen computes the k smallest eigenvalues and their eigenfunctions by
+// finite differences of order 2 on n equal intervals: the conservative
+// scheme with p at the midpoints and half cells at the ends with a
+// derivative condition gives the symmetric tridiagonal problem
+// K y = lambda M y with the diagonal M, solved by eigen.SymTridiag on
+// M^(-1/2) K M^(-1/2). The errors of the eigenvalues grow as k^4 h^2, so
+// n should be large compared with k.
+//
+// output
+//	lambda	: the eigenvalues in ascending order
+//	x	: the mesh a + i h, i = 0..n
+//	y	: y[j] is the eigenfunction of lambda[j] at x, normalized to
+//		  sum_i M_i y_i^2 = 1 (the integral of w y^2) and positive next
+//		  to a
+func (sl *SturmLiouville) Eigen(n, k int) (lambda, x []float64, y [][]float64, err error) {
+	if n < 2 {
+		return nil, nil, nil, fmt.Errorf("Eigen requires at least 2 intervals, got %d", n)
+	}
+	if sl.B <= sl.A {
+		return nil, nil, nil, fmt.Errorf("The interval [%g, %g] is empty", sl.A, sl.B)
+	}
+	h := (sl.B - sl.A) / float64(n)
+	x = make([]float64, n+1)
+	for i := range x {
+		x[i] = sl.A + float64(i)*h
+	}
+	x[n] = sl.B
+	//-----------------------------------------------------
+	// the unknowns i0..i1; the ends with y = 0 are not
+	//-----------------------------------------------------
+	la, lb := sl.Left.coef()
+	ra, rb := sl.Right.coef()
+	i0, i1 := 0, n
+	if lb == 0.0 {
+		i0 = 1
+	}
+	if rb == 0.0 {
+		i1 = n - 1
+	}
+	m := i1 - i0 + 1
+	if k < 1 || k > m {
+		return nil, nil, nil, fmt.Errorf("k = %d is not in [1, %d]", k, m)
+	}
+	d, e, mass := make([]float64, m), make([]float64, m-1), make([]float64, m)
+	for i := i0; i <= i1; i++ {
+		pi, qi, wi := sl.coefs(x[i])
+		cell := h
+		diag := 0.0
+		if i > 0 {
+			pm, _, _ := sl.coefs(x[i] - 0.5*h)
+			diag += pm / h
+		} else {
+			cell = 0.5 * h
+			diag -= pi * la / lb
+		}
+		if i < n {
+			pp, _, _ := sl.coefs(x[i] + 0.5*h)
+			diag += pp / h
+			if i < i1 {
+				e[i-i0] = -pp / h
+			}
+		} else {
+			cell = 0.5 * h
+			diag += pi * ra / rb
+		}
+		d[i-i0] = diag + qi*cell
+		mass[i-i0] = wi * cell
+	}
+	for i := 0; i < m; i++ {
+		d[i] /= mass[i]
+		if i < m-1 {
+			e[i] /= math.Sqrt(mass[i] * mass[i+1])
+		}
+	}
+	w, v, err := eigen.SymTridiag(d, e, true)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	lambda = w[:k]
+	y = make([][]float64, k)
+	for j := 0; j < k; j++ {
+		y[j] = make([]float64, n+1)
+		big := 0.0
+		for i := 0; i < m; i++ {
+			y[j][i+i0] = v.At(i, j) / math.Sqrt(mass[i])
+			big = math.Max(big, math.Abs(y[j][i+i0]))
+		}
+		for i := range y[j] {
+			if math.Abs(y[j][i]) > 1.0e-8*big {
+				if y[j][i] < 0.0 {
+					for l := range y[j] {
+						y[j][l] = -y[j][l]
+					}
+				}
+				break
+			}
+		}
+	}
+	return lambda, x, y, nil
+}
+
+// Shoot finds an eigenvalue in [lo, hi] by shooting: the solution of
+//	y' = z / p,	z' = (q - lambda w) y
+// from the condition Left at a, y(a) = 1 and y'(a) = -A/B or y(a) = 0
+// and y'(a) = 1, is integrated to b by s.Solver, and Brent's method
+// (nonlinear.Broot) finds lambda where the condition Right holds at b.
+// The condition must change sign over [lo, hi], as it does if the
+// bracket holds a single eigenvalue.
+//
+// s	: the settings, nil means the defaults; lambda is found to the
+//	  tolerance Tol max(|lo|, |hi|, 1)
+// output
+//	lambda	: the eigenvalue
+//	sol	: the eigenfunction (y, p y') at the steps, not normalized
+func (sl *SturmLiouville) Shoot(lo, hi float64, s *Settings) (lambda float64, sol *Solution, err error) {
+	if sl.B <= sl.A {
+		return 0.0, nil, fmt.Errorf("The interval [%g, %g] is empty", sl.A, sl.B)
+	}
+	st := s.defaults()
+	la, lb := sl.Left.coef()
+	ra, rb := sl.Right.coef()
+	pa, _, _ := sl.coefs(sl.A)
+	y0 := []float64{0.0, pa}
+	if lb != 0.0 {
+		y0 = []float64{1.0, -pa * la / lb}
+	}
+	system := func(lambda float64) func(x float64, y, dy []float64) {
+		return func(x float64, y, dy []float64) {
+			p, q, w := sl.coefs(x)
+			dy[0] = y[1] / p
+			dy[1] = (q - lambda*w) * y[0]
+		}
+	}
+	var ierr error
+	mismatch := func(lambda float64) float64 {
+		isol, err := st.Solver(system(lambda), sl.A, sl.B, y0, st.IVP)
+		if err != nil {
+			ierr = err
+			return math.NaN()
+		}
+		yb := isol.Y[len(isol.Y)-1]
+		pb, _, _ := sl.coefs(sl.B)
+		return ra*yb[0] + rb*yb[1]/pb
+	}
+	tol := st.Tol * math.Max(math.Max(math.Abs(lo), math.Abs(hi)), 1.0)
+	lambda, _, err = nonlinear.Broot(mismatch, lo, hi, tol, st.MaxIter)
+	if ierr != nil {
+		return lambda, nil, ierr
+	}
+	f := system(lambda)
+	isol, ierr := st.Solver(f, sl.A, sl.B, y0, st.IVP)
+	if ierr != nil {
+		return lambda, nil, ierr
+	}
+	return lambda, newSolution(f, isol.T, isol.Y), err
+}
@@ -0,0 +1,101 @@
+package bvp
+
+import (
+	"math"
+	"testing"
+)
+
+// the roots of tan x = x of the example of nonlinear.Sroot
+var tanRoots = []float64{4.4934094579090641753, 7.7252518369377071642}
+
+func TestSturmLiouvilleEigen(t *testing.T) {
+	tests := []struct {
+		name string
+		sl   SturmLiouville
+		want []float64
+	}{
+		{"y(0) = y(pi) = 0", SturmLiouville{B: math.Pi}, []float64{1, 4, 9, 16}},
+		{"Neumann", SturmLiouville{B: 1, Left: Boundary{B: 1}, Right: Boundary{B: 1}},
+			[]float64{0, math.Pi * math.Pi, 4 * math.Pi * math.Pi}},
+		// sin(k x) with tan k = k, and y = x for lambda = 0
+		{"y(1) = y'(1)", SturmLiouville{B: 1, Right: Boundary{A: 1, B: -1}},
+			[]float64{0, tanRoots[0] * tanRoots[0], tanRoots[1] * tanRoots[1]}},
+		{"weight 4", SturmLiouville{B: math.Pi, W: func(x float64) float64 { return 4 }}, []float64{0.25, 1, 2.25}},
+		// -((1 + x)^2 y')' = lambda y on [0, 1]: y = (1 + x)^(-1/2) sin(k pi ln(1 + x) / ln 2)
+		{"variable p", SturmLiouville{B: 1, P: func(x float64) float64 { return (1 + x) * (1 + x) }},
+			[]float64{0.25 + math.Pow(math.Pi/math.Ln2, 2), 0.25 + math.Pow(2*math.Pi/math.Ln2, 2)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k := len(tt.want)
+			lambda, x, y, err := tt.sl.Eigen(400, k)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for j, w := range tt.want {
+				if math.Abs(lambda[j]-w) > 1e-3*math.Max(w, 1) {
+					t.Errorf("lambda[%d] = %v, want %v", j, lambda[j], w)
+				}
+			}
+			// the j-th eigenfunction has j sign changes and starts positive
+			for j := range y {
+				changes, first := 0, 0.0
+				for i := 1; i < len(x); i++ {
+					if y[j][i]*y[j][i-1] < 0 {
+						changes++
+					}
+					if first == 0 {
+						first = y[j][i]
+					}
+				}
+				if changes != j || first <= 0 {
+					t.Errorf("y[%d] has %d sign changes, starts with %v", j, changes, first)
+				}
+			}
+		})
+	}
+	// O(h^2)
+	sl := SturmLiouville{B: math.Pi}
+	l1, _, _, _ := sl.Eigen(50, 1)
+	l2, _, _, _ := sl.Eigen(100, 1)
+	if r := (l1[0] - 1) / (l2[0] - 1); math.Abs(r-4) > 0.1 {
+		t.Errorf("error ratio %v, want about 4", r)
+	}
+	if _, _, _, err := sl.Eigen(10, 10); err == nil {
+		t.Errorf("Eigen(10, 10) error = nil, want k out of range")
+	}
+}
+
+func TestSturmLiouvilleShoot(t *testing.T) {
+	tests := []struct {
+		name   string
+		sl     SturmLiouville
+		lo, hi float64
+		want   float64
+	}{
+		{"y(0) = y(pi) = 0", SturmLiouville{B: math.Pi}, 3, 5, 4},
+		{"y(1) = y'(1)", SturmLiouville{B: 1, Right: Boundary{A: 1, B: -1}}, 10, 30, tanRoots[0] * tanRoots[0]},
+		{"y(1) = y'(1), second", SturmLiouville{B: 1, Right: Boundary{A: 1, B: -1}}, 30, 80, tanRoots[1] * tanRoots[1]},
+		{"Neumann", SturmLiouville{B: 1, Left: Boundary{B: 1}, Right: Boundary{B: 1}}, 5, 15, math.Pi * math.Pi},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lambda, sol, err := tt.sl.Shoot(tt.lo, tt.hi, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if math.Abs(lambda-tt.want) > 1e-6*tt.want {
+				t.Errorf("lambda = %v, want %v", lambda, tt.want)
+			}
+			ra, rb := tt.sl.Right.coef()
+			yb := sol.Y[len(sol.Y)-1]
+			if r := ra*yb[0] + rb*yb[1]; math.Abs(r) > 1e-6 {
+				t.Errorf("right condition %v, want 0", r)
+			}
+		})
+	}
+	sl := SturmLiouville{B: math.Pi}
+	if _, _, err := sl.Shoot(5, 8, nil); err == nil {
+		t.Errorf("Shoot(5, 8) error = nil, want no eigenvalue")
+	}
+}
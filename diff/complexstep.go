@@ -0,0 +1,12 @@
+package diff
+
+// ComplexStep returns the first derivative of f at x by the complex step
+//	f'(x) = Im f(x + i h) / h + O(h^2)
+// with h = 1e-20. Nothing is subtracted, so there is no cancellation and
+// the result is accurate to rounding. f must be the analytic extension of
+// a real function written with complex arithmetic (math/cmplx); abs and
+// comparisons break it.
+func ComplexStep(f func(complex128) complex128, x float64) float64 {
+	const h = 1.0e-20
+	return imag(f(complex(x, h))) / h
+}
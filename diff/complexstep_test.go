@@ -0,0 +1,31 @@
+package diff
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func TestComplexStep(t *testing.T) {
+	tests := []struct {
+		name string
+		f    func(complex128) complex128
+		x    float64
+		want float64
+	}{
+		{"exp", cmplx.Exp, 1, math.E},
+		{"sin", cmplx.Sin, 0.5, math.Cos(0.5)},
+		// the classic test function of Squire and Trapp
+		{"squire trapp", func(z complex128) complex128 {
+			return cmplx.Exp(z) / cmplx.Sqrt(cmplx.Pow(cmplx.Sin(z), 3)+cmplx.Pow(cmplx.Cos(z), 3))
+		}, 1.5, 4.0534278938986201},
+		{"small x", func(z complex128) complex128 { return z * z * z }, 1e-8, 3e-16},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ComplexStep(tt.f, tt.x); math.Abs(got-tt.want) > 1e-14*math.Abs(tt.want) {
+				t.Errorf("ComplexStep() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
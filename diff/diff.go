@@ -0,0 +1,203 @@
+// Package diff differentiates functions numerically: finite differences
+// of any order and accuracy on central, forward and backward stencils,
+// derivatives extrapolated by Ridders' method with an error estimate,
+// and gradients, Jacobians and Hessians of multivariate functions.
+package diff
+
+import (
+	"fmt"
+	"math"
+)
+
+// Scheme is the placement of the nodes of a finite difference
+type Scheme int
+
+const (
+	// Central uses the nodes x + k h, k = -K, ..., K
+	Central Scheme = iota
+	// Forward uses the nodes x + k h, k = 0, ..., K, for a function
+	// defined only to the right of x
+	Forward
+	// Backward uses the nodes x - k h, k = 0, ..., K
+	Backward
+)
+
+// String returns the name of the scheme
+func (s Scheme) String() string {
+	switch s {
+	case Central:
+		return "Central"
+	case Forward:
+		return "Forward"
+	case Backward:
+		return "Backward"
+	}
+	return fmt.Sprintf("Scheme(%d)", int(s))
+}
+
+// Settings controls the finite differences; the zero value uses the
+// defaults
+type Settings struct {
+	Scheme Scheme // the stencil (default Central)
+	// Accuracy is the order p of the truncation error O(h^p) (default 2,
+	// and rounded up to even for Central)
+	Accuracy int
+	// Step is the step h; the default eps^(1/(m+p)) max(|x|, 1) balances
+	// the truncation error against the rounding error eps / h^m of the
+	// m-th derivative
+	Step float64
+}
+
+// defaults returns a copy of s with the defaults filled in
+func (s *Settings) defaults() (Settings, error) {
+	var d Settings
+	if s != nil {
+		d = *s
+	}
+	if d.Scheme < Central || d.Scheme > Backward {
+		return d, fmt.Errorf("Unknown scheme %v", d.Scheme)
+	}
+	if d.Accuracy <= 0 {
+		d.Accuracy = 2
+	}
+	if d.Scheme == Central && d.Accuracy%2 == 1 {
+		d.Accuracy++
+	}
+	if d.Step < 0.0 || math.IsNaN(d.Step) {
+		return d, fmt.Errorf("Step = %g, want >= 0", d.Step)
+	}
+	return d, nil
+}
+
+// step returns the step of the m-th derivative at x, rounded so that
+// x + h is exact
+func (s *Settings) step(x float64, m int) float64 {
+	h := s.Step
+	if h == 0.0 {
+		h = math.Pow(eps, 1.0/float64(m+s.Accuracy)) * math.Max(math.Abs(x), 1.0)
+	}
+	if t := (x + h) - x; t != 0.0 {
+		h = t
+	}
+	return h
+}
+
+// eps is the machine epsilon
+const eps = 2.220446049250313e-16
+
+// nodes returns the offsets k of the stencil of the m-th derivative
+func (s *Settings) nodes(m int) []float64 {
+	var k []float64
+	switch s.Scheme {
+	case Central:
+		half := (m+1)/2 - 1 + s.Accuracy/2
+		for i := -half; i <= half; i++ {
+			k = append(k, float64(i))
+		}
+	case Forward:
+		for i := 0; i < m+s.Accuracy; i++ {
+			k = append(k, float64(i))
+		}
+	case Backward:
+		for i := 0; i < m+s.Accuracy; i++ {
+			k = append(k, -float64(i))
+		}
+	}
+	return k
+}
+
+// Stencil returns the weights c_k of the m-th derivative
+//	f^(m)(x) ~ sum_k c_k f(x + nodes[k] h) / h^m
+// by Fornberg's algorithm, exact for polynomials of degree
+// len(nodes) - 1. The nodes must be distinct and more than m.
+//
+// m	: the order of the derivative
+// nodes	: the offsets of the nodes in units of h
+func Stencil(m int, nodes []float64) ([]float64, error) {
+	n := len(nodes)
+	if m < 0 {
+		return nil, fmt.Errorf("m = %d, want >= 0", m)
+	}
+	if n <= m {
+		return nil, fmt.Errorf("%d nodes are too few for the derivative of order %d", n, m)
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j < i; j++ {
+			if nodes[i] == nodes[j] {
+				return nil, fmt.Errorf("Duplicate node %g", nodes[i])
+			}
+		}
+	}
+	//-----------------------------------------------------
+	// c[j][k]: the weight of node j for the k-th derivative
+	// on the first i+1 nodes
+	//-----------------------------------------------------
+	c := make([][]float64, n)
+	for j := range c {
+		c[j] = make([]float64, m+1)
+	}
+	c[0][0] = 1.0
+	c1, c4 := 1.0, nodes[0]
+	for i := 1; i < n; i++ {
+		mn := i
+		if mn > m {
+			mn = m
+		}
+		c2, c5 := 1.0, c4
+		c4 = nodes[i]
+		for j := 0; j < i; j++ {
+			c3 := nodes[i] - nodes[j]
+			c2 *= c3
+			if j == i-1 {
+				for k := mn; k >= 1; k-- {
+					c[i][k] = c1 * (float64(k)*c[i-1][k-1] - c5*c[i-1][k]) / c2
+				}
+				c[i][0] = -c1 * c5 * c[i-1][0] / c2
+			}
+			for k := mn; k >= 1; k-- {
+				c[j][k] = (c4*c[j][k] - float64(k)*c[j][k-1]) / c3
+			}
+			c[j][0] = c4 * c[j][0] / c3
+		}
+		c1 = c2
+	}
+	w := make([]float64, n)
+	for j := range w {
+		w[j] = c[j][m]
+	}
+	return w, nil
+}
+
+// Derivative returns the m-th derivative of f at x by the finite
+// difference of the scheme and accuracy of s. Nodes of zero weight, like
+// x itself for odd central derivatives, are not evaluated.
+//
+// m	: the order of the derivative, >= 1
+// s	: the settings, nil means the defaults
+func Derivative(f func(float64) float64, x float64, m int, s *Settings) (float64, error) {
+	if m < 1 {
+		return 0.0, fmt.Errorf("m = %d, want >= 1", m)
+	}
+	st, err := s.defaults()
+	if err != nil {
+		return 0.0, err
+	}
+	h := st.step(x, m)
+	k := st.nodes(m)
+	return apply(f, x, m, h, k)
+}
+
+// apply returns sum_k c_k f(x + k h) / h^m
+func apply(f func(float64) float64, x float64, m int, h float64, k []float64) (float64, error) {
+	c, err := Stencil(m, k)
+	if err != nil {
+		return 0.0, err
+	}
+	d := 0.0
+	for j, cj := range c {
+		if math.Abs(cj) > 1.0e-12 {
+			d += cj * f(x+k[j]*h)
+		}
+	}
+	return d / math.Pow(h, float64(m)), nil
+}
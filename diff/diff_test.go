@@ -0,0 +1,128 @@
+package diff
+
+import (
+	"math"
+	"testing"
+)
+
+func TestStencil(t *testing.T) {
+	tests := []struct {
+		name    string
+		m       int
+		nodes   []float64
+		want    []float64
+		wantErr bool
+	}{
+		{"central first", 1, []float64{-1, 0, 1}, []float64{-0.5, 0, 0.5}, false},
+		{"central second", 2, []float64{-1, 0, 1}, []float64{1, -2, 1}, false},
+		{"central first order 4", 1, []float64{-2, -1, 0, 1, 2}, []float64{1.0 / 12, -2.0 / 3, 0, 2.0 / 3, -1.0 / 12}, false},
+		{"forward first", 1, []float64{0, 1}, []float64{-1, 1}, false},
+		{"forward first order 2", 1, []float64{0, 1, 2}, []float64{-1.5, 2, -0.5}, false},
+		{"backward second", 2, []float64{0, -1, -2}, []float64{1, -2, 1}, false},
+		{"interpolation", 0, []float64{-1, 1}, []float64{0.5, 0.5}, false},
+		{"too few", 2, []float64{0, 1}, nil, true},
+		{"duplicate", 1, []float64{0, 1, 1}, nil, true},
+		{"negative order", -1, []float64{0, 1}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Stencil(tt.m, tt.nodes)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Stencil() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for i := range tt.want {
+				if math.Abs(got[i]-tt.want[i]) > 1e-14 {
+					t.Errorf("Stencil() = %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestDerivative(t *testing.T) {
+	// d^m/dx^m exp(x) = exp(x), and sin cycles through cos, -sin, -cos
+	sinDeriv := []func(float64) float64{
+		math.Sin, math.Cos,
+		func(x float64) float64 { return -math.Sin(x) },
+		func(x float64) float64 { return -math.Cos(x) },
+	}
+	tests := []struct {
+		name string
+		m    int
+		s    *Settings
+		tol  float64
+	}{
+		{"central", 1, nil, 1e-9},
+		{"central order 4", 1, &Settings{Accuracy: 4}, 1e-11},
+		{"central odd accuracy", 1, &Settings{Accuracy: 3}, 1e-11},
+		{"forward", 1, &Settings{Scheme: Forward, Accuracy: 1}, 1e-7},
+		{"forward order 3", 1, &Settings{Scheme: Forward, Accuracy: 3}, 1e-10},
+		{"backward order 2", 1, &Settings{Scheme: Backward}, 1e-9},
+		{"second", 2, nil, 1e-6},
+		{"second order 6", 2, &Settings{Accuracy: 6}, 1e-9},
+		{"third", 3, nil, 1e-4},
+		{"fourth order 4", 4, &Settings{Accuracy: 4}, 1e-2},
+		{"given step", 1, &Settings{Step: 1e-3, Accuracy: 6}, 1e-12},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, x := range []float64{-1.3, 0, 0.7, 2.5} {
+				got, err := Derivative(math.Exp, x, tt.m, tt.s)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if want := math.Exp(x); math.Abs(got-want) > tt.tol*want {
+					t.Errorf("exp: Derivative(%v) = %v, want %v", x, got, want)
+				}
+				got, _ = Derivative(math.Sin, x, tt.m, tt.s)
+				if want := sinDeriv[tt.m%4](x); math.Abs(got-want) > tt.tol*math.Max(math.Abs(x), 1) {
+					t.Errorf("sin: Derivative(%v) = %v, want %v", x, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestDerivativeNodes(t *testing.T) {
+	// the central first derivative does not evaluate f(x), and a forward
+	// difference evaluates f only at x and to the right of it
+	var calls []float64
+	f := func(x float64) float64 { calls = append(calls, x); return x * x }
+	Derivative(f, 1, 1, nil)
+	if len(calls) != 2 || calls[0] == 1 || calls[1] == 1 {
+		t.Errorf("central evaluated f at %v", calls)
+	}
+	calls = nil
+	Derivative(f, 1, 2, &Settings{Scheme: Forward})
+	if len(calls) != 4 {
+		t.Errorf("forward evaluated f at %v, want 4 nodes", calls)
+	}
+	for _, x := range calls {
+		if x < 1 {
+			t.Errorf("forward evaluated f at %v < 1", x)
+		}
+	}
+}
+
+func TestDerivativeErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		m    int
+		s    *Settings
+	}{
+		{"order", 0, nil},
+		{"scheme", 1, &Settings{Scheme: Scheme(5)}},
+		{"step", 1, &Settings{Step: -1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Derivative(math.Sin, 1, tt.m, tt.s); err == nil {
+				t.Errorf("Derivative() error = nil, want an error")
+			}
+		})
+	}
+	if Scheme(5).String() != "Scheme(5)" || Backward.String() != "Backward" {
+		t.Errorf("String() = %v, %v", Scheme(5), Backward)
+	}
+}
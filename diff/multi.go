@@ -0,0 +1,119 @@
+package diff
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/shyang107/gnum"
+)
+
+// Gradient returns the gradient of f at x by the finite differences of
+// s along each coordinate, the step of x_i scaled by max(|x_i|, 1) unless
+// s.Step is given. x is not modified.
+//
+// s	: the settings, nil means the defaults
+func Gradient(f func([]float64) float64, x []float64, s *Settings) ([]float64, error) {
+	st, err := s.defaults()
+	if err != nil {
+		return nil, err
+	}
+	k := st.nodes(1)
+	xt := append([]float64(nil), x...)
+	g := make([]float64, len(x))
+	for i := range x {
+		fi := func(t float64) float64 {
+			xt[i] = t
+			v := f(xt)
+			xt[i] = x[i]
+			return v
+		}
+		if g[i], err = apply(fi, x[i], 1, st.step(x[i], 1), k); err != nil {
+			return nil, err
+		}
+	}
+	return g, nil
+}
+
+// Jacobian returns the m x n Jacobian J_ij = df_i/dx_j of f at x by the
+// finite differences of s, a column per coordinate of x. x is not
+// modified.
+//
+// f	: sets fx = f(x), len(fx) = m
+// m	: the number of components of f
+// s	: the settings, nil means the defaults
+func Jacobian(f func(fx, x []float64), x []float64, m int, s *Settings) (*gnum.Matrix, error) {
+	st, err := s.defaults()
+	if err != nil {
+		return nil, err
+	}
+	if m < 1 {
+		return nil, fmt.Errorf("m = %d, want >= 1", m)
+	}
+	k := st.nodes(1)
+	c, err := Stencil(1, k)
+	if err != nil {
+		return nil, err
+	}
+	n := len(x)
+	jac := gnum.NewMatrix(m, n, nil)
+	xt := append([]float64(nil), x...)
+	fx := make([]float64, m)
+	for j := 0; j < n; j++ {
+		h := st.step(x[j], 1)
+		for l, cl := range c {
+			if math.Abs(cl) <= 1.0e-12 {
+				continue
+			}
+			xt[j] = x[j] + k[l]*h
+			f(fx, xt)
+			for i := 0; i < m; i++ {
+				jac.Set(i, j, jac.At(i, j)+cl*fx[i]/h)
+			}
+		}
+		xt[j] = x[j]
+	}
+	return jac, nil
+}
+
+// Hessian returns the symmetric Hessian of f at x by central differences
+// of second order,
+//	H_ii = (f(x + h_i e_i) - 2 f(x) + f(x - h_i e_i)) / h_i^2
+//	H_ij = (f(x + h_i e_i + h_j e_j) - f(x + h_i e_i - h_j e_j)
+//		- f(x - h_i e_i + h_j e_j) + f(x - h_i e_i - h_j e_j)) / (4 h_i h_j)
+// with h_i = eps^(1/4) max(|x_i|, 1), or s.Step; the scheme and the
+// accuracy of s are not used. x is not modified.
+//
+// s	: the settings, nil means the defaults
+func Hessian(f func([]float64) float64, x []float64, s *Settings) (*gnum.Matrix, error) {
+	st, err := s.defaults()
+	if err != nil {
+		return nil, err
+	}
+	st.Scheme, st.Accuracy = Central, 2
+	n := len(x)
+	h := make([]float64, n)
+	for i := range h {
+		h[i] = st.step(x[i], 2)
+	}
+	xt := append([]float64(nil), x...)
+	at := func(i int, di float64, j int, dj float64) float64 {
+		xt[i] += di
+		xt[j] += dj
+		v := f(xt)
+		xt[i], xt[j] = x[i], x[j]
+		return v
+	}
+	f0 := f(xt)
+	hess := gnum.NewMatrix(n, n, nil)
+	for i := 0; i < n; i++ {
+		fp, fm := at(i, h[i], i, 0.0), at(i, -h[i], i, 0.0)
+		hess.Set(i, i, (fp-2.0*f0+fm)/(h[i]*h[i]))
+		for j := 0; j < i; j++ {
+			v := (at(i, h[i], j, h[j]) - at(i, h[i], j, -h[j]) -
+				at(i, -h[i], j, h[j]) + at(i, -h[i], j, -h[j])) / (4.0 * h[i] * h[j])
+			hess.Set(i, j, v)
+			hess.Set(j, i, v)
+		}
+	}
+	return hess, nil
+}
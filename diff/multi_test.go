@@ -0,0 +1,110 @@
+package diff
+
+import (
+	"math"
+	"testing"
+)
+
+// rosenbrock is (1 - x)^2 + 100 (y - x^2)^2
+func rosenbrock(x []float64) float64 {
+	return (1-x[0])*(1-x[0]) + 100*(x[1]-x[0]*x[0])*(x[1]-x[0]*x[0])
+}
+
+func TestGradient(t *testing.T) {
+	x := []float64{-1.2, 1}
+	want := []float64{
+		-2*(1-x[0]) - 400*x[0]*(x[1]-x[0]*x[0]),
+		200 * (x[1] - x[0]*x[0]),
+	}
+	tests := []struct {
+		name string
+		s    *Settings
+		tol  float64
+	}{
+		{"central", nil, 1e-8},
+		{"forward", &Settings{Scheme: Forward, Accuracy: 1}, 1e-5},
+		{"central order 4", &Settings{Accuracy: 4}, 1e-10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := Gradient(rosenbrock, x, tt.s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := range want {
+				if math.Abs(g[i]-want[i]) > tt.tol*math.Abs(want[i]) {
+					t.Errorf("Gradient()[%d] = %v, want %v", i, g[i], want[i])
+				}
+			}
+			if x[0] != -1.2 || x[1] != 1 {
+				t.Errorf("x modified to %v", x)
+			}
+		})
+	}
+	if _, err := Gradient(rosenbrock, x, &Settings{Scheme: -1}); err == nil {
+		t.Errorf("Gradient() error = nil, want an error")
+	}
+}
+
+func TestJacobian(t *testing.T) {
+	// f = (x y z, sin x + y^2, exp(z))
+	f := func(fx, x []float64) {
+		fx[0] = x[0] * x[1] * x[2]
+		fx[1] = math.Sin(x[0]) + x[1]*x[1]
+		fx[2] = math.Exp(x[2])
+	}
+	x := []float64{0.5, -2, 1.5}
+	want := [][]float64{
+		{x[1] * x[2], x[0] * x[2], x[0] * x[1]},
+		{math.Cos(x[0]), 2 * x[1], 0},
+		{0, 0, math.Exp(x[2])},
+	}
+	for _, s := range []*Settings{nil, {Scheme: Backward}, {Accuracy: 6}} {
+		jac, err := Jacobian(f, x, 3, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := range want {
+			for j := range want[i] {
+				if math.Abs(jac.At(i, j)-want[i][j]) > 1e-7*math.Max(math.Abs(want[i][j]), 1) {
+					t.Errorf("%+v: J(%d,%d) = %v, want %v", s, i, j, jac.At(i, j), want[i][j])
+				}
+			}
+		}
+	}
+	if _, err := Jacobian(f, x, 0, nil); err == nil {
+		t.Errorf("Jacobian() error = nil for m = 0")
+	}
+}
+
+func TestHessian(t *testing.T) {
+	x := []float64{-1.2, 1}
+	want := [][]float64{
+		{2 - 400*x[1] + 1200*x[0]*x[0], -400 * x[0]},
+		{-400 * x[0], 200},
+	}
+	h, err := Hessian(rosenbrock, x, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range want {
+		for j := range want[i] {
+			if math.Abs(h.At(i, j)-want[i][j]) > 1e-5*math.Abs(want[i][j]) {
+				t.Errorf("H(%d,%d) = %v, want %v", i, j, h.At(i, j), want[i][j])
+			}
+		}
+	}
+	if h.At(0, 1) != h.At(1, 0) {
+		t.Errorf("H not symmetric: %v", h)
+	}
+	// a quadratic has the exact Hessian for any step
+	q := func(x []float64) float64 { return 3*x[0]*x[0] + 2*x[0]*x[1] - x[1]*x[1] + x[2] }
+	h, _ = Hessian(q, []float64{1, 2, 3}, &Settings{Step: 0.5})
+	for i, row := range [][]float64{{6, 2, 0}, {2, -2, 0}, {0, 0, 0}} {
+		for j, v := range row {
+			if math.Abs(h.At(i, j)-v) > 1e-12 {
+				t.Errorf("quadratic: H(%d,%d) = %v, want %v", i, j, h.At(i, j), v)
+			}
+		}
+	}
+}
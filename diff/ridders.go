@@ -0,0 +1,63 @@
+package diff
+
+import (
+	"fmt"
+	"math"
+)
+
+// Ridders returns the m-th derivative of f at x and an estimate of its
+// error by Ridders' method: the second order central difference is
+// evaluated with the steps h, h/1.4, h/1.4^2, ... and extrapolated to
+// h -> 0 by Richardson's scheme in h^2 (Neville's tableau), as Romberg
+// integration extrapolates the trapezoidal rule. The tableau is stopped
+// when a new row is worse than the best estimate by a factor of 2, when
+// rounding starts to dominate. The initial h need not be small, but f
+// should not change much more than a polynomial over [x - h, x + h].
+//
+// m	: the order of the derivative, >= 1
+// h	: the initial step, <= 0 means 0.1 max(|x|, 1)
+// output
+//	d	: the derivative
+//	errEst	: the estimated absolute error of d
+func Ridders(f func(float64) float64, x float64, m int, h float64) (d, errEst float64, err error) {
+	const (
+		con  = 1.4
+		con2 = con * con
+		ntab = 10
+		safe = 2.0
+	)
+	if m < 1 {
+		return 0.0, 0.0, fmt.Errorf("m = %d, want >= 1", m)
+	}
+	if h <= 0.0 {
+		h = 0.1 * math.Max(math.Abs(x), 1.0)
+	}
+	s := &Settings{Scheme: Central, Accuracy: 2}
+	k := s.nodes(m)
+	var a [ntab][ntab]float64
+	if a[0][0], err = apply(f, x, m, h, k); err != nil {
+		return 0.0, 0.0, err
+	}
+	d = a[0][0]
+	errEst = math.Inf(1)
+	for i := 1; i < ntab; i++ {
+		h /= con
+		a[0][i], _ = apply(f, x, m, h, k)
+		fac := con2
+		for j := 1; j <= i; j++ {
+			//-----------------------------------------------------
+			// extrapolate the order 2j-2 estimates to order 2j
+			//-----------------------------------------------------
+			a[j][i] = (a[j-1][i]*fac - a[j-1][i-1]) / (fac - 1.0)
+			fac *= con2
+			e := math.Max(math.Abs(a[j][i]-a[j-1][i]), math.Abs(a[j][i]-a[j-1][i-1]))
+			if e <= errEst {
+				errEst, d = e, a[j][i]
+			}
+		}
+		if math.Abs(a[i][i]-a[i-1][i-1]) >= safe*errEst {
+			break
+		}
+	}
+	return d, errEst, nil
+}
@@ -0,0 +1,54 @@
+package diff
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRidders(t *testing.T) {
+	tests := []struct {
+		name string
+		f    func(float64) float64
+		x    float64
+		m    int
+		h    float64
+		want float64
+		tol  float64
+	}{
+		{"exp", math.Exp, 1, 1, 0, math.E, 1e-12},
+		{"exp second", math.Exp, 1, 2, 0, math.E, 1e-10},
+		{"exp third", math.Exp, 1, 3, 0, math.E, 1e-8},
+		{"sin large step", math.Sin, 0.5, 1, 1, math.Cos(0.5), 1e-12},
+		{"log", math.Log, 2, 1, 0, 0.5, 1e-12},
+		{"atan second", math.Atan, 0.3, 2, 0, -2 * 0.3 / math.Pow(1+0.09, 2), 1e-10},
+		{"large x", math.Sqrt, 1e4, 1, 0, 0.5e-2, 1e-13},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, e, err := Ridders(tt.f, tt.x, tt.m, tt.h)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if math.Abs(got-tt.want) > tt.tol*math.Max(math.Abs(tt.want), 1) {
+				t.Errorf("Ridders() = %v, want %v", got, tt.want)
+			}
+			// the estimate bounds the error up to a small factor
+			if e <= 0 || math.Abs(got-tt.want) > 10*e+1e-15 {
+				t.Errorf("Ridders() error estimate %v, actual %v", e, math.Abs(got-tt.want))
+			}
+		})
+	}
+	if _, _, err := Ridders(math.Exp, 1, 0, 0); err == nil {
+		t.Errorf("Ridders() error = nil for m = 0")
+	}
+}
+
+func TestRiddersBetterThanDifference(t *testing.T) {
+	// extrapolation beats the best plain central difference
+	x := 0.8
+	d, _, _ := Ridders(math.Exp, x, 1, 0)
+	c, _ := Derivative(math.Exp, x, 1, nil)
+	if math.Abs(d-math.Exp(x)) >= math.Abs(c-math.Exp(x)) {
+		t.Errorf("Ridders error %v, central difference %v", math.Abs(d-math.Exp(x)), math.Abs(c-math.Exp(x)))
+	}
+}
@@ -0,0 +1,208 @@
+package gnum
+
+import "math"
+
+// Number is the arithmetic of a real scalar type T. A function written
+// once against it,
+//
+//	func f[T gnum.Number[T]](x T) T { return x.Tan().Sub(x) }
+//
+// is evaluated by f[gnum.Float] and differentiated exactly, without
+// truncation error, by f[gnum.Dual], see DualDeriv.
+type Number[T any] interface {
+	Value() float64   // the real value
+	Const(c float64) T // the constant c of the type of the receiver
+	Add(y T) T
+	Sub(y T) T
+	Mul(y T) T
+	Div(y T) T
+	Neg() T
+	Scale(c float64) T // c times the receiver
+	Pow(p float64) T   // the receiver to the real power p
+	Abs() T
+	Sqrt() T
+	Exp() T
+	Log() T
+	Sin() T
+	Cos() T
+	Tan() T
+	Atan() T
+	Sinh() T
+	Cosh() T
+	Tanh() T
+}
+
+// Float is float64 as a Number
+type Float float64
+
+// Value returns x
+func (x Float) Value() float64 { return float64(x) }
+
+// Const returns c
+func (x Float) Const(c float64) Float { return Float(c) }
+
+// Add returns x + y
+func (x Float) Add(y Float) Float { return x + y }
+
+// Sub returns x - y
+func (x Float) Sub(y Float) Float { return x - y }
+
+// Mul returns x y
+func (x Float) Mul(y Float) Float { return x * y }
+
+// Div returns x / y
+func (x Float) Div(y Float) Float { return x / y }
+
+// Neg returns -x
+func (x Float) Neg() Float { return -x }
+
+// Scale returns c x
+func (x Float) Scale(c float64) Float { return Float(c) * x }
+
+// Pow returns x^p
+func (x Float) Pow(p float64) Float { return Float(math.Pow(float64(x), p)) }
+
+// Abs returns |x|
+func (x Float) Abs() Float { return Float(math.Abs(float64(x))) }
+
+// Sqrt returns sqrt(x)
+func (x Float) Sqrt() Float { return Float(math.Sqrt(float64(x))) }
+
+// Exp returns exp(x)
+func (x Float) Exp() Float { return Float(math.Exp(float64(x))) }
+
+// Log returns log(x)
+func (x Float) Log() Float { return Float(math.Log(float64(x))) }
+
+// Sin returns sin(x)
+func (x Float) Sin() Float { return Float(math.Sin(float64(x))) }
+
+// Cos returns cos(x)
+func (x Float) Cos() Float { return Float(math.Cos(float64(x))) }
+
+// Tan returns tan(x)
+func (x Float) Tan() Float { return Float(math.Tan(float64(x))) }
+
+// Atan returns atan(x)
+func (x Float) Atan() Float { return Float(math.Atan(float64(x))) }
+
+// Sinh returns sinh(x)
+func (x Float) Sinh() Float { return Float(math.Sinh(float64(x))) }
+
+// Cosh returns cosh(x)
+func (x Float) Cosh() Float { return Float(math.Cosh(float64(x))) }
+
+// Tanh returns tanh(x)
+func (x Float) Tanh() Float { return Float(math.Tanh(float64(x))) }
+
+// Dual is the dual number Val + Der e with e^2 = 0. Since
+// f(a + b e) = f(a) + f'(a) b e, evaluating f at Variable(x) carries the
+// exact derivative f'(x) along with f(x): forward mode automatic
+// differentiation.
+type Dual struct {
+	Val, Der float64
+}
+
+// Variable returns the dual number x + e of the independent variable
+func Variable(x float64) Dual {
+	return Dual{x, 1.0}
+}
+
+// DualDeriv returns f(x) and the exact derivative f'(x)
+func DualDeriv(f func(Dual) Dual, x float64) (fx, dfx float64) {
+	d := f(Variable(x))
+	return d.Val, d.Der
+}
+
+// chain returns f(x) with the derivative df * x.Der
+func (x Dual) chain(f, df float64) Dual {
+	return Dual{f, df * x.Der}
+}
+
+// Value returns the real part of x
+func (x Dual) Value() float64 { return x.Val }
+
+// Const returns the constant c
+func (x Dual) Const(c float64) Dual { return Dual{c, 0.0} }
+
+// Add returns x + y
+func (x Dual) Add(y Dual) Dual { return Dual{x.Val + y.Val, x.Der + y.Der} }
+
+// Sub returns x - y
+func (x Dual) Sub(y Dual) Dual { return Dual{x.Val - y.Val, x.Der - y.Der} }
+
+// Mul returns x y
+func (x Dual) Mul(y Dual) Dual { return Dual{x.Val * y.Val, x.Der*y.Val + x.Val*y.Der} }
+
+// Div returns x / y
+func (x Dual) Div(y Dual) Dual {
+	q := x.Val / y.Val
+	return Dual{q, (x.Der - q*y.Der) / y.Val}
+}
+
+// Neg returns -x
+func (x Dual) Neg() Dual { return Dual{-x.Val, -x.Der} }
+
+// Scale returns c x
+func (x Dual) Scale(c float64) Dual { return Dual{c * x.Val, c * x.Der} }
+
+// Pow returns x^p
+func (x Dual) Pow(p float64) Dual {
+	if p == 0.0 {
+		return Dual{1.0, 0.0}
+	}
+	return x.chain(math.Pow(x.Val, p), p*math.Pow(x.Val, p-1.0))
+}
+
+// Abs returns |x|; the derivative at 0 is taken as 0
+func (x Dual) Abs() Dual {
+	switch {
+	case x.Val > 0.0:
+		return x
+	case x.Val < 0.0:
+		return x.Neg()
+	}
+	return Dual{0.0, 0.0}
+}
+
+// Sqrt returns sqrt(x)
+func (x Dual) Sqrt() Dual {
+	s := math.Sqrt(x.Val)
+	return x.chain(s, 0.5/s)
+}
+
+// Exp returns exp(x)
+func (x Dual) Exp() Dual {
+	e := math.Exp(x.Val)
+	return x.chain(e, e)
+}
+
+// Log returns log(x)
+func (x Dual) Log() Dual { return x.chain(math.Log(x.Val), 1.0/x.Val) }
+
+// Sin returns sin(x)
+func (x Dual) Sin() Dual { return x.chain(math.Sin(x.Val), math.Cos(x.Val)) }
+
+// Cos returns cos(x)
+func (x Dual) Cos() Dual { return x.chain(math.Cos(x.Val), -math.Sin(x.Val)) }
+
+// Tan returns tan(x)
+func (x Dual) Tan() Dual {
+	t := math.Tan(x.Val)
+	return x.chain(t, 1.0+t*t)
+}
+
+// Atan returns atan(x)
+func (x Dual) Atan() Dual { return x.chain(math.Atan(x.Val), 1.0/(1.0+x.Val*x.Val)) }
+
+// Sinh returns sinh(x)
+func (x Dual) Sinh() Dual { return x.chain(math.Sinh(x.Val), math.Cosh(x.Val)) }
+
+// Cosh returns cosh(x)
+func (x Dual) Cosh() Dual { return x.chain(math.Cosh(x.Val), math.Sinh(x.Val)) }
+
+// Tanh returns tanh(x)
+func (x Dual) Tanh() Dual {
+	t := math.Tanh(x.Val)
+	return x.chain(t, 1.0-t*t)
+}
@@ -0,0 +1,81 @@
+package gnum
+
+import (
+	"math"
+	"testing"
+)
+
+var (
+	_ Number[Float] = Float(0)
+	_ Number[Dual]  = Dual{}
+)
+
+// poly is x^3 - 2x + 5 / x written once for any Number
+func poly[T Number[T]](x T) T {
+	return x.Pow(3).Sub(x.Scale(2)).Add(x.Const(5).Div(x))
+}
+
+// mixed exercises every elementary function of Number
+func mixed[T Number[T]](x T) T {
+	y := x.Sin().Mul(x.Cos()).Add(x.Exp().Log()).Add(x.Tan().Atan())
+	y = y.Add(x.Mul(x).Sqrt()).Add(x.Sinh().Sub(x.Cosh())).Add(x.Tanh())
+	return y.Add(x.Neg().Abs())
+}
+
+func TestDual(t *testing.T) {
+	tests := []struct {
+		name string
+		f    func(Dual) Dual
+		g    func(float64) float64 // f
+		dg   func(float64) float64 // f'
+	}{
+		{"poly", poly[Dual], func(x float64) float64 { return float64(poly(Float(x))) },
+			func(x float64) float64 { return 3*x*x - 2 - 5/(x*x) }},
+		{"mixed", mixed[Dual], func(x float64) float64 { return float64(mixed(Float(x))) },
+			func(x float64) float64 {
+				return math.Cos(2*x) + 1 + 1 + 1 + math.Cosh(x) - math.Sinh(x) +
+					1 - math.Tanh(x)*math.Tanh(x) + 1
+			}},
+		{"sqrt", Dual.Sqrt, math.Sqrt, func(x float64) float64 { return 0.5 / math.Sqrt(x) }},
+		{"pow", func(x Dual) Dual { return x.Pow(-1.5) }, func(x float64) float64 { return math.Pow(x, -1.5) },
+			func(x float64) float64 { return -1.5 * math.Pow(x, -2.5) }},
+		{"pow 0", func(x Dual) Dual { return x.Pow(0) }, func(x float64) float64 { return 1 },
+			func(x float64) float64 { return 0 }},
+		{"quotient", func(x Dual) Dual { return x.Sin().Div(x) }, func(x float64) float64 { return math.Sin(x) / x },
+			func(x float64) float64 { return (x*math.Cos(x) - math.Sin(x)) / (x * x) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, x := range []float64{0.3, 1, 1.4} {
+				fx, dfx := DualDeriv(tt.f, x)
+				if want := tt.g(x); math.Abs(fx-want) > 1e-14*math.Max(math.Abs(want), 1) {
+					t.Errorf("f(%v) = %v, want %v", x, fx, want)
+				}
+				if want := tt.dg(x); math.Abs(dfx-want) > 1e-13*math.Max(math.Abs(want), 1) {
+					t.Errorf("f'(%v) = %v, want %v", x, dfx, want)
+				}
+			}
+		})
+	}
+}
+
+func TestDualAbs(t *testing.T) {
+	tests := []struct {
+		x, want, der float64
+	}{
+		{-2, 2, -1},
+		{3, 3, 1},
+		{0, 0, 0},
+	}
+	for _, tt := range tests {
+		if got := Variable(tt.x).Abs(); got.Val != tt.want || got.Der != tt.der {
+			t.Errorf("Variable(%v).Abs() = %v, want {%v %v}", tt.x, got, tt.want, tt.der)
+		}
+	}
+	if c := Variable(2).Const(3); c.Val != 3 || c.Der != 0 {
+		t.Errorf("Const(3) = %v", c)
+	}
+	if v := Variable(2).Value(); v != 2 {
+		t.Errorf("Value() = %v", v)
+	}
+}
@@ -0,0 +1,129 @@
+package gnum
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// Evaluator decorates an objective function func(float64) float64 with
+// an evaluation counter, an optional cache keyed on x, a NaN/Inf guard
+// and timing. Pass e.F to a solver or an integrator in place of f, then
+// read the counts from e. An Evaluator is safe for concurrent use.
+//
+//	e := gnum.NewEvaluator(f, true, true)
+//	area := integrate.Romberg(0, 1, e.F, 1e-6)
+//	fmt.Println(e)
+type Evaluator struct {
+	f     func(float64) float64
+	name  string
+	guard bool
+	prof  *Profile
+
+	mu    sync.Mutex
+	cache map[float64]float64
+	calls int
+	hits  int
+	err   error
+}
+
+// NewEvaluator returns an Evaluator of f
+//
+// cache	: remember f(x) and return it when x is requested again
+// guard	: record the first x where f(x) is NaN or Inf, see Err
+func NewEvaluator(f func(float64) float64, cache, guard bool) *Evaluator {
+	name := GetFunctionName(f)
+	e := &Evaluator{f: f, name: name, guard: guard, prof: NewProfile(name)}
+	if cache {
+		e.cache = make(map[float64]float64)
+	}
+	return e
+}
+
+// F evaluates the decorated function at x
+func (e *Evaluator) F(x float64) float64 {
+	e.mu.Lock()
+	e.calls++
+	if e.cache != nil {
+		if y, ok := e.cache[x]; ok {
+			e.hits++
+			e.mu.Unlock()
+			return y
+		}
+	}
+	e.mu.Unlock()
+
+	t := time.Now()
+	y := e.f(x)
+	e.prof.Add(time.Since(t))
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.cache != nil {
+		e.cache[x] = y
+	}
+	if e.guard && e.err == nil && (math.IsNaN(y) || math.IsInf(y, 0)) {
+		e.err = fmt.Errorf("%s(%13.6e) = %v", e.name, x, y)
+	}
+	return y
+}
+
+// Calls returns the number of calls of F
+func (e *Evaluator) Calls() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.calls
+}
+
+// Evals returns the number of evaluations of the decorated function,
+// i.e. Calls less the cache hits
+func (e *Evaluator) Evals() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.calls - e.hits
+}
+
+// Hits returns the number of calls answered from the cache
+func (e *Evaluator) Hits() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.hits
+}
+
+// Elapsed returns the total time spent in the decorated function
+func (e *Evaluator) Elapsed() time.Duration {
+	return e.prof.Stats().Total
+}
+
+// Profile returns the latency profile of the evaluations
+func (e *Evaluator) Profile() *Profile {
+	return e.prof
+}
+
+// Err returns the first non-finite value seen by the guard, or nil
+func (e *Evaluator) Err() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.err
+}
+
+// Reset clears the counters, the timing, the guard and the cache
+func (e *Evaluator) Reset() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.calls, e.hits, e.err = 0, 0, nil
+	e.prof.Reset()
+	if e.cache != nil {
+		e.cache = make(map[float64]float64)
+	}
+}
+
+// String reports the counts and the timing
+func (e *Evaluator) String() string {
+	elapsed := e.Elapsed()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return fmt.Sprintf("%s: %d calls, %d evaluations, %d cache hits, %v elapsed",
+		e.name, e.calls, e.calls-e.hits, e.hits, elapsed)
+}
@@ -0,0 +1,65 @@
+package gnum
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEvaluator(t *testing.T) {
+	type args struct {
+		f     func(float64) float64
+		cache bool
+		guard bool
+		xs    []float64
+	}
+	tests := []struct {
+		name      string
+		args      args
+		wantCalls int
+		wantEvals int
+		wantHits  int
+		wantErr   bool
+	}{
+		{
+			"Case 1 : counting only",
+			args{math.Sqrt, false, false, []float64{1, 2, 1, 2, -1}},
+			5, 5, 0, false,
+		},
+		{
+			"Case 2 : cache",
+			args{math.Sqrt, true, false, []float64{1, 2, 1, 2, 3}},
+			5, 3, 2, false,
+		},
+		{
+			"Case 3 : guard",
+			args{math.Sqrt, true, true, []float64{4, -1, 4}},
+			3, 2, 1, true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewEvaluator(tt.args.f, tt.args.cache, tt.args.guard)
+			for _, x := range tt.args.xs {
+				if got, want := e.F(x), tt.args.f(x); got != want && !math.IsNaN(want) {
+					t.Errorf("Evaluator.F(%v) = %v, want %v", x, got, want)
+				}
+			}
+			if got := e.Calls(); got != tt.wantCalls {
+				t.Errorf("Evaluator.Calls() = %v, want %v", got, tt.wantCalls)
+			}
+			if got := e.Evals(); got != tt.wantEvals {
+				t.Errorf("Evaluator.Evals() = %v, want %v", got, tt.wantEvals)
+			}
+			if got := e.Hits(); got != tt.wantHits {
+				t.Errorf("Evaluator.Hits() = %v, want %v", got, tt.wantHits)
+			}
+			if (e.Err() != nil) != tt.wantErr {
+				t.Errorf("Evaluator.Err() = %v, wantErr %v", e.Err(), tt.wantErr)
+			}
+			e.Reset()
+			if e.Calls() != 0 || e.Evals() != 0 || e.Err() != nil {
+				t.Errorf("Evaluator.Reset() left %v", e)
+			}
+		})
+	}
+}
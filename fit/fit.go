@@ -0,0 +1,110 @@
+// Package fit fits models to data by least squares: linear combinations
+// of basis functions, polynomials through orthogonal polynomials and
+// nonlinear models by Levenberg-Marquardt, with optional weighting by the
+// uncertainties of the data; robustly against outliers by IRLS,
+// Theil-Sen and RANSAC; and smooths noisy data by smoothing splines and
+// LOESS.
+package fit
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/shyang107/gnum"
+)
+
+// Result is the outcome of a least squares fit. With uncertainties
+// sigma the residuals are weighted by 1/sigma^2 and the covariance is
+// absolute; without them every weight is 1 and the covariance is scaled
+// by the reduced chi-square, the estimated variance of the data.
+type Result struct {
+	Params    []float64    // the fitted parameters
+	Cov       *gnum.Matrix // the covariance matrix of the parameters
+	Residuals []float64    // y_i - f(x_i)
+	ChiSq     float64      // sum_i w_i r_i^2
+	RSquared  float64      // 1 - sum_i w_i r_i^2 / sum_i w_i (y_i - mean)^2
+	DoF       int          // the degrees of freedom, the number of points of positive weight - len(Params)
+}
+
+// StdErr returns the standard errors of the parameters, the square roots
+// of the diagonal of Cov
+func (r *Result) StdErr() []float64 {
+	e := make([]float64, len(r.Params))
+	for i := range e {
+		e[i] = math.Sqrt(r.Cov.At(i, i))
+	}
+	return e
+}
+
+// RedChiSq returns the reduced chi-square ChiSq / DoF, which is about 1
+// for a good model with correct uncertainties
+func (r *Result) RedChiSq() float64 {
+	return r.ChiSq / float64(r.DoF)
+}
+
+// weights returns w_i = 1/sigma_i^2, or 1 if sigma is nil, after checking
+// the lengths of the data
+//
+// p	: the number of parameters
+func weights(xs, ys, sigma []float64, p int) ([]float64, error) {
+	n := len(xs)
+	if len(ys) != n {
+		return nil, fmt.Errorf("len(xs) = %d, len(ys) = %d, want equal", n, len(ys))
+	}
+	if sigma != nil && len(sigma) != n {
+		return nil, fmt.Errorf("len(sigma) = %d, want %d", len(sigma), n)
+	}
+	if n < p || (sigma == nil && n == p) {
+		return nil, fmt.Errorf("%d points are too few for %d parameters", n, p)
+	}
+	w := make([]float64, n)
+	for i := range w {
+		w[i] = 1.0
+		if sigma != nil {
+			if !(sigma[i] > 0.0) {
+				return nil, fmt.Errorf("sigma[%d] = %g, want > 0", i, sigma[i])
+			}
+			w[i] = 1.0 / (sigma[i] * sigma[i])
+		}
+	}
+	return w, nil
+}
+
+// summarize fills in the residuals and the statistics of r from the
+// fitted values and scales r.Cov by the reduced chi-square if the fit is
+// unweighted
+func (r *Result) summarize(ys, fitted, w []float64, weighted bool) {
+	n := len(ys)
+	r.DoF = -len(r.Params)
+	for _, wi := range w {
+		if wi > 0.0 {
+			r.DoF++
+		}
+	}
+	r.Residuals = make([]float64, n)
+	sw, mean := 0.0, 0.0
+	for i := range ys {
+		r.Residuals[i] = ys[i] - fitted[i]
+		r.ChiSq += w[i] * r.Residuals[i] * r.Residuals[i]
+		sw += w[i]
+		mean += w[i] * ys[i]
+	}
+	mean /= sw
+	tss := 0.0
+	for i := range ys {
+		tss += w[i] * (ys[i] - mean) * (ys[i] - mean)
+	}
+	r.RSquared = 1.0
+	if tss > 0.0 {
+		r.RSquared = 1.0 - r.ChiSq/tss
+	}
+	if !weighted {
+		s2 := r.RedChiSq()
+		p := len(r.Params)
+		for i := 0; i < p; i++ {
+			for j := 0; j < p; j++ {
+				r.Cov.Set(i, j, s2*r.Cov.At(i, j))
+			}
+		}
+	}
+}
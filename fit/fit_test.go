@@ -0,0 +1,73 @@
+package fit
+
+import (
+	"math"
+	"testing"
+
+	"github.com/shyang107/gnum"
+)
+
+// noise returns a deterministic pseudo-random perturbation of size about
+// amp for point i
+func noise(i int, amp float64) float64 {
+	return amp * math.Sin(12.9898*float64(i)+78.233*float64(i*i%7))
+}
+
+func TestWeights(t *testing.T) {
+	tests := []struct {
+		name       string
+		xs, ys, sg []float64
+		p          int
+		want       []float64
+		wantErr    bool
+	}{
+		{"unweighted", []float64{0, 1, 2}, []float64{1, 2, 3}, nil, 2, []float64{1, 1, 1}, false},
+		{"sigma", []float64{0, 1}, []float64{1, 2}, []float64{0.5, 2}, 2, []float64{4, 0.25}, false},
+		{"ys length", []float64{0, 1}, []float64{1}, nil, 1, nil, true},
+		{"sigma length", []float64{0, 1}, []float64{1, 2}, []float64{1}, 1, nil, true},
+		{"zero sigma", []float64{0, 1}, []float64{1, 2}, []float64{1, 0}, 1, nil, true},
+		{"nan sigma", []float64{0, 1}, []float64{1, 2}, []float64{1, math.NaN()}, 1, nil, true},
+		{"too few", []float64{0, 1}, []float64{1, 2}, nil, 3, nil, true},
+		{"no variance", []float64{0, 1}, []float64{1, 2}, nil, 2, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := weights(tt.xs, tt.ys, tt.sg, tt.p)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("weights() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("weights()[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestResultSummarize(t *testing.T) {
+	ys := []float64{1, 2, 4, 5}
+	fitted := []float64{1.5, 1.5, 4.5, 4.5}
+	w := []float64{1, 1, 1, 1}
+	r := Result{Params: []float64{0, 0}, Cov: gnum.NewMatrix(2, 2, []float64{2, 1, 1, 8})}
+	r.summarize(ys, fitted, w, false)
+	if r.DoF != 2 || r.ChiSq != 1 {
+		t.Errorf("DoF, ChiSq = %d, %v, want 2, 1", r.DoF, r.ChiSq)
+	}
+	// mean 3, total sum of squares 10
+	if math.Abs(r.RSquared-0.9) > 1e-15 {
+		t.Errorf("RSquared = %v, want 0.9", r.RSquared)
+	}
+	if r.RedChiSq() != 0.5 {
+		t.Errorf("RedChiSq() = %v, want 0.5", r.RedChiSq())
+	}
+	// Cov is scaled by RedChiSq
+	if se := r.StdErr(); se[0] != 1 || se[1] != 2 {
+		t.Errorf("StdErr() = %v, want [1 2]", se)
+	}
+	for i, res := range []float64{-0.5, 0.5, -0.5, 0.5} {
+		if r.Residuals[i] != res {
+			t.Errorf("Residuals[%d] = %v, want %v", i, r.Residuals[i], res)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package fit
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// Line is a straight line y = Intercept + Slope x fitted by a robust
+// estimator
+type Line struct {
+	Intercept, Slope float64
+	Inliers          []bool  // the points consistent with the line
+	Scale            float64 // the robust scale of the residuals, MAD / 0.6745
+}
+
+// Eval returns the line at x
+func (l *Line) Eval(x float64) float64 {
+	return l.Intercept + l.Slope*x
+}
+
+// resid returns the residuals of the line at the data
+func (l *Line) resid(xs, ys []float64) []float64 {
+	r := make([]float64, len(xs))
+	for i, x := range xs {
+		r[i] = ys[i] - l.Eval(x)
+	}
+	return r
+}
+
+// checkLine checks the data of a line fit
+func checkLine(xs, ys []float64) error {
+	if len(ys) != len(xs) {
+		return fmt.Errorf("len(xs) = %d, len(ys) = %d, want equal", len(xs), len(ys))
+	}
+	for i := 1; i < len(xs); i++ {
+		if xs[i] != xs[0] {
+			return nil
+		}
+	}
+	return fmt.Errorf("A line needs two distinct abscissae")
+}
+
+// TheilSen fits a line by the Theil-Sen estimator: the slope is the
+// median of the slopes through all pairs of points with distinct x and
+// the intercept the median of y - slope x. It tolerates up to about 29%
+// outliers; the cost is O(n^2). The inliers have |r_i| <= 2.5 Scale.
+func TheilSen(xs, ys []float64) (*Line, error) {
+	if err := checkLine(xs, ys); err != nil {
+		return nil, err
+	}
+	n := len(xs)
+	slopes := make([]float64, 0, n*(n-1)/2)
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			if xs[j] != xs[i] {
+				slopes = append(slopes, (ys[j]-ys[i])/(xs[j]-xs[i]))
+			}
+		}
+	}
+	l := &Line{Slope: median(slopes)}
+	b := make([]float64, n)
+	for i, x := range xs {
+		b[i] = ys[i] - l.Slope*x
+	}
+	l.Intercept = median(b)
+	r := l.resid(xs, ys)
+	l.Scale = mad(r) / 0.6745
+	l.Inliers = make([]bool, n)
+	for i, ri := range r {
+		l.Inliers[i] = math.Abs(ri) <= 2.5*l.Scale
+	}
+	return l, nil
+}
+
+// RANSACSettings controls RANSAC; the zero value uses the defaults
+type RANSACSettings struct {
+	// Threshold is the largest |r_i| of an inlier; the default is 2.5
+	// times the robust scale of the Theil-Sen residuals
+	Threshold  float64
+	MaxTrials  int     // the maximum number of random pairs (default 1000)
+	Confidence float64 // stop when no better pair is found with this probability (default 0.99)
+	Seed       int64   // the seed of the random pairs
+}
+
+// RANSAC fits a line by random sample consensus: lines through random
+// pairs of points are scored by their number of inliers, ties broken by
+// the smaller sum of squared inlier residuals. The number of trials
+// adapts to the inlier fraction q of the best line so far, N = log(1 -
+// Confidence) / log(1 - q^2). The best line is refitted by least squares
+// to its inliers, and the inliers of the refitted line are reported. It
+// tolerates more than half outliers.
+//
+// s	: the settings, nil means the defaults
+func RANSAC(xs, ys []float64, s *RANSACSettings) (*Line, error) {
+	if err := checkLine(xs, ys); err != nil {
+		return nil, err
+	}
+	var st RANSACSettings
+	if s != nil {
+		st = *s
+	}
+	if st.MaxTrials <= 0 {
+		st.MaxTrials = 1000
+	}
+	if st.Confidence <= 0.0 || st.Confidence >= 1.0 {
+		st.Confidence = 0.99
+	}
+	if st.Threshold <= 0.0 {
+		ts, _ := TheilSen(xs, ys)
+		st.Threshold = 2.5 * ts.Scale
+		if st.Threshold == 0.0 {
+			st.Threshold = 1.0e-12 * (1.0 + math.Abs(ts.Intercept))
+		}
+	}
+	n := len(xs)
+	rng := rand.New(rand.NewSource(st.Seed))
+	var best *Line
+	bestCount, bestSSE := -1, 0.0
+	trials := st.MaxTrials
+	for t := 0; t < trials; t++ {
+		i, j := rng.Intn(n), rng.Intn(n)
+		if xs[i] == xs[j] {
+			continue
+		}
+		slope := (ys[j] - ys[i]) / (xs[j] - xs[i])
+		l := &Line{Slope: slope, Intercept: ys[i] - slope*xs[i]}
+		count, sse := 0, 0.0
+		for k, x := range xs {
+			if r := ys[k] - l.Eval(x); math.Abs(r) <= st.Threshold {
+				count++
+				sse += r * r
+			}
+		}
+		if count > bestCount || (count == bestCount && sse < bestSSE) {
+			best, bestCount, bestSSE = l, count, sse
+			q := float64(count) / float64(n)
+			if q >= 1.0 {
+				break
+			}
+			trials = ransacTrials(q, st.Confidence, trials, t)
+		}
+	}
+	if best == nil {
+		return nil, fmt.Errorf("No pair of distinct abscissae in %d trials", st.MaxTrials)
+	}
+	//-----------------------------------------------------
+	// refit to the inliers by least squares
+	//-----------------------------------------------------
+	w := make([]float64, n)
+	for k, r := range best.resid(xs, ys) {
+		if math.Abs(r) <= st.Threshold {
+			w[k] = 1.0
+		}
+	}
+	if m, err := linearFit(xs, ys, w, Monomials(1), true); err == nil {
+		best.Intercept, best.Slope = m.Params[0], m.Params[1]
+	}
+	r := best.resid(xs, ys)
+	best.Inliers = make([]bool, n)
+	var in []float64
+	for k, rk := range r {
+		if best.Inliers[k] = math.Abs(rk) <= st.Threshold; best.Inliers[k] {
+			in = append(in, rk)
+		}
+	}
+	best.Scale = mad(in) / 0.6745
+	return best, nil
+}
+
+// ransacTrials returns the number of trials N = log(1 - confidence) /
+// log(1 - q^2) for the inlier fraction q after the trial t, at most
+// trials and more than t; N is not finite, and trials is kept, if q or
+// q^2 is too small to give 1 - q^2 < 1
+func ransacTrials(q, confidence float64, trials, t int) int {
+	if q <= 0.0 {
+		return trials
+	}
+	nt := math.Log(1.0-confidence) / math.Log(1.0-q*q)
+	if nt > 0.0 && nt < float64(trials) {
+		return imax(int(math.Ceil(nt)), t+1)
+	}
+	return trials
+}
+
+func imax(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
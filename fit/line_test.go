@@ -0,0 +1,141 @@
+package fit
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTheilSen(t *testing.T) {
+	xs, ys, outlier := outlierData()
+	l, err := TheilSen(xs, ys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(l.Intercept-1) > 0.1 || math.Abs(l.Slope-2) > 0.03 {
+		t.Errorf("TheilSen() = %v + %v x, want 1 + 2 x", l.Intercept, l.Slope)
+	}
+	for i := range outlier {
+		if l.Inliers[i] == outlier[i] {
+			t.Errorf("Inliers[%d] = %v, outlier %v", i, l.Inliers[i], outlier[i])
+		}
+	}
+	if got := l.Eval(2); got != l.Intercept+2*l.Slope {
+		t.Errorf("Eval(2) = %v", got)
+	}
+	// an exact line with repeated abscissae
+	l, err = TheilSen([]float64{0, 0, 1, 2, 2}, []float64{3, 3, 1, -1, -1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.Intercept != 3 || l.Slope != -2 || l.Scale != 0 {
+		t.Errorf("TheilSen() = %v + %v x, scale %v, want 3 - 2 x, 0", l.Intercept, l.Slope, l.Scale)
+	}
+}
+
+func TestRANSAC(t *testing.T) {
+	// 40 points on y = -3 + 0.5 x and 60 scattered outliers
+	var xs, ys []float64
+	var outlier []bool
+	for i := 0; i < 100; i++ {
+		x := float64(i) / 10
+		y := -3 + 0.5*x + noise(i, 0.01)
+		bad := i%5 != 0 && i%5 != 3
+		if bad {
+			y = 10 * math.Sin(float64(7*i))
+		}
+		xs = append(xs, x)
+		ys = append(ys, y)
+		outlier = append(outlier, bad)
+	}
+	tests := []struct {
+		name string
+		s    *RANSACSettings
+	}{
+		{"threshold", &RANSACSettings{Threshold: 0.05, Seed: 1}},
+		{"other seed", &RANSACSettings{Threshold: 0.05, Seed: 42}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, err := RANSAC(xs, ys, tt.s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if math.Abs(l.Intercept+3) > 0.01 || math.Abs(l.Slope-0.5) > 0.002 {
+				t.Errorf("RANSAC() = %v + %v x, want -3 + 0.5 x", l.Intercept, l.Slope)
+			}
+			for i := range outlier {
+				if l.Inliers[i] == outlier[i] && math.Abs(ys[i]-l.Eval(xs[i])) > 0.05 {
+					t.Errorf("Inliers[%d] = %v, outlier %v", i, l.Inliers[i], outlier[i])
+				}
+			}
+		})
+	}
+	// the default threshold on the data with 20% outliers
+	xs, ys, outlier = outlierData()
+	l, err := RANSAC(xs, ys, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(l.Intercept-1) > 0.05 || math.Abs(l.Slope-2) > 0.01 {
+		t.Errorf("RANSAC() = %v + %v x, want 1 + 2 x", l.Intercept, l.Slope)
+	}
+	for i := range outlier {
+		if l.Inliers[i] == outlier[i] {
+			t.Errorf("Inliers[%d] = %v, outlier %v", i, l.Inliers[i], outlier[i])
+		}
+	}
+}
+
+func TestRANSACTrials(t *testing.T) {
+	tests := []struct {
+		name string
+		q    float64
+		t    int
+		want int
+	}{
+		{"half inliers", 0.5, 0, 17},
+		{"at least the next trial", 0.5, 30, 31},
+		{"more than trials", 0.01, 0, 1000},
+		{"no inliers", 0, 0, 1000},
+		// 1 - q^2 rounds to 1
+		{"q^2 below epsilon", 1e-9, 0, 1000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ransacTrials(tt.q, 0.99, 1000, tt.t); got != tt.want {
+				t.Errorf("ransacTrials(%v) = %d, want %d", tt.q, got, tt.want)
+			}
+		})
+	}
+	// a threshold below the rounding of the residuals leaves lines
+	// without inliers
+	xs := []float64{0.1, 0.7, 0.3, 1.9, 2.2, 3.7}
+	ys := make([]float64, len(xs))
+	for i, x := range xs {
+		ys[i] = 0.3 + x/3
+	}
+	if l, err := RANSAC(xs, ys, &RANSACSettings{Threshold: 1e-300, MaxTrials: 50}); err != nil || l == nil {
+		t.Errorf("RANSAC() = %v, %v", l, err)
+	}
+}
+
+func TestLineErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		xs, ys []float64
+	}{
+		{"mismatch", []float64{0, 1}, []float64{0}},
+		{"vertical", []float64{1, 1, 1}, []float64{0, 1, 2}},
+		{"empty", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := TheilSen(tt.xs, tt.ys); err == nil {
+				t.Errorf("TheilSen() error = nil, want an error")
+			}
+			if _, err := RANSAC(tt.xs, tt.ys, nil); err == nil {
+				t.Errorf("RANSAC() error = nil, want an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package fit
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/shyang107/gnum"
+	"github.com/shyang107/gnum/solveeqs"
+)
+
+// Linear is the least squares fit of the model
+//	f(x) = sum_j p_j phi_j(x)
+// that is linear in the parameters p_j
+type Linear struct {
+	Result
+	basis []func(float64) float64
+}
+
+// NewLinear fits sum_j p_j basis[j](x) to the data (xs[i], ys[i]) by
+// Householder QR of the weighted design matrix A_ij = phi_j(x_i) /
+// sigma_i, which avoids squaring the condition number as the normal
+// equations do; Cov = (A^T A)^(-1) = R^(-1) R^(-T).
+//
+// sigma	: the uncertainties of ys, nil for an unweighted fit
+// basis	: the basis functions phi_j
+func NewLinear(xs, ys, sigma []float64, basis ...func(float64) float64) (*Linear, error) {
+	w, err := weights(xs, ys, sigma, len(basis))
+	if err != nil {
+		return nil, err
+	}
+	return linearFit(xs, ys, w, basis, sigma != nil)
+}
+
+// linearFit fits sum_j p_j basis[j](x) with the weights w; the
+// covariance is absolute if weighted, otherwise it is scaled by the
+// reduced chi-square
+func linearFit(xs, ys, w []float64, basis []func(float64) float64, weighted bool) (*Linear, error) {
+	p := len(basis)
+	n := len(xs)
+	a := gnum.NewMatrix(n, p, nil)
+	b := make([]float64, n)
+	for i, x := range xs {
+		s := math.Sqrt(w[i])
+		for j, phi := range basis {
+			a.Set(i, j, s*phi(x))
+		}
+		b[i] = s * ys[i]
+	}
+	var qr solveeqs.QR
+	if err := qr.Factorize(a); err != nil {
+		return nil, err
+	}
+	r := qr.R()
+	if err := fullRank(r, n); err != nil {
+		return nil, err
+	}
+	params, _, err := qr.Solve(b)
+	if err != nil {
+		return nil, err
+	}
+	m := &Linear{basis: basis}
+	m.Params = params
+	m.Cov = invRRT(r)
+	fitted := make([]float64, n)
+	for i, x := range xs {
+		fitted[i] = m.Eval(x)
+	}
+	m.summarize(ys, fitted, w, weighted)
+	return m, nil
+}
+
+// Eval returns the fitted model at x
+func (m *Linear) Eval(x float64) float64 {
+	s := 0.0
+	for j, phi := range m.basis {
+		s += m.Params[j] * phi(x)
+	}
+	return s
+}
+
+// Monomials returns the basis 1, x, ..., x^deg
+func Monomials(deg int) []func(float64) float64 {
+	basis := make([]func(float64) float64, deg+1)
+	for k := range basis {
+		k := k
+		basis[k] = func(x float64) float64 { return math.Pow(x, float64(k)) }
+	}
+	return basis
+}
+
+// fullRank returns an error if a diagonal element of the triangular
+// factor R of an n-row matrix is below n eps max |r_jj|, which means
+// dependent columns
+func fullRank(r *gnum.Matrix, n int) error {
+	p, _ := r.Dims()
+	rmax := 0.0
+	for j := 0; j < p; j++ {
+		rmax = math.Max(rmax, math.Abs(r.At(j, j)))
+	}
+	const eps = 2.220446049250313e-16
+	for j := 0; j < p; j++ {
+		if math.Abs(r.At(j, j)) <= float64(n)*eps*rmax {
+			return fmt.Errorf("Rank deficient fit: parameter %d depends on the others", j)
+		}
+	}
+	return nil
+}
+
+// invRRT returns R^(-1) R^(-T) for the upper triangular R
+func invRRT(r *gnum.Matrix) *gnum.Matrix {
+	n, _ := r.Dims()
+	//-----------------------------------------------------
+	// U = R^(-1) by back substitution, column by column
+	//-----------------------------------------------------
+	u := gnum.NewMatrix(n, n, nil)
+	for j := 0; j < n; j++ {
+		u.Set(j, j, 1.0/r.At(j, j))
+		for i := j - 1; i >= 0; i-- {
+			s := 0.0
+			for k := i + 1; k <= j; k++ {
+				s += r.At(i, k) * u.At(k, j)
+			}
+			u.Set(i, j, -s/r.At(i, i))
+		}
+	}
+	c := gnum.NewMatrix(n, n, nil)
+	for i := 0; i < n; i++ {
+		for j := 0; j <= i; j++ {
+			s := 0.0
+			for k := i; k < n; k++ {
+				s += u.At(i, k) * u.At(j, k)
+			}
+			c.Set(i, j, s)
+			c.Set(j, i, s)
+		}
+	}
+	return c
+}
@@ -0,0 +1,103 @@
+package fit
+
+import (
+	"math"
+	"testing"
+)
+
+// lineFit returns the weighted straight line fit a + b x by the closed
+// formulas and the variances and covariance of a and b
+func lineFit(xs, ys, sigma []float64) (a, b, va, vb, cab float64) {
+	var s, sx, sy, sxx, sxy float64
+	for i, x := range xs {
+		w := 1.0
+		if sigma != nil {
+			w = 1 / (sigma[i] * sigma[i])
+		}
+		s += w
+		sx += w * x
+		sy += w * ys[i]
+		sxx += w * x * x
+		sxy += w * x * ys[i]
+	}
+	d := s*sxx - sx*sx
+	return (sxx*sy - sx*sxy) / d, (s*sxy - sx*sy) / d, sxx / d, s / d, -sx / d
+}
+
+func TestNewLinear(t *testing.T) {
+	n := 30
+	xs := make([]float64, n)
+	ys := make([]float64, n)
+	sigma := make([]float64, n)
+	for i := range xs {
+		xs[i] = 0.5 * float64(i)
+		ys[i] = 2 - 0.7*xs[i] + noise(i, 0.3)
+		sigma[i] = 0.1 + 0.02*float64(i%5)
+	}
+	tests := []struct {
+		name  string
+		sigma []float64
+	}{
+		{"unweighted", nil},
+		{"weighted", sigma},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewLinear(xs, ys, tt.sigma, Monomials(1)...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			a, b, va, vb, cab := lineFit(xs, ys, tt.sigma)
+			s2 := 1.0
+			if tt.sigma == nil {
+				s2 = m.RedChiSq()
+			}
+			want := []float64{a, b}
+			cov := [][]float64{{va, cab}, {cab, vb}}
+			for i := range want {
+				if math.Abs(m.Params[i]-want[i]) > 1e-12 {
+					t.Errorf("Params[%d] = %v, want %v", i, m.Params[i], want[i])
+				}
+				for j := range want {
+					if math.Abs(m.Cov.At(i, j)-s2*cov[i][j]) > 1e-12*s2*math.Abs(va) {
+						t.Errorf("Cov(%d,%d) = %v, want %v", i, j, m.Cov.At(i, j), s2*cov[i][j])
+					}
+				}
+			}
+			if m.DoF != n-2 || m.RSquared < 0.9 || m.RSquared > 1 {
+				t.Errorf("DoF, RSquared = %d, %v", m.DoF, m.RSquared)
+			}
+			if got := m.Eval(3); math.Abs(got-(a+3*b)) > 1e-12 {
+				t.Errorf("Eval(3) = %v, want %v", got, a+3*b)
+			}
+		})
+	}
+}
+
+func TestNewLinearBasis(t *testing.T) {
+	f := func(x float64) float64 { return 1.5*math.Sin(x) - 0.25*math.Cos(2*x) + 0.1*math.Exp(x/5) }
+	xs := make([]float64, 50)
+	ys := make([]float64, 50)
+	for i := range xs {
+		xs[i] = 0.2 * float64(i)
+		ys[i] = f(xs[i])
+	}
+	m, err := NewLinear(xs, ys, nil, math.Sin, func(x float64) float64 { return math.Cos(2 * x) },
+		func(x float64) float64 { return math.Exp(x / 5) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, want := range []float64{1.5, -0.25, 0.1} {
+		if math.Abs(m.Params[i]-want) > 1e-12 {
+			t.Errorf("Params[%d] = %v, want %v", i, m.Params[i], want)
+		}
+	}
+	if m.ChiSq > 1e-20 || math.Abs(m.RSquared-1) > 1e-14 {
+		t.Errorf("ChiSq, RSquared = %v, %v, want 0, 1", m.ChiSq, m.RSquared)
+	}
+	// x and 2x are dependent
+	if _, err := NewLinear(xs, ys, nil, func(x float64) float64 { return x },
+		func(x float64) float64 { return 2 * x }); err == nil {
+		t.Errorf("NewLinear(dependent basis) error = nil, want an error")
+	}
+}
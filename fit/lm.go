@@ -0,0 +1,354 @@
+package fit
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/shyang107/gnum"
+	"github.com/shyang107/gnum/solveeqs"
+)
+
+// Model is the model y = f(x; p) with the parameters p
+type Model func(x float64, p []float64) float64
+
+// LMSettings controls NewNonlinear; the zero value uses the defaults
+type LMSettings struct {
+	Lower, Upper []float64 // the bounds of the parameters, nil means none; +-Inf entries are allowed
+	// Jacobian, if not nil, sets grad[j] = df/dp_j at x; otherwise
+	// forward differences are used
+	Jacobian func(x float64, p, grad []float64)
+	Step     float64 // the finite difference step is Step max(|p_j|, 1) (default 1.5e-8)
+	FTol     float64 // stop when the actual and predicted relative decrease of chi^2 are below FTol (default 1e-12)
+	XTol     float64 // stop when ||dp|| <= XTol (||p|| + XTol) (default 1e-10)
+	GTol     float64 // stop when the cosine between the residual and every column of the Jacobian is below GTol (default 1e-10)
+	MaxIter  int     // the maximum number of iterations (default 200)
+	Lambda0  float64 // the initial damping (default 1e-3)
+}
+
+// LMReport reports the convergence of NewNonlinear
+type LMReport struct {
+	Iterations int       // the number of iterations, one per Jacobian
+	FuncEvals  int       // the number of evaluations of the model at all xs
+	JacEvals   int       // the number of Jacobians
+	Lambda     float64   // the final damping
+	History    []float64 // chi^2 after each iteration
+	Converged  bool
+	Reason     string // which test stopped the iteration
+}
+
+// Nonlinear is the least squares fit of a model nonlinear in its
+// parameters
+type Nonlinear struct {
+	Result
+	Report LMReport
+	model  Model
+}
+
+// defaults returns a copy of s with the defaults filled in for np
+// parameters after checking the bounds
+func (s *LMSettings) defaults(np int) (LMSettings, error) {
+	var d LMSettings
+	if s != nil {
+		d = *s
+	}
+	if d.Step <= 0.0 {
+		d.Step = 1.5e-8
+	}
+	if d.FTol <= 0.0 {
+		d.FTol = 1.0e-12
+	}
+	if d.XTol <= 0.0 {
+		d.XTol = 1.0e-10
+	}
+	if d.GTol <= 0.0 {
+		d.GTol = 1.0e-10
+	}
+	if d.MaxIter <= 0 {
+		d.MaxIter = 200
+	}
+	if d.Lambda0 <= 0.0 {
+		d.Lambda0 = 1.0e-3
+	}
+	if (d.Lower != nil && len(d.Lower) != np) || (d.Upper != nil && len(d.Upper) != np) {
+		return d, fmt.Errorf("len(Lower) = %d, len(Upper) = %d, want %d", len(d.Lower), len(d.Upper), np)
+	}
+	for j := 0; j < np; j++ {
+		if d.lower(j) > d.upper(j) {
+			return d, fmt.Errorf("Lower[%d] = %g > Upper[%d] = %g", j, d.lower(j), j, d.upper(j))
+		}
+	}
+	return d, nil
+}
+
+// lower returns the lower bound of parameter j
+func (s *LMSettings) lower(j int) float64 {
+	if s.Lower == nil {
+		return math.Inf(-1)
+	}
+	return s.Lower[j]
+}
+
+// upper returns the upper bound of parameter j
+func (s *LMSettings) upper(j int) float64 {
+	if s.Upper == nil {
+		return math.Inf(1)
+	}
+	return s.Upper[j]
+}
+
+// clamp projects p onto the bounds
+func (s *LMSettings) clamp(p []float64) {
+	for j := range p {
+		p[j] = math.Max(s.lower(j), math.Min(s.upper(j), p[j]))
+	}
+}
+
+// NewNonlinear fits the model f to the data (xs[i], ys[i]) from the
+// initial parameters p0 by the Levenberg-Marquardt method: each step
+// solves
+//	min ||J dp - r||^2 + lambda ||D dp||^2,	D^2 = diag(J^T J),
+// by QR of the augmented matrix, with r and J weighted by 1/sigma. A
+// step that lowers chi^2 is taken and lambda decreased ten times,
+// otherwise lambda is increased ten times. Steps are projected onto the
+// bounds, and a parameter on a bound is held while the gradient pushes
+// it outward. When no step lowers chi^2, the minimum is reached within
+// the accuracy of the Jacobian and the fit is taken as converged. The
+// covariance is (J^T J)^(-1) at the solution; it ignores the
+// bounds, so it is only meaningful for parameters off them. If the
+// iteration does not converge, the last fit is returned with an error.
+//
+// f	: the model
+// sigma	: the uncertainties of ys, nil for an unweighted fit
+// p0	: the initial parameters, projected onto the bounds
+// s	: the settings, nil means the defaults
+func NewNonlinear(f Model, xs, ys, sigma, p0 []float64, s *LMSettings) (*Nonlinear, error) {
+	np := len(p0)
+	if np == 0 {
+		return nil, fmt.Errorf("No parameters to fit")
+	}
+	w, err := weights(xs, ys, sigma, np)
+	if err != nil {
+		return nil, err
+	}
+	st, err := s.defaults(np)
+	if err != nil {
+		return nil, err
+	}
+	n := len(xs)
+	sw := make([]float64, n)
+	for i := range sw {
+		sw[i] = math.Sqrt(w[i])
+	}
+	m := &Nonlinear{model: f}
+	rep := &m.Report
+	p := append([]float64(nil), p0...)
+	st.clamp(p)
+	//-----------------------------------------------------
+	// resid sets r = (y - f(x; q)) / sigma and returns chi^2
+	//-----------------------------------------------------
+	resid := func(r, q []float64) float64 {
+		rep.FuncEvals++
+		chi2 := 0.0
+		for i, x := range xs {
+			r[i] = sw[i] * (ys[i] - f(x, q))
+			chi2 += r[i] * r[i]
+		}
+		return chi2
+	}
+	r := make([]float64, n)
+	rn := make([]float64, n)
+	chi2 := resid(r, p)
+	jac := gnum.NewMatrix(n, np, nil)
+	aug := gnum.NewMatrix(n+np, np, nil)
+	rhs := make([]float64, n+np)
+	g := make([]float64, np)
+	d := make([]float64, np)
+	pn := make([]float64, np)
+	active := make([]bool, np)
+	jacStale := false
+	lambda := st.Lambda0
+	var qr solveeqs.QR
+	for {
+		rep.FuncEvals += jacobian(jac, f, xs, p, sw, &st)
+		rep.JacEvals++
+		//-----------------------------------------------------
+		// g = J^T r; a parameter on a bound that g pushes out
+		// of the box is active: it is held in the step and
+		// left out of the gradient test
+		//-----------------------------------------------------
+		gmax := 0.0
+		for j := 0; j < np; j++ {
+			g[j] = 0.0
+			cj := 0.0
+			for i := 0; i < n; i++ {
+				g[j] += jac.At(i, j) * r[i]
+				cj += jac.At(i, j) * jac.At(i, j)
+			}
+			d[j] = math.Max(d[j], cj)
+			active[j] = (p[j] >= st.upper(j) && g[j] > 0.0) || (p[j] <= st.lower(j) && g[j] < 0.0)
+			if active[j] {
+				continue
+			}
+			if cj > 0.0 && chi2 > 0.0 {
+				gmax = math.Max(gmax, math.Abs(g[j])/math.Sqrt(cj*chi2))
+			}
+		}
+		if gmax <= st.GTol {
+			rep.Converged, rep.Reason = true, "gradient orthogonal to the residual"
+			break
+		}
+		if rep.Iterations >= st.MaxIter {
+			rep.Reason = "too many iterations"
+			break
+		}
+		rep.Iterations++
+		stop := false
+		for {
+			//-----------------------------------------------------
+			// the damped step from [J; sqrt(lambda) D] dp = [r; 0]
+			//-----------------------------------------------------
+			for i := 0; i < n; i++ {
+				for j := 0; j < np; j++ {
+					aug.Set(i, j, jac.At(i, j))
+					if active[j] {
+						aug.Set(i, j, 0.0)
+					}
+				}
+				rhs[i] = r[i]
+			}
+			for k := 0; k < np; k++ {
+				for j := 0; j < np; j++ {
+					aug.Set(n+k, j, 0.0)
+				}
+				dk := d[k]
+				if dk == 0.0 {
+					dk = 1.0
+				}
+				aug.Set(n+k, k, math.Sqrt(lambda*dk))
+				rhs[n+k] = 0.0
+			}
+			if err := qr.Factorize(aug); err != nil {
+				return nil, err
+			}
+			dp, _, err := qr.Solve(rhs)
+			if err != nil {
+				return nil, err
+			}
+			for j := range pn {
+				pn[j] = p[j] + dp[j]
+			}
+			st.clamp(pn)
+			chi2n := resid(rn, pn)
+			if chi2n < chi2 {
+				//-----------------------------------------------------
+				// the predicted chi^2 is ||r - J dp||^2 for the
+				// projected step dp
+				//-----------------------------------------------------
+				pred, dpn, pnorm := 0.0, 0.0, 0.0
+				for i := 0; i < n; i++ {
+					e := r[i]
+					for j := 0; j < np; j++ {
+						e -= jac.At(i, j) * (pn[j] - p[j])
+					}
+					pred += e * e
+				}
+				for j := range p {
+					dpn += (pn[j] - p[j]) * (pn[j] - p[j])
+					pnorm += pn[j] * pn[j]
+				}
+				actRed, predRed := chi2-chi2n, chi2-pred
+				copy(p, pn)
+				r, rn = rn, r
+				chi2 = chi2n
+				lambda = math.Max(lambda/10.0, 1.0e-15)
+				rep.History = append(rep.History, chi2)
+				if actRed <= st.FTol*(chi2+actRed) && predRed <= st.FTol*(chi2+actRed) {
+					rep.Converged, rep.Reason = true, "relative decrease of chi-square below FTol"
+				} else if math.Sqrt(dpn) <= st.XTol*(math.Sqrt(pnorm)+st.XTol) {
+					rep.Converged, rep.Reason = true, "relative step below XTol"
+				} else if chi2 == 0.0 {
+					rep.Converged, rep.Reason = true, "zero chi-square"
+				}
+				stop, jacStale = rep.Converged, rep.Converged
+				break
+			}
+			lambda *= 10.0
+			if lambda > 1.0e16 {
+				rep.Converged, rep.Reason, stop = true, "chi-square cannot be decreased further", true
+				break
+			}
+		}
+		if stop {
+			break
+		}
+	}
+	rep.Lambda = lambda
+	//-----------------------------------------------------
+	// the statistics at the solution; a stop after a step
+	// needs the Jacobian there
+	//-----------------------------------------------------
+	m.Params = p
+	if jacStale {
+		rep.FuncEvals += jacobian(jac, f, xs, p, sw, &st)
+		rep.JacEvals++
+	}
+	if err := qr.Factorize(jac); err != nil {
+		return nil, err
+	}
+	if err := fullRank(qr.R(), n); err != nil {
+		return nil, fmt.Errorf("No covariance: %v", err)
+	}
+	m.Cov = invRRT(qr.R())
+	fitted := make([]float64, n)
+	for i, x := range xs {
+		fitted[i] = f(x, p)
+	}
+	m.summarize(ys, fitted, w, sigma != nil)
+	if !rep.Converged {
+		if rep.Iterations >= st.MaxIter {
+			return m, fmt.Errorf("Not convergence in %4d iterations within %10.3e", st.MaxIter, st.FTol)
+		}
+		return m, fmt.Errorf("Not convergence: %s at iteration %d", rep.Reason, rep.Iterations)
+	}
+	return m, nil
+}
+
+// Eval returns the fitted model at x
+func (m *Nonlinear) Eval(x float64) float64 {
+	return m.model(x, m.Params)
+}
+
+// jacobian sets jac(i, j) = sw_i df(x_i; p)/dp_j, by forward differences
+// if no Jacobian is given, and returns the number of evaluations of f at
+// all xs; the difference step turns back at an upper bound
+func jacobian(jac *gnum.Matrix, f Model, xs, p, sw []float64, s *LMSettings) int {
+	n, np := jac.Dims()
+	if s.Jacobian != nil {
+		grad := make([]float64, np)
+		for i, x := range xs {
+			s.Jacobian(x, p, grad)
+			for j := 0; j < np; j++ {
+				jac.Set(i, j, sw[i]*grad[j])
+			}
+		}
+		return 0
+	}
+	f0 := make([]float64, n)
+	for i, x := range xs {
+		f0[i] = f(x, p)
+	}
+	q := append([]float64(nil), p...)
+	for j := 0; j < np; j++ {
+		h := s.Step * math.Max(math.Abs(p[j]), 1.0)
+		if p[j]+h > s.upper(j) {
+			h = -h
+		}
+		q[j] = p[j] + h
+		h = q[j] - p[j]
+		for i, x := range xs {
+			jac.Set(i, j, sw[i]*(f(x, q)-f0[i])/h)
+		}
+		q[j] = p[j]
+	}
+	return np + 1
+}
@@ -0,0 +1,187 @@
+package fit
+
+import (
+	"math"
+	"testing"
+)
+
+// decay is A exp(-x / tau) + C
+func decay(x float64, p []float64) float64 {
+	return p[0]*math.Exp(-x/p[1]) + p[2]
+}
+
+// decayJac is the Jacobian of decay
+func decayJac(x float64, p, grad []float64) {
+	e := math.Exp(-x / p[1])
+	grad[0] = e
+	grad[1] = p[0] * e * x / (p[1] * p[1])
+	grad[2] = 1
+}
+
+// decayData returns noisy samples of decay with A = 5, tau = 2, C = 1
+func decayData() (xs, ys, sigma []float64) {
+	for i := 0; i < 60; i++ {
+		x := 0.2 * float64(i)
+		s := 0.05 + 0.01*float64(i%3)
+		xs = append(xs, x)
+		ys = append(ys, decay(x, []float64{5, 2, 1})+noise(i, s))
+		sigma = append(sigma, s)
+	}
+	return xs, ys, sigma
+}
+
+func TestNewNonlinear(t *testing.T) {
+	xs, ys, sigma := decayData()
+	tests := []struct {
+		name  string
+		sigma []float64
+		s     *LMSettings
+	}{
+		{"finite differences", sigma, nil},
+		{"jacobian", sigma, &LMSettings{Jacobian: decayJac}},
+		{"unweighted", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewNonlinear(decay, xs, ys, tt.sigma, []float64{1, 1, 0}, tt.s)
+			if err != nil {
+				t.Fatalf("NewNonlinear() error = %v, report %+v", err, m.Report)
+			}
+			if !m.Report.Converged || m.Report.Iterations == 0 || m.Report.JacEvals == 0 {
+				t.Errorf("Report = %+v", m.Report)
+			}
+			se := m.StdErr()
+			for j, want := range []float64{5, 2, 1} {
+				if math.Abs(m.Params[j]-want) > 5*se[j] {
+					t.Errorf("Params[%d] = %v +- %v, want %v", j, m.Params[j], se[j], want)
+				}
+			}
+			if rc := m.RedChiSq(); tt.sigma != nil && (rc < 0.1 || rc > 3) {
+				t.Errorf("RedChiSq() = %v, want about 1", rc)
+			}
+			if m.DoF != len(xs)-3 || m.RSquared < 0.99 {
+				t.Errorf("DoF, RSquared = %d, %v", m.DoF, m.RSquared)
+			}
+			if got, want := m.Eval(1), decay(1, m.Params); got != want {
+				t.Errorf("Eval(1) = %v, want %v", got, want)
+			}
+			h := m.Report.History
+			for i := 1; i < len(h); i++ {
+				if h[i] >= h[i-1] {
+					t.Errorf("History not decreasing: %v", h)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestNewNonlinearLinear(t *testing.T) {
+	// a model linear in p is fitted exactly as by NewLinear
+	xs, ys, sigma := decayData()
+	f := func(x float64, p []float64) float64 { return p[0] + p[1]*math.Exp(-x/2) + p[2]*x }
+	basis := []func(float64) float64{
+		func(x float64) float64 { return 1 },
+		func(x float64) float64 { return math.Exp(-x / 2) },
+		func(x float64) float64 { return x },
+	}
+	want, err := NewLinear(xs, ys, sigma, basis...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := NewNonlinear(f, xs, ys, sigma, []float64{0, 0, 0}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the finite difference Jacobian and the stopping test limit the
+	// agreement to a tiny fraction of the standard errors
+	se := want.StdErr()
+	for i := range want.Params {
+		if math.Abs(m.Params[i]-want.Params[i]) > 1e-4*se[i] {
+			t.Errorf("Params[%d] = %v, want %v", i, m.Params[i], want.Params[i])
+		}
+		for j := range want.Params {
+			if math.Abs(m.Cov.At(i, j)-want.Cov.At(i, j)) > 1e-6*math.Sqrt(want.Cov.At(i, i)*want.Cov.At(j, j)) {
+				t.Errorf("Cov(%d,%d) = %v, want %v", i, j, m.Cov.At(i, j), want.Cov.At(i, j))
+			}
+		}
+	}
+	if math.Abs(m.ChiSq-want.ChiSq) > 1e-8*want.ChiSq {
+		t.Errorf("ChiSq = %v, want %v", m.ChiSq, want.ChiSq)
+	}
+}
+
+func TestNewNonlinearGaussian(t *testing.T) {
+	// a narrow peak from a poor start
+	g := func(x float64, p []float64) float64 {
+		d := (x - p[1]) / p[2]
+		return p[0] * math.Exp(-0.5*d*d)
+	}
+	var xs, ys []float64
+	for i := 0; i <= 100; i++ {
+		x := 0.1 * float64(i)
+		xs = append(xs, x)
+		ys = append(ys, g(x, []float64{3, 6.2, 0.7}))
+	}
+	m, err := NewNonlinear(g, xs, ys, nil, []float64{1, 5, 2}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for j, want := range []float64{3, 6.2, 0.7} {
+		if math.Abs(m.Params[j]-want) > 1e-7 {
+			t.Errorf("Params[%d] = %v, want %v", j, m.Params[j], want)
+		}
+	}
+}
+
+func TestNewNonlinearBounds(t *testing.T) {
+	xs, ys, sigma := decayData()
+	// tau = 2 is excluded by tau <= 1.5, so tau stops on the bound
+	s := &LMSettings{Lower: []float64{0, 0.1, math.Inf(-1)}, Upper: []float64{10, 1.5, math.Inf(1)}}
+	m, err := NewNonlinear(decay, xs, ys, sigma, []float64{1, 1, 0}, s)
+	if err != nil {
+		t.Fatalf("NewNonlinear() error = %v, report %+v", err, m.Report)
+	}
+	if m.Params[1] != 1.5 {
+		t.Errorf("Params[1] = %v, want the bound 1.5", m.Params[1])
+	}
+	// the start is projected onto the box
+	m, err = NewNonlinear(decay, xs, ys, sigma, []float64{20, 1, 0}, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Params[0] < 0 || m.Params[0] > 10 {
+		t.Errorf("Params[0] = %v, want in [0, 10]", m.Params[0])
+	}
+}
+
+func TestNewNonlinearErrors(t *testing.T) {
+	xs, ys, sigma := decayData()
+	tests := []struct {
+		name    string
+		f       Model
+		p0      []float64
+		s       *LMSettings
+		wantFit bool
+	}{
+		{"no parameters", decay, nil, nil, false},
+		{"bounds length", decay, []float64{1, 1, 0}, &LMSettings{Lower: []float64{0}}, false},
+		{"crossed bounds", decay, []float64{1, 1, 0}, &LMSettings{Lower: []float64{0, 3, 0}, Upper: []float64{1, 2, 1}}, false},
+		{"max iterations", decay, []float64{1, 1, 0}, &LMSettings{MaxIter: 2}, true},
+		{"dependent", func(x float64, p []float64) float64 { return p[0] * p[1] * x }, []float64{1, 1}, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewNonlinear(tt.f, xs, ys, sigma, tt.p0, tt.s)
+			if err == nil {
+				t.Fatalf("NewNonlinear() error = nil, want an error")
+			}
+			if (m != nil) != tt.wantFit {
+				t.Errorf("NewNonlinear() fit = %v, want a fit %v", m, tt.wantFit)
+			}
+			if m != nil && m.Report.Converged {
+				t.Errorf("Report.Converged = true, want false")
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package fit
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/shyang107/gnum"
+	"github.com/shyang107/gnum/solveeqs"
+)
+
+// Loess is a LOESS local regression smoother: the value at x is that of
+// a polynomial of low degree fitted by weighted least squares to the
+// nearest data, weighted by the tricube of the distance to x relative to
+// the farthest of them.
+type Loess struct {
+	x, y, robust []float64 // sorted data and robustness weights
+	q, degree    int
+	span         float64
+	Fitted       []float64 // the smoothed values at the data, in their order
+	Residuals    []float64 // y_i - Fitted[i]
+	Weights      []float64 // the final robustness weights in [0, 1]
+}
+
+// NewLoess fits LOESS with q = ceil(span n) nearest points per local fit
+// of the given degree (0, 1 or 2). If span > 1 all points are used and
+// the tricube bandwidth is stretched by span. Each of the robustness
+// iterations reweights the data by the bisquare of r_i / (6 median|r|)
+// of the residuals of the previous fit, as in Cleveland's LOWESS.
+//
+// span	: the fraction of the data in each local fit, <= 0 means 0.75
+// degree	: the degree of the local polynomials
+// iterations	: the number of robustness iterations, 0 for none
+func NewLoess(xs, ys []float64, span float64, degree, iterations int) (*Loess, error) {
+	if _, err := weights(xs, ys, nil, 0); err != nil {
+		return nil, err
+	}
+	if degree < 0 || degree > 2 {
+		return nil, fmt.Errorf("degree = %d, want 0, 1 or 2", degree)
+	}
+	if span <= 0.0 {
+		span = 0.75
+	}
+	n := len(xs)
+	q := int(math.Ceil(span * float64(n)))
+	if q > n {
+		q = n
+	}
+	if q < degree+1 {
+		return nil, fmt.Errorf("span %g leaves %d points, too few for degree %d", span, q, degree)
+	}
+	idx := make([]int, n)
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(a, b int) bool { return xs[idx[a]] < xs[idx[b]] })
+	l := &Loess{x: make([]float64, n), y: make([]float64, n), robust: make([]float64, n),
+		q: q, degree: degree, span: span}
+	for k, i := range idx {
+		l.x[k], l.y[k], l.robust[k] = xs[i], ys[i], 1.0
+	}
+	r := make([]float64, n)
+	for iter := 0; ; iter++ {
+		for k, x := range l.x {
+			r[k] = l.y[k] - l.Eval(x)
+		}
+		if iter == iterations {
+			break
+		}
+		abs := make([]float64, n)
+		for k := range r {
+			abs[k] = math.Abs(r[k])
+		}
+		s := 6.0 * median(abs)
+		if s == 0.0 {
+			break
+		}
+		for k := range r {
+			l.robust[k] = Tukey.weight(r[k] / s)
+		}
+	}
+	l.Fitted = make([]float64, n)
+	l.Residuals = make([]float64, n)
+	l.Weights = make([]float64, n)
+	for k, i := range idx {
+		l.Residuals[i] = r[k]
+		l.Fitted[i] = ys[i] - r[k]
+		l.Weights[i] = l.robust[k]
+	}
+	return l, nil
+}
+
+// NewLowess is Cleveland's LOWESS, NewLoess of degree 1 with 3
+// robustness iterations.
+//
+// span	: the fraction of the data in each local fit, <= 0 means 2/3
+func NewLowess(xs, ys []float64, span float64) (*Loess, error) {
+	if span <= 0.0 {
+		span = 2.0 / 3.0
+	}
+	return NewLoess(xs, ys, span, 1, 3)
+}
+
+// Eval returns the local fit at x; a local fit without enough weighted
+// points falls back to a lower degree, and NaN is returned if no point
+// has weight
+func (l *Loess) Eval(x float64) float64 {
+	n := len(l.x)
+	//-----------------------------------------------------
+	// the window [lo, hi) of the q nearest points
+	//-----------------------------------------------------
+	lo := sort.SearchFloat64s(l.x, x)
+	hi := lo
+	for hi-lo < l.q {
+		switch {
+		case lo == 0:
+			hi++
+		case hi == n:
+			lo--
+		case x-l.x[lo-1] <= l.x[hi]-x:
+			lo--
+		default:
+			hi++
+		}
+	}
+	h := math.Max(x-l.x[lo], l.x[hi-1]-x)
+	if l.span > 1.0 {
+		h *= l.span
+	}
+	if h == 0.0 {
+		h = 1.0
+	}
+	//-----------------------------------------------------
+	// the tricube weights and the normal equations in
+	// u = (x_i - x) / h
+	//-----------------------------------------------------
+	w := make([]float64, hi-lo)
+	for k := range w {
+		d := math.Abs(l.x[lo+k]-x) / h
+		if d < 1.0 {
+			t := 1.0 - d*d*d
+			w[k] = t * t * t * l.robust[lo+k]
+		}
+	}
+	for deg := l.degree; deg >= 0; deg-- {
+		p := deg + 1
+		a := gnum.NewMatrix(p, p, nil)
+		b := make([]float64, p)
+		for k, wk := range w {
+			if wk == 0.0 {
+				continue
+			}
+			u := (l.x[lo+k] - x) / h
+			ui := 1.0
+			for i := 0; i < p; i++ {
+				uj := ui
+				for j := 0; j < p; j++ {
+					a.Set(i, j, a.At(i, j)+wk*uj)
+					uj *= u
+				}
+				b[i] += wk * ui * l.y[lo+k]
+				ui *= u
+			}
+		}
+		if a.At(0, 0) == 0.0 {
+			return math.NaN()
+		}
+		if c, err := solveeqs.Solve(a, b); err == nil && !math.IsNaN(c[0]) && !math.IsInf(c[0], 0) {
+			return c[0]
+		}
+	}
+	return math.NaN()
+}
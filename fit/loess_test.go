@@ -0,0 +1,133 @@
+package fit
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewLoessExact(t *testing.T) {
+	// a local polynomial of degree d reproduces polynomials of degree d
+	tests := []struct {
+		name   string
+		degree int
+		f      func(float64) float64
+	}{
+		{"constant", 0, func(x float64) float64 { return 2 }},
+		{"line", 1, func(x float64) float64 { return 1 - 0.5*x }},
+		{"quadratic", 2, func(x float64) float64 { return 1 + x - 0.3*x*x }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var xs, ys []float64
+			for i := 0; i < 30; i++ {
+				x := float64(i)*0.3 + 0.1*math.Sin(float64(i))
+				xs = append(xs, x)
+				ys = append(ys, tt.f(x))
+			}
+			l, err := NewLoess(xs, ys, 0.3, tt.degree, 2)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := range xs {
+				if math.Abs(l.Residuals[i]) > 1e-9 {
+					t.Errorf("Residuals[%d] = %v, want 0", i, l.Residuals[i])
+				}
+			}
+			for _, x := range []float64{0.05, 4.4, 8.6} {
+				if got := l.Eval(x); math.Abs(got-tt.f(x)) > 1e-9 {
+					t.Errorf("Eval(%v) = %v, want %v", x, got, tt.f(x))
+				}
+			}
+		})
+	}
+}
+
+func TestNewLoessSmooth(t *testing.T) {
+	xs, ys := sineData(80, 0.2)
+	for _, degree := range []int{1, 2} {
+		l, err := NewLoess(xs, ys, 0.25, degree, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		es, ed := 0.0, 0.0
+		for i, x := range xs {
+			es += (l.Fitted[i] - math.Sin(x)) * (l.Fitted[i] - math.Sin(x))
+			ed += (ys[i] - math.Sin(x)) * (ys[i] - math.Sin(x))
+			if l.Weights[i] != 1 {
+				t.Errorf("Weights[%d] = %v, want 1", i, l.Weights[i])
+			}
+		}
+		if es > 0.3*ed {
+			t.Errorf("degree %d: squared error %v, data %v", degree, es, ed)
+		}
+	}
+}
+
+func TestNewLowess(t *testing.T) {
+	xs, ys := sineData(60, 0.05)
+	out := []int{7, 23, 41}
+	for _, i := range out {
+		ys[i] += 5
+	}
+	l, err := NewLowess(xs, ys, 0.3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, i := range out {
+		if l.Weights[i] != 0 {
+			t.Errorf("Weights[%d] = %v, want 0", i, l.Weights[i])
+		}
+		if math.Abs(l.Fitted[i]-math.Sin(xs[i])) > 0.1 {
+			t.Errorf("Fitted[%d] = %v, want about %v", i, l.Fitted[i], math.Sin(xs[i]))
+		}
+	}
+	// without robustness iterations the outliers pull the fit
+	p, _ := NewLoess(xs, ys, 0.3, 1, 0)
+	if math.Abs(p.Fitted[out[0]]-math.Sin(xs[out[0]])) < 0.3 {
+		t.Errorf("non-robust Fitted[%d] = %v, want pulled", out[0], p.Fitted[out[0]])
+	}
+}
+
+func TestLoessUnsorted(t *testing.T) {
+	xs, ys := sineData(25, 0.1)
+	want, err := NewLowess(xs, ys, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := len(xs)
+	rx, ry := make([]float64, n), make([]float64, n)
+	for i := range xs {
+		rx[i], ry[i] = xs[n-1-i], ys[n-1-i]
+	}
+	l, err := NewLowess(rx, ry, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range xs {
+		if math.Abs(l.Fitted[n-1-i]-want.Fitted[i]) > 1e-12 {
+			t.Errorf("Fitted[%d] = %v, want %v", n-1-i, l.Fitted[n-1-i], want.Fitted[i])
+		}
+	}
+}
+
+func TestNewLoessErrors(t *testing.T) {
+	xs, ys := sineData(10, 0.1)
+	tests := []struct {
+		name   string
+		ys     []float64
+		span   float64
+		degree int
+	}{
+		{"degree", ys, 0.5, 3},
+		{"negative degree", ys, 0.5, -1},
+		{"span", ys, 0.1, 2},
+		{"length", ys[:5], 0.5, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewLoess(xs, tt.ys, tt.span, tt.degree, 0); err == nil {
+				t.Errorf("NewLoess() error = nil, want an error")
+			}
+		})
+	}
+}
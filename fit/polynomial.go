@@ -0,0 +1,141 @@
+package fit
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/shyang107/gnum"
+)
+
+// Polynomial is the least squares polynomial fit
+//	f(x) = sum_{k=0}^{deg} b_k P_k(x)
+// in the polynomials P_k orthogonal over the weighted data (Forsythe),
+//	P_0 = 1,	P_1 = x - alpha_0,
+//	P_{k+1} = (x - alpha_k) P_k - beta_k P_{k-1}.
+// The normal equations are diagonal in this basis, so a high degree does
+// not suffer from the ill-conditioning of the monomial Vandermonde
+// matrix. Params are the monomial coefficients c_0, ..., c_deg of f;
+// Eval uses the recurrence and is more accurate than them.
+type Polynomial struct {
+	Result
+	alpha, beta, b []float64
+}
+
+// NewPolynomial fits a polynomial of degree deg to the data (xs[i],
+// ys[i]); it needs at least deg+1 distinct abscissae. The coefficients
+// are computed from the running residual (modified Gram-Schmidt).
+//
+// sigma	: the uncertainties of ys, nil for an unweighted fit
+// deg	: the degree
+func NewPolynomial(xs, ys, sigma []float64, deg int) (*Polynomial, error) {
+	if deg < 0 {
+		return nil, fmt.Errorf("deg = %d, want >= 0", deg)
+	}
+	p := deg + 1
+	w, err := weights(xs, ys, sigma, p)
+	if err != nil {
+		return nil, err
+	}
+	sorted := append([]float64(nil), xs...)
+	sort.Float64s(sorted)
+	distinct := 0
+	for i := range sorted {
+		if i == 0 || sorted[i] != sorted[i-1] {
+			distinct++
+		}
+	}
+	if distinct < p {
+		return nil, fmt.Errorf("%d distinct abscissae are too few for degree %d", distinct, deg)
+	}
+	n := len(xs)
+	m := &Polynomial{alpha: make([]float64, p), beta: make([]float64, p), b: make([]float64, p)}
+	//-----------------------------------------------------
+	// pk, pkm1: P_k and P_{k-1} at the data;
+	// ck, ckm1: their monomial coefficients; t: column k
+	// holds those of P_k
+	//-----------------------------------------------------
+	pk := make([]float64, n)
+	pkm1 := make([]float64, n)
+	for i := range pk {
+		pk[i] = 1.0
+	}
+	ck := []float64{1.0}
+	var ckm1 []float64
+	t := gnum.NewMatrix(p, p, nil)
+	resid := append([]float64(nil), ys...)
+	fitted := make([]float64, n)
+	variance := make([]float64, p)
+	nkm1 := 0.0
+	for k := 0; k <= deg; k++ {
+		nk, ry, xp := 0.0, 0.0, 0.0
+		for i := range pk {
+			nk += w[i] * pk[i] * pk[i]
+			ry += w[i] * resid[i] * pk[i]
+			xp += w[i] * xs[i] * pk[i] * pk[i]
+		}
+		m.b[k] = ry / nk
+		variance[k] = 1.0 / nk
+		for i := range pk {
+			fitted[i] += m.b[k] * pk[i]
+			resid[i] -= m.b[k] * pk[i]
+		}
+		for j, c := range ck {
+			t.Set(j, k, c)
+		}
+		m.alpha[k] = xp / nk
+		if k > 0 {
+			m.beta[k] = nk / nkm1
+		}
+		nkm1 = nk
+		//-----------------------------------------------------
+		// P_{k+1} = (x - alpha_k) P_k - beta_k P_{k-1}
+		//-----------------------------------------------------
+		for i, x := range xs {
+			pk[i], pkm1[i] = (x-m.alpha[k])*pk[i]-m.beta[k]*pkm1[i], pk[i]
+		}
+		next := make([]float64, len(ck)+1)
+		for j, c := range ck {
+			next[j+1] += c
+			next[j] -= m.alpha[k] * c
+		}
+		for j, c := range ckm1 {
+			next[j] -= m.beta[k] * c
+		}
+		ck, ckm1 = next, ck
+	}
+	//-----------------------------------------------------
+	// c = T b and Cov = T diag(variance) T^T
+	//-----------------------------------------------------
+	m.Params = make([]float64, p)
+	m.Cov = gnum.NewMatrix(p, p, nil)
+	for i := 0; i < p; i++ {
+		for k := 0; k < p; k++ {
+			m.Params[i] += t.At(i, k) * m.b[k]
+		}
+		for j := 0; j < p; j++ {
+			s := 0.0
+			for k := 0; k < p; k++ {
+				s += t.At(i, k) * variance[k] * t.At(j, k)
+			}
+			m.Cov.Set(i, j, s)
+		}
+	}
+	m.summarize(ys, fitted, w, sigma != nil)
+	return m, nil
+}
+
+// Degree returns the degree of the polynomial
+func (m *Polynomial) Degree() int {
+	return len(m.b) - 1
+}
+
+// Eval returns the fitted polynomial at x by the three-term recurrence
+func (m *Polynomial) Eval(x float64) float64 {
+	pk, pkm1 := 1.0, 0.0
+	s := 0.0
+	for k, bk := range m.b {
+		s += bk * pk
+		pk, pkm1 = (x-m.alpha[k])*pk-m.beta[k]*pkm1, pk
+	}
+	return s
+}
@@ -0,0 +1,106 @@
+package fit
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewPolynomial(t *testing.T) {
+	n := 40
+	xs := make([]float64, n)
+	ys := make([]float64, n)
+	sigma := make([]float64, n)
+	for i := range xs {
+		xs[i] = -2 + 0.1*float64(i)
+		ys[i] = 1 - 2*xs[i] + 0.5*xs[i]*xs[i]*xs[i] + noise(i, 0.05)
+		sigma[i] = 0.05 * (1 + 0.5*math.Abs(xs[i]))
+	}
+	tests := []struct {
+		name  string
+		sigma []float64
+		deg   int
+	}{
+		{"cubic", nil, 3},
+		{"weighted cubic", sigma, 3},
+		{"constant", nil, 0},
+		{"quintic", sigma, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewPolynomial(xs, ys, tt.sigma, tt.deg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// the same fit in the monomial basis by QR
+			want, err := NewLinear(xs, ys, tt.sigma, Monomials(tt.deg)...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if m.Degree() != tt.deg {
+				t.Errorf("Degree() = %d, want %d", m.Degree(), tt.deg)
+			}
+			for i := range want.Params {
+				if math.Abs(m.Params[i]-want.Params[i]) > 1e-10 {
+					t.Errorf("Params[%d] = %v, want %v", i, m.Params[i], want.Params[i])
+				}
+				for j := range want.Params {
+					if math.Abs(m.Cov.At(i, j)-want.Cov.At(i, j)) > 1e-10*math.Abs(want.Cov.At(i, i)) {
+						t.Errorf("Cov(%d,%d) = %v, want %v", i, j, m.Cov.At(i, j), want.Cov.At(i, j))
+					}
+				}
+			}
+			if math.Abs(m.ChiSq-want.ChiSq) > 1e-10*want.ChiSq || math.Abs(m.RSquared-want.RSquared) > 1e-12 {
+				t.Errorf("ChiSq, RSquared = %v, %v, want %v, %v", m.ChiSq, m.RSquared, want.ChiSq, want.RSquared)
+			}
+			for _, x := range []float64{-1.5, 0.3, 1.7} {
+				if math.Abs(m.Eval(x)-want.Eval(x)) > 1e-10 {
+					t.Errorf("Eval(%v) = %v, want %v", x, m.Eval(x), want.Eval(x))
+				}
+			}
+		})
+	}
+}
+
+func TestNewPolynomialStability(t *testing.T) {
+	// a degree 12 polynomial on [1000, 1010]: the monomial normal
+	// equations are hopeless, the orthogonal basis is not
+	f := func(x float64) float64 {
+		u := (x - 1005) / 5
+		return math.Cos(3 * u)
+	}
+	xs := make([]float64, 101)
+	ys := make([]float64, 101)
+	for i := range xs {
+		xs[i] = 1000 + 0.1*float64(i)
+		ys[i] = f(xs[i])
+	}
+	m, err := NewPolynomial(xs, ys, nil, 12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 100; i++ {
+		x := xs[i] + 0.05
+		if math.Abs(m.Eval(x)-f(x)) > 1e-5 {
+			t.Fatalf("Eval(%v) = %v, want %v", x, m.Eval(x), f(x))
+		}
+	}
+}
+
+func TestNewPolynomialErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		xs, ys []float64
+		deg    int
+	}{
+		{"negative degree", []float64{0, 1, 2}, []float64{0, 1, 2}, -1},
+		{"repeated abscissae", []float64{0, 0, 1, 1}, []float64{0, 1, 2, 3}, 2},
+		{"too few", []float64{0, 1}, []float64{0, 1}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewPolynomial(tt.xs, tt.ys, nil, tt.deg); err == nil {
+				t.Errorf("NewPolynomial() error = nil, want an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package fit
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Loss is a robust loss function; its weight function w(u) = psi(u) / u
+// of the scaled residual u = r / (C s) downweights large residuals
+type Loss int
+
+const (
+	// Huber is quadratic for |u| <= 1 and linear beyond,
+	// w = min(1, 1/|u|); convex, so IRLS converges from any start
+	Huber Loss = iota
+	// Tukey is Tukey's bisquare, w = (1 - u^2)^2 for |u| < 1 and 0
+	// beyond, so gross outliers are ignored completely
+	Tukey
+	// Cauchy is log(1 + u^2), w = 1 / (1 + u^2)
+	Cauchy
+)
+
+// String returns the name of the loss
+func (l Loss) String() string {
+	switch l {
+	case Huber:
+		return "Huber"
+	case Tukey:
+		return "Tukey"
+	case Cauchy:
+		return "Cauchy"
+	}
+	return fmt.Sprintf("Loss(%d)", int(l))
+}
+
+// tuning returns the tuning constant with 95% efficiency for normal
+// errors
+func (l Loss) tuning() float64 {
+	switch l {
+	case Tukey:
+		return 4.685
+	case Cauchy:
+		return 2.385
+	}
+	return 1.345
+}
+
+// weight returns w(u)
+func (l Loss) weight(u float64) float64 {
+	u = math.Abs(u)
+	switch l {
+	case Tukey:
+		if u >= 1.0 {
+			return 0.0
+		}
+		return (1.0 - u*u) * (1.0 - u*u)
+	case Cauchy:
+		return 1.0 / (1.0 + u*u)
+	}
+	if u <= 1.0 {
+		return 1.0
+	}
+	return 1.0 / u
+}
+
+// RobustSettings controls NewRobust; the zero value uses the defaults
+type RobustSettings struct {
+	C       float64 // the tuning constant (default 1.345 Huber, 4.685 Tukey, 2.385 Cauchy)
+	Tol     float64 // stop when ||dp|| <= Tol (||p|| + Tol) (default 1e-8)
+	MaxIter int     // the maximum number of iterations (default 50)
+}
+
+// Robust is a linear least squares fit made resistant to outliers by
+// iteratively reweighted least squares (IRLS). Params, Residuals and
+// Cov are those of the final weighted fit; Cov is scaled by the
+// weighted residual variance and is only approximate.
+type Robust struct {
+	Linear
+	Weights    []float64 // the final robust weights in [0, 1]
+	Inliers    []bool    // |r_i| <= 2.5 Scale
+	Scale      float64   // the robust scale of the residuals, MAD / 0.6745
+	Iterations int
+}
+
+// NewRobust fits sum_j p_j basis[j](x) to the data (xs[i], ys[i]) with
+// the loss l by IRLS: starting from ordinary least squares, every
+// iteration estimates the scale s of the residuals by their median
+// absolute deviation and refits with the weights w(r_i / (C s)). Tukey's
+// loss is not convex, so it starts from the Huber fit. If the iteration
+// does not converge, the last fit is returned with an error.
+//
+// l	: the loss
+// s	: the settings, nil means the defaults
+// basis	: the basis functions phi_j
+func NewRobust(xs, ys []float64, l Loss, s *RobustSettings, basis ...func(float64) float64) (*Robust, error) {
+	if l < Huber || l > Cauchy {
+		return nil, fmt.Errorf("Unknown loss %v", l)
+	}
+	var st RobustSettings
+	if s != nil {
+		st = *s
+	}
+	if st.C <= 0.0 {
+		st.C = l.tuning()
+	}
+	if st.Tol <= 0.0 {
+		st.Tol = 1.0e-8
+	}
+	if st.MaxIter <= 0 {
+		st.MaxIter = 50
+	}
+	w, err := weights(xs, ys, nil, len(basis))
+	if err != nil {
+		return nil, err
+	}
+	m, err := linearFit(xs, ys, w, basis, false)
+	if err != nil {
+		return nil, err
+	}
+	r := &Robust{}
+	if l == Tukey {
+		h, err := NewRobust(xs, ys, Huber, &RobustSettings{Tol: st.Tol, MaxIter: st.MaxIter}, basis...)
+		if h == nil {
+			return nil, err
+		}
+		m, w, r.Iterations = &h.Linear, h.Weights, h.Iterations
+	}
+	var scale float64
+	converged := false
+	for iter := 0; iter < st.MaxIter && !converged; iter++ {
+		scale = mad(m.Residuals) / 0.6745
+		if scale == 0.0 {
+			//-----------------------------------------------------
+			// more than half of the points are fitted exactly
+			//-----------------------------------------------------
+			converged = true
+			break
+		}
+		for i, ri := range m.Residuals {
+			w[i] = l.weight(ri / (st.C * scale))
+		}
+		next, err := linearFit(xs, ys, w, basis, false)
+		if err != nil {
+			return nil, err
+		}
+		r.Iterations++
+		dp, pn := 0.0, 0.0
+		for j := range next.Params {
+			dp += (next.Params[j] - m.Params[j]) * (next.Params[j] - m.Params[j])
+			pn += next.Params[j] * next.Params[j]
+		}
+		converged = math.Sqrt(dp) <= st.Tol*(math.Sqrt(pn)+st.Tol)
+		m = next
+	}
+	r.Linear = *m
+	r.Weights = w
+	r.Scale = scale
+	r.Inliers = make([]bool, len(xs))
+	for i, ri := range m.Residuals {
+		r.Inliers[i] = math.Abs(ri) <= 2.5*scale
+	}
+	if !converged {
+		return r, fmt.Errorf("Not convergence in %4d iterations within %10.3e", st.MaxIter, st.Tol)
+	}
+	return r, nil
+}
+
+// median returns the median of x; x is not modified
+func median(x []float64) float64 {
+	s := append([]float64(nil), x...)
+	sort.Float64s(s)
+	n := len(s)
+	if n == 0 {
+		return math.NaN()
+	}
+	if n%2 == 1 {
+		return s[n/2]
+	}
+	return 0.5 * (s[n/2-1] + s[n/2])
+}
+
+// mad returns the median absolute deviation of x from its median
+func mad(x []float64) float64 {
+	med := median(x)
+	d := make([]float64, len(x))
+	for i, xi := range x {
+		d[i] = math.Abs(xi - med)
+	}
+	return median(d)
+}
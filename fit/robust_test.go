@@ -0,0 +1,153 @@
+package fit
+
+import (
+	"math"
+	"testing"
+)
+
+// outlierData returns y = 1 + 2x with small noise and gross outliers at
+// every fifth point
+func outlierData() (xs, ys []float64, outlier []bool) {
+	for i := 0; i < 50; i++ {
+		x := 0.2 * float64(i)
+		y := 1 + 2*x + noise(i, 0.05)
+		bad := i%5 == 2
+		if bad {
+			y += 8 + float64(i%3)
+		}
+		xs = append(xs, x)
+		ys = append(ys, y)
+		outlier = append(outlier, bad)
+	}
+	return xs, ys, outlier
+}
+
+func TestLossWeight(t *testing.T) {
+	tests := []struct {
+		l    Loss
+		u    float64
+		want float64
+	}{
+		{Huber, 0.5, 1},
+		{Huber, -4, 0.25},
+		{Tukey, 0, 1},
+		{Tukey, 0.5, 0.5625},
+		{Tukey, -1.5, 0},
+		{Cauchy, 2, 0.2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.l.String(), func(t *testing.T) {
+			if got := tt.l.weight(tt.u); math.Abs(got-tt.want) > 1e-15 {
+				t.Errorf("%v.weight(%v) = %v, want %v", tt.l, tt.u, got, tt.want)
+			}
+		})
+	}
+	if s := Loss(7).String(); s != "Loss(7)" {
+		t.Errorf("String() = %q, want Loss(7)", s)
+	}
+}
+
+func TestNewRobust(t *testing.T) {
+	xs, ys, outlier := outlierData()
+	ols, err := NewLinear(xs, ys, nil, Monomials(1)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		l   Loss
+		tol float64
+	}{
+		{Huber, 0.1},
+		{Tukey, 0.03},
+		{Cauchy, 0.05},
+	}
+	for _, tt := range tests {
+		t.Run(tt.l.String(), func(t *testing.T) {
+			m, err := NewRobust(xs, ys, tt.l, nil, Monomials(1)...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for j, want := range []float64{1, 2} {
+				if math.Abs(m.Params[j]-want) > tt.tol {
+					t.Errorf("Params[%d] = %v, want %v", j, m.Params[j], want)
+				}
+				if math.Abs(m.Params[j]-want) >= math.Abs(ols.Params[j]-want) {
+					t.Errorf("Params[%d] = %v is no better than least squares %v", j, m.Params[j], ols.Params[j])
+				}
+			}
+			for i := range outlier {
+				if m.Inliers[i] == outlier[i] {
+					t.Errorf("Inliers[%d] = %v, outlier %v", i, m.Inliers[i], outlier[i])
+				}
+			}
+			if m.Iterations == 0 || m.Scale <= 0 || m.Scale > 0.2 {
+				t.Errorf("Iterations, Scale = %d, %v", m.Iterations, m.Scale)
+			}
+			if tt.l == Tukey {
+				for i := range outlier {
+					if outlier[i] && m.Weights[i] != 0 {
+						t.Errorf("Weights[%d] = %v, want 0 for an outlier", i, m.Weights[i])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestNewRobustExact(t *testing.T) {
+	// most points lie exactly on a parabola: the scale vanishes
+	xs := []float64{0, 1, 2, 3, 4, 5, 6}
+	ys := make([]float64, len(xs))
+	for i, x := range xs {
+		ys[i] = 1 - x + 0.5*x*x
+	}
+	ys[3] += 10
+	m, err := NewRobust(xs, ys, Tukey, nil, Monomials(2)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for j, want := range []float64{1, -1, 0.5} {
+		if math.Abs(m.Params[j]-want) > 1e-8 {
+			t.Errorf("Params[%d] = %v, want %v", j, m.Params[j], want)
+		}
+	}
+	if m.Inliers[3] {
+		t.Errorf("Inliers[3] = true, want false")
+	}
+}
+
+func TestNewRobustErrors(t *testing.T) {
+	xs, ys, _ := outlierData()
+	if _, err := NewRobust(xs, ys, Loss(5), nil, Monomials(1)...); err == nil {
+		t.Errorf("NewRobust(unknown loss) error = nil, want an error")
+	}
+	if _, err := NewRobust(xs[:2], ys[:2], Huber, nil, Monomials(2)...); err == nil {
+		t.Errorf("NewRobust(too few points) error = nil, want an error")
+	}
+	m, err := NewRobust(xs, ys, Huber, &RobustSettings{MaxIter: 1}, Monomials(1)...)
+	if err == nil || m == nil {
+		t.Errorf("NewRobust(MaxIter 1) = %v, %v, want a fit and an error", m, err)
+	}
+}
+
+func TestMedian(t *testing.T) {
+	tests := []struct {
+		x         []float64
+		med, want float64
+	}{
+		{[]float64{3, 1, 2}, 2, 1},
+		{[]float64{4, 1, 3, 2}, 2.5, 1},
+		{[]float64{5}, 5, 0},
+	}
+	for _, tt := range tests {
+		if got := median(tt.x); got != tt.med {
+			t.Errorf("median(%v) = %v, want %v", tt.x, got, tt.med)
+		}
+		if got := mad(tt.x); got != tt.want {
+			t.Errorf("mad(%v) = %v, want %v", tt.x, got, tt.want)
+		}
+	}
+	if !math.IsNaN(median(nil)) {
+		t.Errorf("median(nil) = %v, want NaN", median(nil))
+	}
+}
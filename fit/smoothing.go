@@ -0,0 +1,243 @@
+package fit
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/shyang107/gnum/interpolate"
+)
+
+// SmoothingSpline is the cubic smoothing spline g minimizing
+//	sum_i w_i (y_i - g(x_i))^2 + lambda int g''(x)^2 dx,
+// a natural cubic spline with knots at the data; lambda -> 0 interpolates
+// and lambda -> inf gives the least squares line. Outside the data it is
+// extrapolated by the end pieces.
+type SmoothingSpline struct {
+	*interpolate.CubicSpline
+	Lambda    float64   // the smoothing parameter
+	GCV       float64   // the generalized cross-validation score
+	DoF       float64   // the effective degrees of freedom tr(A), from 2 to n
+	Fitted    []float64 // g(x_i) in the order of the data
+	Residuals []float64 // y_i - g(x_i)
+}
+
+// NewSmoothingSpline fits the cubic smoothing spline by the Reinsch
+// algorithm: with Q^T g = R gamma relating the values g and the second
+// derivatives gamma at the interior knots,
+//	(R + lambda Q^T W^(-1) Q) gamma = Q^T y,	g = y - lambda W^(-1) Q gamma,
+// a pentadiagonal system solved by LDL^T in O(n). The trace of the hat
+// matrix A, g = A y, comes from the band of the inverse (Hutchinson and
+// de Hoog), also in O(n), so that
+//	GCV(lambda) = n sum_i w_i (y_i - g_i)^2 / (n - tr A)^2
+// is cheap; if lambda <= 0 it is minimized over lambda by a grid search
+// on log lambda refined by golden section. The abscissae need not be
+// sorted but must be distinct, at least 3 of them.
+//
+// sigma	: the uncertainties of ys, nil for equal weights
+// lambda	: the smoothing parameter, <= 0 to choose it by GCV
+func NewSmoothingSpline(xs, ys, sigma []float64, lambda float64) (*SmoothingSpline, error) {
+	w, err := weights(xs, ys, sigma, 0)
+	if err != nil {
+		return nil, err
+	}
+	n := len(xs)
+	if n < 3 {
+		return nil, fmt.Errorf("A smoothing spline needs at least 3 points, got %d", n)
+	}
+	idx := make([]int, n)
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(a, b int) bool { return xs[idx[a]] < xs[idx[b]] })
+	ss := &smoother{x: make([]float64, n), y: make([]float64, n), w: make([]float64, n)}
+	for k, i := range idx {
+		ss.x[k], ss.y[k], ss.w[k] = xs[i], ys[i], w[i]
+		if k > 0 && ss.x[k] == ss.x[k-1] {
+			return nil, fmt.Errorf("Duplicate abscissa %g", ss.x[k])
+		}
+	}
+	ss.setup()
+	if lambda <= 0.0 {
+		lambda = ss.minGCV()
+	}
+	g, trA := ss.solve(lambda)
+	sp, err := interpolate.NewCubicSpline(ss.x, g, interpolate.Natural)
+	if err != nil {
+		return nil, err
+	}
+	s := &SmoothingSpline{CubicSpline: sp, Lambda: lambda, DoF: trA,
+		Fitted: make([]float64, n), Residuals: make([]float64, n)}
+	for k, i := range idx {
+		s.Fitted[i] = g[k]
+		s.Residuals[i] = ys[i] - g[k]
+	}
+	s.GCV = ss.gcv(g, trA)
+	return s, nil
+}
+
+// smoother holds the sorted data and the band matrices of the Reinsch
+// algorithm; r0, r1 are the diagonals of R and b0, b1, b2 those of
+// B = Q^T W^(-1) Q, all of the order m = n - 2
+type smoother struct {
+	x, y, w    []float64
+	h          []float64
+	r0, r1     []float64
+	b0, b1, b2 []float64
+	qty        []float64 // Q^T y
+}
+
+// q returns the three nonzeros Q[j][j], Q[j+1][j], Q[j+2][j] of column j
+func (ss *smoother) q(j int) (float64, float64, float64) {
+	return 1.0 / ss.h[j], -1.0/ss.h[j] - 1.0/ss.h[j+1], 1.0 / ss.h[j+1]
+}
+
+// setup computes h, R, B and Q^T y
+func (ss *smoother) setup() {
+	n := len(ss.x)
+	m := n - 2
+	ss.h = make([]float64, n-1)
+	for i := range ss.h {
+		ss.h[i] = ss.x[i+1] - ss.x[i]
+	}
+	ss.r0, ss.r1 = make([]float64, m), make([]float64, m)
+	ss.b0, ss.b1, ss.b2 = make([]float64, m), make([]float64, m), make([]float64, m)
+	ss.qty = make([]float64, m)
+	for j := 0; j < m; j++ {
+		ss.r0[j] = (ss.h[j] + ss.h[j+1]) / 3.0
+		ss.r1[j] = ss.h[j+1] / 6.0
+		a, b, c := ss.q(j)
+		ss.b0[j] = a*a/ss.w[j] + b*b/ss.w[j+1] + c*c/ss.w[j+2]
+		if j+1 < m {
+			a1, b1, _ := ss.q(j + 1)
+			ss.b1[j] = b*a1/ss.w[j+1] + c*b1/ss.w[j+2]
+		}
+		if j+2 < m {
+			a2, _, _ := ss.q(j + 2)
+			ss.b2[j] = c * a2 / ss.w[j+2]
+		}
+		ss.qty[j] = a*ss.y[j] + b*ss.y[j+1] + c*ss.y[j+2]
+	}
+}
+
+// solve returns the fitted values g and tr A for lambda
+func (ss *smoother) solve(lambda float64) (g []float64, trA float64) {
+	n := len(ss.x)
+	m := n - 2
+	//-----------------------------------------------------
+	// M = R + lambda B = L D L^T, L unit lower triangular
+	// with the subdiagonals l1, l2
+	//-----------------------------------------------------
+	d := make([]float64, m)
+	l1 := make([]float64, m)
+	l2 := make([]float64, m)
+	for i := 0; i < m; i++ {
+		d[i] = ss.r0[i] + lambda*ss.b0[i]
+		m1 := ss.r1[i] + lambda*ss.b1[i]
+		if i >= 1 {
+			d[i] -= d[i-1] * l1[i-1] * l1[i-1]
+			m1 -= l2[i-1] * d[i-1] * l1[i-1]
+		}
+		if i >= 2 {
+			d[i] -= d[i-2] * l2[i-2] * l2[i-2]
+		}
+		l1[i] = m1 / d[i]
+		l2[i] = lambda * ss.b2[i] / d[i]
+	}
+	gamma := append([]float64(nil), ss.qty...)
+	for i := 0; i < m; i++ {
+		if i >= 1 {
+			gamma[i] -= l1[i-1] * gamma[i-1]
+		}
+		if i >= 2 {
+			gamma[i] -= l2[i-2] * gamma[i-2]
+		}
+	}
+	for i := m - 1; i >= 0; i-- {
+		gamma[i] /= d[i]
+		if i+1 < m {
+			gamma[i] -= l1[i] * gamma[i+1]
+		}
+		if i+2 < m {
+			gamma[i] -= l2[i] * gamma[i+2]
+		}
+	}
+	g = append([]float64(nil), ss.y...)
+	for j := 0; j < m; j++ {
+		a, b, c := ss.q(j)
+		g[j] -= lambda * a * gamma[j] / ss.w[j]
+		g[j+1] -= lambda * b * gamma[j] / ss.w[j+1]
+		g[j+2] -= lambda * c * gamma[j] / ss.w[j+2]
+	}
+	//-----------------------------------------------------
+	// the band s0, s1, s2 of S = M^(-1) from
+	// S_ij = delta_ij / d_i - l1_i S_(i+1)j - l2_i S_(i+2)j,
+	// i <= j, backwards; tr A = n - lambda tr(S B)
+	//-----------------------------------------------------
+	s0 := make([]float64, m+2)
+	s1 := make([]float64, m+2)
+	s2 := make([]float64, m+2)
+	tr := 0.0
+	for i := m - 1; i >= 0; i-- {
+		s2[i] = -l1[i]*s1[i+1] - l2[i]*s0[i+2]
+		s1[i] = -l1[i]*s0[i+1] - l2[i]*s1[i+1]
+		s0[i] = 1.0/d[i] - l1[i]*s1[i] - l2[i]*s2[i]
+		tr += s0[i]*ss.b0[i] + 2.0*(s1[i]*ss.b1[i]+s2[i]*ss.b2[i])
+	}
+	return g, float64(n) - lambda*tr
+}
+
+// gcv returns the GCV score of the fitted values g
+func (ss *smoother) gcv(g []float64, trA float64) float64 {
+	n := float64(len(ss.x))
+	rss := 0.0
+	for i := range g {
+		rss += ss.w[i] * (ss.y[i] - g[i]) * (ss.y[i] - g[i])
+	}
+	return n * rss / ((n - trA) * (n - trA))
+}
+
+// minGCV returns the lambda minimizing GCV: a grid on log10(lambda / r),
+// r = tr R / tr B balancing the two terms, from -10 to 6, then golden
+// section between the neighbours of the best grid point
+func (ss *smoother) minGCV() float64 {
+	r0, b0 := 0.0, 0.0
+	for j := range ss.r0 {
+		r0 += ss.r0[j]
+		b0 += ss.b0[j]
+	}
+	ratio := r0 / b0
+	score := func(t float64) float64 {
+		g, trA := ss.solve(ratio * math.Pow(10.0, t))
+		return ss.gcv(g, trA)
+	}
+	const lo, hi, steps = -10.0, 6.0, 64
+	dt := (hi - lo) / steps
+	best, fbest := lo, math.Inf(1)
+	for k := 0; k <= steps; k++ {
+		t := lo + dt*float64(k)
+		if f := score(t); f < fbest {
+			best, fbest = t, f
+		}
+	}
+	a, b := best-dt, best+dt
+	const golden = 0.6180339887498949
+	c, d := b-golden*(b-a), a+golden*(b-a)
+	fc, fd := score(c), score(d)
+	for b-a > 1.0e-6 {
+		if fc < fd {
+			b, d, fd = d, c, fc
+			c = b - golden*(b-a)
+			fc = score(c)
+		} else {
+			a, c, fc = c, d, fd
+			d = a + golden*(b-a)
+			fd = score(d)
+		}
+	}
+	t := 0.5 * (a + b)
+	if score(t) > fbest {
+		t = best
+	}
+	return ratio * math.Pow(10.0, t)
+}
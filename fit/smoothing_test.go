@@ -0,0 +1,160 @@
+package fit
+
+import (
+	"math"
+	"testing"
+)
+
+// sineData returns noisy samples of sin(x) on [0, 2 pi]
+func sineData(n int, amp float64) (xs, ys []float64) {
+	for i := 0; i < n; i++ {
+		x := 2 * math.Pi * float64(i) / float64(n-1)
+		xs = append(xs, x)
+		ys = append(ys, math.Sin(x)+noise(i, amp))
+	}
+	return xs, ys
+}
+
+func TestNewSmoothingSpline(t *testing.T) {
+	xs, ys := sineData(60, 0.2)
+	sigma := make([]float64, len(xs))
+	for i := range sigma {
+		sigma[i] = 0.2
+	}
+	tests := []struct {
+		name  string
+		sigma []float64
+	}{
+		{"unweighted", nil},
+		{"sigma", sigma},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewSmoothingSpline(xs, ys, tt.sigma, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s.Lambda <= 0 || s.DoF < 3 || s.DoF > 20 {
+				t.Errorf("Lambda, DoF = %v, %v", s.Lambda, s.DoF)
+			}
+			// the smoothed curve is closer to sin than the data
+			es, ed := 0.0, 0.0
+			for i, x := range xs {
+				es += (s.Eval(x) - math.Sin(x)) * (s.Eval(x) - math.Sin(x))
+				ed += (ys[i] - math.Sin(x)) * (ys[i] - math.Sin(x))
+				if math.Abs(s.Fitted[i]-s.Eval(x)) > 1e-12 || math.Abs(s.Residuals[i]-(ys[i]-s.Fitted[i])) > 1e-12 {
+					t.Errorf("Fitted[%d], Residuals[%d] = %v, %v", i, i, s.Fitted[i], s.Residuals[i])
+				}
+			}
+			if es > 0.25*ed {
+				t.Errorf("squared error %v, data %v", es, ed)
+			}
+			// GCV is minimal at Lambda
+			for _, f := range []float64{0.5, 2} {
+				o, _ := NewSmoothingSpline(xs, ys, tt.sigma, f*s.Lambda)
+				if o.GCV < s.GCV {
+					t.Errorf("GCV(%v lambda) = %v < GCV(lambda) = %v", f, o.GCV, s.GCV)
+				}
+			}
+		})
+	}
+}
+
+func TestSmoothingSplineLimits(t *testing.T) {
+	xs, ys := sineData(20, 0.3)
+	// small lambda interpolates
+	s, err := NewSmoothingSpline(xs, ys, nil, 1e-12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, r := range s.Residuals {
+		if math.Abs(r) > 1e-8 {
+			t.Errorf("Residuals[%d] = %v, want 0", i, r)
+		}
+	}
+	if math.Abs(s.DoF-20) > 1e-6 {
+		t.Errorf("DoF = %v, want 20", s.DoF)
+	}
+	// large lambda gives the least squares line
+	s, err = NewSmoothingSpline(xs, ys, nil, 1e12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, b, _, _, _ := lineFit(xs, ys, nil)
+	for i, x := range xs {
+		if math.Abs(s.Fitted[i]-(a+b*x)) > 1e-6 {
+			t.Errorf("Fitted[%d] = %v, want %v", i, s.Fitted[i], a+b*x)
+		}
+	}
+	if math.Abs(s.DoF-2) > 1e-6 {
+		t.Errorf("DoF = %v, want 2", s.DoF)
+	}
+}
+
+func TestSmoothingSplineTrace(t *testing.T) {
+	// tr A from the band of the inverse equals the trace of the hat
+	// matrix built column by column, unequal spacing and weights
+	n := 12
+	xs := make([]float64, n)
+	w := make([]float64, n)
+	for i := range xs {
+		xs[i] = float64(i) + 0.3*math.Sin(float64(i))
+		w[i] = 1 + 0.5*math.Cos(float64(i))
+	}
+	for _, lambda := range []float64{1e-3, 0.1, 10} {
+		tr := 0.0
+		var trA float64
+		for k := 0; k < n; k++ {
+			e := make([]float64, n)
+			e[k] = 1
+			ss := &smoother{x: xs, y: e, w: w}
+			ss.setup()
+			var g []float64
+			g, trA = ss.solve(lambda)
+			tr += g[k]
+		}
+		if math.Abs(trA-tr) > 1e-10*tr {
+			t.Errorf("lambda %v: tr A = %v, want %v", lambda, trA, tr)
+		}
+	}
+}
+
+func TestSmoothingSplineUnsorted(t *testing.T) {
+	xs, ys := sineData(15, 0.1)
+	want, err := NewSmoothingSpline(xs, ys, nil, 0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := len(xs)
+	rx, ry := make([]float64, n), make([]float64, n)
+	for i := range xs {
+		rx[i], ry[i] = xs[n-1-i], ys[n-1-i]
+	}
+	s, err := NewSmoothingSpline(rx, ry, nil, 0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range xs {
+		if math.Abs(s.Fitted[n-1-i]-want.Fitted[i]) > 1e-12 {
+			t.Errorf("Fitted[%d] = %v, want %v", n-1-i, s.Fitted[n-1-i], want.Fitted[i])
+		}
+	}
+}
+
+func TestNewSmoothingSplineErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		xs, ys []float64
+	}{
+		{"too few", []float64{0, 1}, []float64{1, 2}},
+		{"duplicate", []float64{0, 1, 1, 2}, []float64{1, 2, 3, 4}},
+		{"length", []float64{0, 1, 2}, []float64{1, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewSmoothingSpline(tt.xs, tt.ys, nil, 1); err == nil {
+				t.Errorf("NewSmoothingSpline() error = nil, want an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+// Package gonumadapt converts between gnum.Matrix / gnum.Vector and the
+// gonum.org/v1/gonum/mat interfaces. It lives in its own package so that
+// gnum itself does not depend on gonum.
+package gonumadapt
+
+import (
+	"github.com/shyang107/gnum"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/mat"
+)
+
+// Matrix wraps a *gnum.Matrix as a mat.Matrix and a mat.Mutable without
+// copying
+type Matrix struct {
+	*gnum.Matrix
+}
+
+// T returns the transpose view of m
+func (m Matrix) T() mat.Matrix {
+	return mat.Transpose{Matrix: m}
+}
+
+// Dense returns a *mat.Dense that shares the storage of m; changes made
+// through either one are seen by the other
+func Dense(m *gnum.Matrix) *mat.Dense {
+	r, c, stride, data := m.RawMatrix()
+	if r == 0 || c == 0 {
+		return &mat.Dense{}
+	}
+	var d mat.Dense
+	d.SetRawMatrix(blas64.General{Rows: r, Cols: c, Stride: stride, Data: data})
+	return &d
+}
+
+// FromDense returns a *gnum.Matrix that shares the storage of d
+func FromDense(d *mat.Dense) *gnum.Matrix {
+	raw := d.RawMatrix()
+	return gnum.NewMatrixStride(raw.Rows, raw.Cols, raw.Stride, raw.Data)
+}
+
+// FromMatrix copies any mat.Matrix into a new *gnum.Matrix
+func FromMatrix(a mat.Matrix) *gnum.Matrix {
+	r, c := a.Dims()
+	m := gnum.NewMatrix(r, c, nil)
+	for i := 0; i < r; i++ {
+		row := m.Row(i)
+		for j := range row {
+			row[j] = a.At(i, j)
+		}
+	}
+	return m
+}
+
+// VecDense returns a *mat.VecDense that shares the storage of v
+func VecDense(v gnum.Vector) *mat.VecDense {
+	if len(v) == 0 {
+		return &mat.VecDense{}
+	}
+	return mat.NewVecDense(len(v), v)
+}
+
+// FromVector copies any mat.Vector into a new gnum.Vector
+func FromVector(a mat.Vector) gnum.Vector {
+	v := gnum.NewVector(a.Len())
+	for i := range v {
+		v[i] = a.AtVec(i)
+	}
+	return v
+}
@@ -0,0 +1,47 @@
+package gonumadapt
+
+import (
+	"testing"
+
+	"github.com/shyang107/gnum"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestDense(t *testing.T) {
+	m := gnum.NewMatrix(3, 3, []float64{1, 2, 3, 4, 5, 6, 7, 8, 10})
+	view := m.Slice(1, 3, 0, 2)
+	d := Dense(view)
+	if r, c := d.Dims(); r != 2 || c != 2 || d.At(1, 1) != 8 {
+		t.Errorf("Dense() = %v", mat.Formatted(d))
+	}
+	d.Set(0, 0, -4)
+	if m.At(1, 0) != -4 {
+		t.Errorf("Dense() does not share storage, At(1, 0) = %v", m.At(1, 0))
+	}
+	back := FromDense(d)
+	back.Set(1, 1, -8)
+	if m.At(2, 1) != -8 {
+		t.Errorf("FromDense() does not share storage, At(2, 1) = %v", m.At(2, 1))
+	}
+}
+
+func TestMatrix(t *testing.T) {
+	a := gnum.NewMatrix(2, 3, []float64{1, 2, 3, 4, 5, 6})
+	var p mat.Dense
+	p.Mul(Matrix{a}, Matrix{a}.T())
+	want := []float64{14, 32, 32, 77}
+	got := FromMatrix(&p)
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 2; j++ {
+			if got.At(i, j) != want[2*i+j] {
+				t.Errorf("A A^T (%d,%d) = %v, want %v", i, j, got.At(i, j), want[2*i+j])
+			}
+		}
+	}
+	v := gnum.Vector{1, 2, 3}
+	var y mat.VecDense
+	y.MulVec(Matrix{a}, VecDense(v))
+	if got := FromVector(&y); got[0] != 14 || got[1] != 32 {
+		t.Errorf("A v = %v, want [14 32]", got)
+	}
+}
@@ -0,0 +1,61 @@
+package integrate
+
+import (
+	"runtime"
+	"sync"
+)
+
+// BatchFunc evaluates an integrand at all the nodes xs at once and
+// stores f(xs[i]) in out[i]; len(out) == len(xs)
+type BatchFunc func(xs []float64, out []float64)
+
+// Sequential returns a BatchFunc that evaluates f on the nodes one by one
+func Sequential(f func(float64) float64) BatchFunc {
+	return func(xs []float64, out []float64) {
+		for i, x := range xs {
+			out[i] = f(x)
+		}
+	}
+}
+
+// Parallel returns a BatchFunc that evaluates f on the nodes concurrently
+//
+// The nodes are cut into contiguous chunks, one per worker, and every
+// value is written back to its own index, so the results do not depend
+// on the scheduling. f must be safe for concurrent use.
+//
+// workers	: the number of goroutines; <= 0 means runtime.NumCPU()
+func Parallel(f func(float64) float64, workers int) BatchFunc {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	return func(xs []float64, out []float64) {
+		n := len(xs)
+		nw := workers
+		if nw > n {
+			nw = n
+		}
+		if nw <= 1 {
+			for i, x := range xs {
+				out[i] = f(x)
+			}
+			return
+		}
+		var wg sync.WaitGroup
+		chunk := (n + nw - 1) / nw
+		for lo := 0; lo < n; lo += chunk {
+			hi := lo + chunk
+			if hi > n {
+				hi = n
+			}
+			wg.Add(1)
+			go func(lo, hi int) {
+				defer wg.Done()
+				for i := lo; i < hi; i++ {
+					out[i] = f(xs[i])
+				}
+			}(lo, hi)
+		}
+		wg.Wait()
+	}
+}
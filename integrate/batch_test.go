@@ -0,0 +1,44 @@
+package integrate
+
+import (
+	"math"
+	"sync/atomic"
+	"testing"
+)
+
+func TestParallel(t *testing.T) {
+	xs := make([]float64, 101)
+	for i := range xs {
+		xs[i] = 0.01 * float64(i)
+	}
+	want := make([]float64, len(xs))
+	Sequential(math.Exp)(xs, want)
+	tests := []struct {
+		name    string
+		workers int
+	}{
+		{"one worker", 1},
+		{"four workers", 4},
+		{"more workers than nodes", 500},
+		{"default workers", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int64
+			f := func(x float64) float64 {
+				atomic.AddInt64(&calls, 1)
+				return math.Exp(x)
+			}
+			got := make([]float64, len(xs))
+			Parallel(f, tt.workers)(xs, got)
+			if calls != int64(len(xs)) {
+				t.Errorf("Parallel() calls = %d, want %d", calls, len(xs))
+			}
+			for i := range got {
+				if got[i] != want[i] {
+					t.Errorf("Parallel() out[%d] = %v, want %v", i, got[i], want[i])
+				}
+			}
+		})
+	}
+}
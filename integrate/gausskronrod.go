@@ -0,0 +1,182 @@
+package integrate
+
+import (
+	"fmt"
+	"math"
+)
+
+// abscissae and weights of the 21-point Kronrod rule and the embedded
+// 10-point Gauss rule (QUADPACK qk21)
+var (
+	xgk21 = [11]float64{
+		0.995657163025808080735527280689003,
+		0.973906528517171720077964012084452,
+		0.930157491355708226001207180059508,
+		0.865063366688984510732096688423493,
+		0.780817726586416897063717578345042,
+		0.679409568299024406234327365114874,
+		0.562757134668604683339000099272694,
+		0.433395394129247190799265943165784,
+		0.294392862701460198131126603103866,
+		0.148874338981631210884826001129720,
+		0.000000000000000000000000000000000,
+	}
+	wgk21 = [11]float64{
+		0.011694638867371874278064396062192,
+		0.032558162307964727478818972459390,
+		0.054755896574351996031381300244580,
+		0.075039674810919952767043140916190,
+		0.093125454583697605535065465083366,
+		0.109387158802297641899210590325805,
+		0.123491976262065851077208896601480,
+		0.134709217311473325928054001771707,
+		0.142775938577060080797094273138717,
+		0.147739104901338491374841515972068,
+		0.149445554002916905664936468389821,
+	}
+	wg10 = [5]float64{
+		0.066671344308688137593568809893332,
+		0.149451349150580593145776339657697,
+		0.219086362515982043995534934228163,
+		0.269266719309996355091226921569469,
+		0.295524224714752870173892994651338,
+	}
+)
+
+// qk21 integrates f over [a,b] with the 21-point Gauss-Kronrod rule
+//
+// output
+//	result		: the 21-point Kronrod approximation
+//	abserr		: estimate of the absolute error
+func qk21(f func(float64) float64, a, b float64) (result, abserr float64) {
+	centr := 0.5 * (a + b)
+	hlgth := 0.5 * (b - a)
+	fc := f(centr)
+	resk := fc * wgk21[10]
+	resg := 0.0
+	for j := 0; j < 10; j++ {
+		dx := hlgth * xgk21[j]
+		fsum := f(centr-dx) + f(centr+dx)
+		resk += wgk21[j] * fsum
+		if j%2 == 1 {
+			resg += wg10[j/2] * fsum
+		}
+	}
+	result = resk * hlgth
+	abserr = math.Abs((resk - resg) * hlgth)
+	return result, abserr
+}
+
+// segment is a subinterval kept by the adaptive driver
+type segment struct {
+	a, b, result, abserr float64
+}
+
+// adaptive bisects [a,b] until the summed error estimate of rule falls
+// below max(epsabs, epsrel*|result|)
+//
+// rule		: integrates over one subinterval and returns the value,
+//			  its error estimate and the number of function evaluations
+// limit	: the maximum number of subintervals (default 100)
+func adaptive(rule func(a, b float64) (float64, float64, int), a, b, epsabs, epsrel float64, limit int) (result, abserr float64, neval int, err error) {
+	if limit <= 0 {
+		limit = 100
+	}
+	r, e, n := rule(a, b)
+	neval += n
+	segs := []segment{{a, b, r, e}}
+	result, abserr = r, e
+	for len(segs) < limit {
+		if abserr <= math.Max(epsabs, epsrel*math.Abs(result)) {
+			return result, abserr, neval, nil
+		}
+		//-----------------------------------------------------
+		// bisect the subinterval with the largest error
+		//-----------------------------------------------------
+		k := 0
+		for i := range segs {
+			if segs[i].abserr > segs[k].abserr {
+				k = i
+			}
+		}
+		s := segs[k]
+		m := 0.5 * (s.a + s.b)
+		if m <= s.a || m >= s.b {
+			return result, abserr, neval, fmt.Errorf("Roundoff error: interval [%13.6e, %13.6e] cannot be bisected", s.a, s.b)
+		}
+		r1, e1, n1 := rule(s.a, m)
+		r2, e2, n2 := rule(m, s.b)
+		neval += n1 + n2
+		segs[k] = segment{s.a, m, r1, e1}
+		segs = append(segs, segment{m, s.b, r2, e2})
+		result = 0.0
+		abserr = 0.0
+		for i := range segs {
+			result += segs[i].result
+			abserr += segs[i].abserr
+		}
+	}
+	if abserr <= math.Max(epsabs, epsrel*math.Abs(result)) {
+		return result, abserr, neval, nil
+	}
+	return result, abserr, neval, fmt.Errorf("The number of subintervals exceeds, limit = %4d, abserr = %10.3e", limit, abserr)
+}
+
+// qag integrates f over the finite range [a,b] by adaptive 21-point
+// Gauss-Kronrod quadrature
+func qag(f func(float64) float64, a, b, epsabs, epsrel float64, limit int) (result, abserr float64, neval int, err error) {
+	rule := func(a, b float64) (float64, float64, int) {
+		r, e := qk21(f, a, b)
+		return r, e, 21
+	}
+	return adaptive(rule, a, b, epsabs, epsrel, limit)
+}
+
+// qagi integrates f over the semi-infinite range [a,+inf) by mapping
+// x = a + (1-t)/t onto t in (0,1]
+func qagi(f func(float64) float64, a, epsabs, epsrel float64, limit int) (result, abserr float64, neval int, err error) {
+	g := func(t float64) float64 {
+		return f(a+(1.0-t)/t) / (t * t)
+	}
+	return qag(g, 0.0, 1.0, epsabs, epsrel, limit)
+}
+
+// wynnEpsilon extrapolates the limit of the partial sums s by Wynn's
+// epsilon algorithm
+//
+// output
+//	limit		: the extrapolated limit
+//	abserr		: the difference of the last two estimates
+func wynnEpsilon(s []float64) (limit, abserr float64) {
+	n := len(s)
+	if n == 0 {
+		return 0.0, math.Inf(1)
+	}
+	limit = s[n-1]
+	if n < 3 {
+		if n == 2 {
+			return limit, math.Abs(s[1] - s[0])
+		}
+		return limit, math.Inf(1)
+	}
+	prev := make([]float64, n+1)
+	cur := append([]float64(nil), s...)
+	est := []float64{s[n-2], s[n-1]}
+	for k := 1; len(cur) > 1; k++ {
+		next := make([]float64, len(cur)-1)
+		for i := range next {
+			d := cur[i+1] - cur[i]
+			if d == 0.0 {
+				// the table has converged
+				return cur[i+1], math.Abs(est[len(est)-1] - cur[i+1])
+			}
+			next[i] = prev[i+1] + 1.0/d
+		}
+		if k%2 == 0 {
+			est = append(est, next[len(next)-1])
+		}
+		prev, cur = cur, next
+	}
+	m := len(est)
+	return est[m-1], math.Abs(est[m-1] - est[m-2])
+}
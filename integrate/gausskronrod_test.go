@@ -0,0 +1,62 @@
+package integrate
+
+import (
+	"math"
+	"testing"
+)
+
+func Test_qag(t *testing.T) {
+	type args struct {
+		f      func(float64) float64
+		a      float64
+		b      float64
+		epsabs float64
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantResult float64
+	}{
+		{
+			"Case 1 : f(x) = 1.0 / math.Sqrt(1.0+x*x)",
+			args{Fa, 0.0, 1.0, 1.0e-12},
+			0.8813735870195430,
+		},
+		{
+			"Case 2 : f(x) = x * math.Sin(x)",
+			args{Fb, -1.0, 1.0, 1.0e-12},
+			0.6023373578795136,
+		},
+		{
+			"Case 3 : f(x) = 1/sqrt(x)",
+			args{func(x float64) float64 { return 1.0 / math.Sqrt(x) }, 0.0, 1.0, 1.0e-8},
+			2.0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotResult, _, _, err := qag(tt.args.f, tt.args.a, tt.args.b, tt.args.epsabs, 0.0, 0)
+			if err != nil {
+				t.Errorf("qag() error = %v", err)
+				return
+			}
+			if math.Abs(gotResult-tt.wantResult) > 10.0*tt.args.epsabs {
+				t.Errorf("qag() = %v, want %v", gotResult, tt.wantResult)
+			}
+		})
+	}
+}
+
+func Test_wynnEpsilon(t *testing.T) {
+	// partial sums of 1 - 1/2 + 1/3 - ... = ln 2
+	s := make([]float64, 20)
+	sum := 0.0
+	for k := range s {
+		sum += math.Pow(-1.0, float64(k)) / float64(k+1)
+		s[k] = sum
+	}
+	gotLimit, _ := wynnEpsilon(s)
+	if math.Abs(gotLimit-math.Ln2) > 1.0e-12 {
+		t.Errorf("wynnEpsilon() = %v, want %v", gotLimit, math.Ln2)
+	}
+}
@@ -0,0 +1,349 @@
+package integrate
+
+import (
+	"fmt"
+	"math"
+)
+
+// Weight selects the trigonometric factor w(x) of an oscillatory
+// integral Int_a^b f(x) w(x) dx
+type Weight int
+
+const (
+	Cos Weight = iota // w(x) = cos(omega*x)
+	Sin               // w(x) = sin(omega*x)
+)
+
+// eval returns w(x) for the frequency omega
+func (w Weight) eval(omega, x float64) float64 {
+	if w == Sin {
+		return math.Sin(omega * x)
+	}
+	return math.Cos(omega * x)
+}
+
+// Filon integrates f(x)*w(x) over [a,b] using Filon's method
+//
+// f is approximated by a parabola on each pair of panels and the product
+// with cos(omega*x) or sin(omega*x) is integrated exactly, so the
+// accuracy does not degrade as omega grows.
+//
+// f 		: the non-oscillatory part of the integrand
+// omega	: the frequency of w(x)
+// w		: Cos or Sin
+// n		: the number of panels, a positive even number
+func Filon(f func(float64) float64, a, b, omega float64, w Weight, n int) (area float64, err error) {
+	//-----------------------------------------------------
+	// area = Int_a^b f(x) w(omega*x) dx
+	//-----------------------------------------------------
+	if n <= 0 || n%2 != 0 {
+		return 0.0, fmt.Errorf("The number of panels must be positive and even, n = %d", n)
+	}
+	h := (b - a) / float64(n)
+	theta := omega * h
+	alpha, beta, gamma := filonCoefficients(theta)
+	//-----------------------------------------------------
+	// sums of f*w over the even and odd nodes
+	//-----------------------------------------------------
+	var seven, sodd, fa, fb float64
+	for i := 0; i <= n; i++ {
+		x := a + float64(i)*h
+		if i == n {
+			x = b
+		}
+		fx := f(x)
+		switch {
+		case i == 0:
+			fa = fx
+		case i == n:
+			fb = fx
+		}
+		if i%2 == 0 {
+			seven += fx * w.eval(omega, x)
+		} else {
+			sodd += fx * w.eval(omega, x)
+		}
+	}
+	seven -= 0.5 * (fa*w.eval(omega, a) + fb*w.eval(omega, b))
+	//-----------------------------------------------------
+	// end point correction
+	//-----------------------------------------------------
+	var ends float64
+	if w == Sin {
+		ends = -(fb*math.Cos(omega*b) - fa*math.Cos(omega*a))
+	} else {
+		ends = fb*math.Sin(omega*b) - fa*math.Sin(omega*a)
+	}
+	area = h * (alpha*ends + beta*seven + gamma*sodd)
+	return area, nil
+}
+
+// filonCoefficients returns Filon's alpha, beta and gamma for theta = omega*h
+func filonCoefficients(theta float64) (alpha, beta, gamma float64) {
+	if math.Abs(theta) < 1.0/6.0 {
+		//-----------------------------------------------------
+		// power series to avoid cancellation for small theta
+		//-----------------------------------------------------
+		t2 := theta * theta
+		t3 := t2 * theta
+		alpha = t3 * (2.0/45.0 + t2*(-2.0/315.0+t2*2.0/4725.0))
+		beta = 2.0/3.0 + t2*(2.0/15.0+t2*(-4.0/105.0+t2*2.0/567.0))
+		gamma = 4.0/3.0 + t2*(-2.0/15.0+t2*(1.0/210.0-t2/11340.0))
+		return alpha, beta, gamma
+	}
+	s, c := math.Sincos(theta)
+	t3 := theta * theta * theta
+	alpha = (theta*theta + theta*s*c - 2.0*s*s) / t3
+	beta = 2.0 * (theta*(1.0+c*c) - 2.0*s*c) / t3
+	gamma = 4.0 * (s - theta*c) / t3
+	return alpha, beta, gamma
+}
+
+// Levin integrates f(x)*w(x) over [a,b] using Levin's collocation method
+//
+// The integral is written as [p(x) exp(i*omega*x)]_a^b where p solves
+// p' + i*omega*p = f, and p is collocated by a Chebyshev series on n
+// Chebyshev-Lobatto points. f must be smooth and omega*(b-a) should be
+// at least a few radians; otherwise use Romberg or Qawo.
+//
+// n		: the number of collocation points, n >= 2
+func Levin(f func(float64) float64, a, b, omega float64, w Weight, n int) (area float64, err error) {
+	if n < 2 {
+		return 0.0, fmt.Errorf("The number of collocation points must be >= 2, n = %d", n)
+	}
+	if omega == 0.0 {
+		return 0.0, fmt.Errorf("Levin's method requires omega != 0")
+	}
+	t := lobatto(n)
+	fx := make([]float64, n)
+	for j := range t {
+		fx[j] = f(0.5*(a+b) + 0.5*(b-a)*t[j])
+	}
+	ic, is, err := levinRule(fx, t, a, b, omega)
+	if err != nil {
+		return 0.0, err
+	}
+	if w == Sin {
+		return is, nil
+	}
+	return ic, nil
+}
+
+// lobatto returns the n Chebyshev-Lobatto points cos(pi*j/(n-1)) on [-1,1]
+func lobatto(n int) []float64 {
+	t := make([]float64, n)
+	for j := range t {
+		t[j] = math.Cos(math.Pi * float64(j) / float64(n-1))
+	}
+	return t
+}
+
+// levinRule solves the Levin collocation system for the samples fx of f
+// at the points t mapped onto [a,b], and returns both
+// Int_a^b f cos(omega*x) dx and Int_a^b f sin(omega*x) dx
+func levinRule(fx, t []float64, a, b, omega float64) (ic, is float64, err error) {
+	//-----------------------------------------------------
+	// p = u + i*v = sum_k (c_k + i*d_k) T_k(t),
+	// u' - omega*v = f and v' + omega*u = 0
+	//-----------------------------------------------------
+	n := len(t)
+	scale := 2.0 / (b - a)
+	A := make([][]float64, 2*n)
+	rhs := make([]float64, 2*n)
+	tk := make([]float64, n)
+	dk := make([]float64, n)
+	for j := 0; j < n; j++ {
+		chebyshevT(t[j], tk, dk)
+		re := make([]float64, 2*n)
+		im := make([]float64, 2*n)
+		for k := 0; k < n; k++ {
+			re[k] = scale * dk[k]
+			re[n+k] = -omega * tk[k]
+			im[k] = omega * tk[k]
+			im[n+k] = scale * dk[k]
+		}
+		A[2*j], A[2*j+1] = re, im
+		rhs[2*j] = fx[j]
+	}
+	c, err := gaussSolve(A, rhs)
+	if err != nil {
+		return 0.0, 0.0, err
+	}
+	//-----------------------------------------------------
+	// evaluate [p(x) exp(i*omega*x)]_a^b
+	//-----------------------------------------------------
+	for _, e := range []struct{ t, x, sign float64 }{{1.0, b, 1.0}, {-1.0, a, -1.0}} {
+		chebyshevT(e.t, tk, dk)
+		var u, v float64
+		for k := 0; k < n; k++ {
+			u += c[k] * tk[k]
+			v += c[n+k] * tk[k]
+		}
+		s, co := math.Sincos(omega * e.x)
+		ic += e.sign * (u*co - v*s)
+		is += e.sign * (u*s + v*co)
+	}
+	return ic, is, nil
+}
+
+// chebyshevT fills tk with T_k(t) and dk with dT_k/dt for k = 0..len(tk)-1
+func chebyshevT(t float64, tk, dk []float64) {
+	// dT_k/dt = k U_{k-1}(t)
+	n := len(tk)
+	tk[0], dk[0] = 1.0, 0.0
+	if n == 1 {
+		return
+	}
+	tk[1], dk[1] = t, 1.0
+	u0, u1 := 1.0, 2.0*t // U_0, U_1
+	for k := 2; k < n; k++ {
+		tk[k] = 2.0*t*tk[k-1] - tk[k-2]
+		dk[k] = float64(k) * u1
+		u0, u1 = u1, 2.0*t*u1-u0
+	}
+}
+
+// gaussSolve solves A x = b by Gaussian elimination with partial
+// pivoting; A and b are overwritten
+func gaussSolve(A [][]float64, b []float64) (x []float64, err error) {
+	n := len(b)
+	for k := 0; k < n; k++ {
+		p := k
+		for i := k + 1; i < n; i++ {
+			if math.Abs(A[i][k]) > math.Abs(A[p][k]) {
+				p = i
+			}
+		}
+		if A[p][k] == 0.0 {
+			return nil, fmt.Errorf("Singular matrix at column %d", k)
+		}
+		A[k], A[p] = A[p], A[k]
+		b[k], b[p] = b[p], b[k]
+		for i := k + 1; i < n; i++ {
+			m := A[i][k] / A[k][k]
+			if m == 0.0 {
+				continue
+			}
+			for j := k; j < n; j++ {
+				A[i][j] -= m * A[k][j]
+			}
+			b[i] -= m * b[k]
+		}
+	}
+	x = make([]float64, n)
+	for i := n - 1; i >= 0; i-- {
+		s := b[i]
+		for j := i + 1; j < n; j++ {
+			s -= A[i][j] * x[j]
+		}
+		x[i] = s / A[i][i]
+	}
+	return x, nil
+}
+
+// Qawo integrates f(x)*w(x) over the finite range [a,b] adaptively
+//
+// Subintervals that contain less than about one oscillation are
+// integrated by the 21-point Gauss-Kronrod rule; the others by Levin's
+// method on 9 and 17 nested Chebyshev-Lobatto points, whose difference
+// is the error estimate. The subinterval with the largest error is
+// bisected until abserr <= max(epsabs, epsrel*|result|).
+//
+// limit	: the maximum number of subintervals (default 100)
+//
+// output
+//	result		: the approximation to Int_a^b f(x) w(x) dx
+//	abserr		: estimate of the absolute error
+//	neval		: the number of evaluations of f
+func Qawo(f func(float64) float64, a, b, omega float64, w Weight, epsabs, epsrel float64, limit int) (result, abserr float64, neval int, err error) {
+	if epsabs <= 0.0 && epsrel <= 0.0 {
+		return 0.0, 0.0, 0, fmt.Errorf("Invalid tolerance, epsabs = %10.3e, epsrel = %10.3e", epsabs, epsrel)
+	}
+	sign := 1.0
+	if a > b {
+		a, b, sign = b, a, -1.0
+	}
+	fw := func(x float64) float64 {
+		return f(x) * w.eval(omega, x)
+	}
+	t17 := lobatto(17)
+	t9 := lobatto(9)
+	rule := func(a, b float64) (float64, float64, int) {
+		if math.Abs(omega)*(b-a) < 2.0*math.Pi {
+			r, e := qk21(fw, a, b)
+			return r, e, 21
+		}
+		f17 := make([]float64, 17)
+		for j := range t17 {
+			f17[j] = f(0.5*(a+b) + 0.5*(b-a)*t17[j])
+		}
+		f9 := make([]float64, 9)
+		for j := range f9 {
+			f9[j] = f17[2*j]
+		}
+		c17, s17, err17 := levinRule(f17, t17, a, b, omega)
+		c9, s9, err9 := levinRule(f9, t9, a, b, omega)
+		if err17 != nil || err9 != nil {
+			r, e := qk21(fw, a, b)
+			return r, e, 17 + 21
+		}
+		if w == Sin {
+			return s17, math.Abs(s17 - s9), 17
+		}
+		return c17, math.Abs(c17 - c9), 17
+	}
+	result, abserr, neval, err = adaptive(rule, a, b, epsabs, epsrel, limit)
+	return sign * result, abserr, neval, err
+}
+
+// Qawf integrates f(x)*w(x) over the semi-infinite range [a,+inf)
+//
+// The range is cut into cycles of length (2*int(|omega|)+1)*pi/|omega|,
+// each cycle is integrated by Qawo, and the limit of the partial sums is
+// extrapolated by Wynn's epsilon algorithm. f must decay to zero.
+//
+// epsabs	: the requested absolute accuracy, epsabs > 0
+// limit	: the maximum number of cycles (default 50)
+func Qawf(f func(float64) float64, a, omega float64, w Weight, epsabs float64, limit int) (result, abserr float64, neval int, err error) {
+	if epsabs <= 0.0 {
+		return 0.0, 0.0, 0, fmt.Errorf("Invalid tolerance, epsabs = %10.3e", epsabs)
+	}
+	if omega == 0.0 {
+		if w == Sin {
+			return 0.0, 0.0, 0, nil
+		}
+		return qagi(f, a, epsabs, 0.0, 0)
+	}
+	if limit <= 0 {
+		limit = 50
+	}
+	cycle := float64(2*int(math.Abs(omega))+1) * math.Pi / math.Abs(omega)
+	const p = 0.9
+	eps := epsabs * (1.0 - p)
+	var sum, errsum float64
+	var psum []float64
+	x := a
+	for k := 0; k < limit; k++ {
+		r, e, n, err := Qawo(f, x, x+cycle, omega, w, eps, 0.0, 0)
+		neval += n
+		if err != nil {
+			return sum, errsum, neval, err
+		}
+		sum += r
+		errsum += e
+		psum = append(psum, sum)
+		x += cycle
+		eps *= p
+		//-----------------------------------------------------
+		// the terms alternate in sign; extrapolate their sum
+		//-----------------------------------------------------
+		if k >= 2 {
+			ext, exterr := wynnEpsilon(psum)
+			result, abserr = ext, exterr+errsum
+			if abserr <= epsabs {
+				return result, abserr, neval, nil
+			}
+		}
+	}
+	return result, abserr, neval, fmt.Errorf("The number of cycles exceeds, limit = %4d, abserr = %10.3e", limit, abserr)
+}
@@ -0,0 +1,240 @@
+package integrate
+
+import (
+	"math"
+	"testing"
+)
+
+// expCos returns Int_0^1 e^x cos(omega*x) dx and Int_0^1 e^x sin(omega*x) dx
+func expCos(omega float64) (ic, is float64) {
+	d := 1.0 + omega*omega
+	s, c := math.Sincos(omega)
+	ic = (math.E*(c+omega*s) - 1.0) / d
+	is = (math.E*(s-omega*c) + omega) / d
+	return ic, is
+}
+
+func TestFilon(t *testing.T) {
+	ic, is := expCos(100.0)
+	type args struct {
+		f     func(float64) float64
+		a     float64
+		b     float64
+		omega float64
+		w     Weight
+		n     int
+	}
+	tests := []struct {
+		name     string
+		args     args
+		wantArea float64
+		tol      float64
+		wantErr  bool
+	}{
+		{
+			"Case 1 : Int_0^1 e^x cos(100x) dx",
+			args{math.Exp, 0.0, 1.0, 100.0, Cos, 200},
+			ic,
+			1.0e-7,
+			false,
+		},
+		{
+			"Case 2 : Int_0^1 e^x sin(100x) dx",
+			args{math.Exp, 0.0, 1.0, 100.0, Sin, 200},
+			is,
+			1.0e-7,
+			false,
+		},
+		{
+			"Case 3 : odd number of panels",
+			args{math.Exp, 0.0, 1.0, 100.0, Sin, 5},
+			0.0,
+			0.0,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotArea, err := Filon(tt.args.f, tt.args.a, tt.args.b, tt.args.omega, tt.args.w, tt.args.n)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Filon() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if math.Abs(gotArea-tt.wantArea) > tt.tol {
+				t.Errorf("Filon() = %v, want %v", gotArea, tt.wantArea)
+			}
+		})
+	}
+}
+
+func TestFilonEvaluations(t *testing.T) {
+	// f is evaluated once at each of the n + 1 nodes
+	calls := 0
+	f := func(x float64) float64 {
+		calls++
+		return math.Exp(x)
+	}
+	area, err := Filon(f, 0.0, 1.0, 100.0, Cos, 200)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ic, _ := expCos(100.0); math.Abs(area-ic) > 1.0e-7 {
+		t.Errorf("Filon() = %v, want %v", area, ic)
+	}
+	if calls != 201 {
+		t.Errorf("Filon() evaluated f %d times, want 201", calls)
+	}
+}
+
+func TestLevin(t *testing.T) {
+	ic, is := expCos(1000.0)
+	type args struct {
+		f     func(float64) float64
+		a     float64
+		b     float64
+		omega float64
+		w     Weight
+		n     int
+	}
+	tests := []struct {
+		name     string
+		args     args
+		wantArea float64
+		wantErr  bool
+	}{
+		{
+			"Case 1 : Int_0^1 e^x cos(1000x) dx",
+			args{math.Exp, 0.0, 1.0, 1000.0, Cos, 12},
+			ic,
+			false,
+		},
+		{
+			"Case 2 : Int_0^1 e^x sin(1000x) dx",
+			args{math.Exp, 0.0, 1.0, 1000.0, Sin, 12},
+			is,
+			false,
+		},
+		{
+			"Case 3 : omega = 0",
+			args{math.Exp, 0.0, 1.0, 0.0, Sin, 12},
+			0.0,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotArea, err := Levin(tt.args.f, tt.args.a, tt.args.b, tt.args.omega, tt.args.w, tt.args.n)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Levin() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if math.Abs(gotArea-tt.wantArea) > 1.0e-12 {
+				t.Errorf("Levin() = %v, want %v", gotArea, tt.wantArea)
+			}
+		})
+	}
+}
+
+func TestQawo(t *testing.T) {
+	ic, is := expCos(1.0e4)
+	ic1, _ := expCos(0.5)
+	type args struct {
+		f      func(float64) float64
+		a      float64
+		b      float64
+		omega  float64
+		w      Weight
+		epsabs float64
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantResult float64
+	}{
+		{
+			"Case 1 : Int_0^1 e^x cos(1e4 x) dx",
+			args{math.Exp, 0.0, 1.0, 1.0e4, Cos, 1.0e-10},
+			ic,
+		},
+		{
+			"Case 2 : Int_0^1 e^x sin(1e4 x) dx",
+			args{math.Exp, 0.0, 1.0, 1.0e4, Sin, 1.0e-10},
+			is,
+		},
+		{
+			"Case 3 : Int_0^1 e^x cos(0.5 x) dx",
+			args{math.Exp, 0.0, 1.0, 0.5, Cos, 1.0e-10},
+			ic1,
+		},
+		{
+			"Case 4 : Int_0^2pi x^2 cos(20x) dx",
+			args{func(x float64) float64 { return x * x }, 0.0, 2.0 * math.Pi, 20.0, Cos, 1.0e-10},
+			math.Pi / 100.0,
+		},
+		{
+			"Case 5 : Int_1^0 e^x cos(1e4 x) dx, reversed",
+			args{math.Exp, 1.0, 0.0, 1.0e4, Cos, 1.0e-10},
+			-ic,
+		},
+		{
+			"Case 6 : Int_10^0 e^x cos(50 x) dx, reversed",
+			args{math.Exp, 10.0, 0.0, 50.0, Cos, 1.0e-8},
+			-(math.Exp(10.0)*(math.Cos(500.0)+50.0*math.Sin(500.0)) - 1.0) / 2501.0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotResult, gotAbserr, _, err := Qawo(tt.args.f, tt.args.a, tt.args.b, tt.args.omega, tt.args.w, tt.args.epsabs, 0.0, 0)
+			if err != nil {
+				t.Errorf("Qawo() error = %v", err)
+				return
+			}
+			if math.Abs(gotResult-tt.wantResult) > 10.0*tt.args.epsabs {
+				t.Errorf("Qawo() = %v, want %v, abserr = %v", gotResult, tt.wantResult, gotAbserr)
+			}
+		})
+	}
+}
+
+func TestQawf(t *testing.T) {
+	type args struct {
+		f      func(float64) float64
+		a      float64
+		omega  float64
+		w      Weight
+		epsabs float64
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantResult float64
+	}{
+		{
+			"Case 1 : Int_0^inf e^-x cos(x) dx",
+			args{func(x float64) float64 { return math.Exp(-x) }, 0.0, 1.0, Cos, 1.0e-9},
+			0.5,
+		},
+		{
+			"Case 2 : Int_1^inf sin(x)/x dx",
+			args{func(x float64) float64 { return 1.0 / x }, 1.0, 1.0, Sin, 1.0e-9},
+			0.62471325642771360429,
+		},
+		{
+			"Case 3 : Int_0^inf e^-x dx (omega = 0)",
+			args{func(x float64) float64 { return math.Exp(-x) }, 0.0, 0.0, Cos, 1.0e-9},
+			1.0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotResult, gotAbserr, _, err := Qawf(tt.args.f, tt.args.a, tt.args.omega, tt.args.w, tt.args.epsabs, 0)
+			if err != nil {
+				t.Errorf("Qawf() error = %v", err)
+				return
+			}
+			if math.Abs(gotResult-tt.wantResult) > 10.0*tt.args.epsabs {
+				t.Errorf("Qawf() = %v, want %v, abserr = %v", gotResult, tt.wantResult, gotAbserr)
+			}
+		})
+	}
+}
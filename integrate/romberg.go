@@ -3,10 +3,48 @@ package integrate
 import (
 	"fmt"
 	"math"
+
+	"github.com/shyang107/gnum"
 )
 
 // Romberg is integrate a function using Romberg method
 func Romberg(xa, xb float64, f func(float64) float64, eps float64) (area float64) {
+	return romberg(xa, xb, Sequential(f), eps, true)
+}
+
+// RombergBatch is integrate a function using Romberg method, evaluating
+// all the new midpoints of each level with one call of f
+//
+// With f = Parallel(g, workers) the levels are evaluated concurrently;
+// the nodes and the order of summation are the same as in Romberg, so
+// the result is identical to Romberg(xa, xb, g, eps). The table is not
+// printed.
+func RombergBatch(xa, xb float64, f BatchFunc, eps float64) (area float64) {
+	return romberg(xa, xb, f, eps, false)
+}
+
+// RombergCount is Romberg reporting the number of evaluations of f,
+// counted by a gnum.Evaluator; a converged level n costs 2^n + 1
+func RombergCount(xa, xb float64, f func(float64) float64, eps float64) (area float64, evals int) {
+	e := gnum.NewEvaluator(f, false, false)
+	area = Romberg(xa, xb, e.F, eps)
+	return area, e.Calls()
+}
+
+// RombergBatchCount is RombergBatch reporting the number of nodes
+// evaluated by f
+func RombergBatchCount(xa, xb float64, f BatchFunc, eps float64) (area float64, evals int) {
+	counted := func(xs []float64, out []float64) {
+		evals += len(xs)
+		f(xs, out)
+	}
+	area = RombergBatch(xa, xb, counted, eps)
+	return area, evals
+}
+
+// romberg is the Romberg scheme shared by Romberg and RombergBatch;
+// verbose prints the extrapolation table
+func romberg(xa, xb float64, f BatchFunc, eps float64, verbose bool) (area float64) {
 	//-----------------------------------------------------
 	// area = Int_xa^xb f(x) dx
 	//-----------------------------------------------------
@@ -14,10 +52,15 @@ func Romberg(xa, xb float64, f func(float64) float64, eps float64) (area float64
 	var T [20][20]float64
 	//-----------------------------------------------------
 	h := xb - xa
-	A[0] = 0.5 * (f(xa) + f(xb)) * h
+	xs := []float64{xa, xb}
+	fs := make([]float64, 2)
+	f(xs, fs)
+	A[0] = 0.5 * (fs[0] + fs[1]) * h
 	area = A[0]
 	T[0][0] = A[0]
-	fmt.Printf("%13.6e\n", T[0][0])
+	if verbose {
+		fmt.Printf("%13.6e\n", T[0][0])
+	}
 	//-----------------------------------------------------
 	// compute T^{(1)}_N
 	//-----------------------------------------------------
@@ -28,11 +71,17 @@ func Romberg(xa, xb float64, f func(float64) float64, eps float64) (area float64
 		aold = area
 		an := 0.0
 		x := xa + h*0.5
+		xs, fs = xs[:0], fs[:0]
 		for j := 1; j < jj+1; j++ {
 			// fmt.Println("\tj = ", j)
-			an += f(x)
+			xs = append(xs, x)
+			fs = append(fs, 0.0)
 			x += h
 		}
+		f(xs, fs)
+		for _, fx := range fs {
+			an += fx
+		}
 		A[n] = 0.5 * (A[n-1] + h*an)
 		T[n][0] = A[n]
 		//-----------------------------------------------------
@@ -46,11 +95,13 @@ func Romberg(xa, xb float64, f func(float64) float64, eps float64) (area float64
 			// fmt.Println("\t\ti = ", i, "n-i = ", n-i)
 		}
 		//-----------------------------------------------------
-		for m := 0; m <= n; m++ {
-			// fmt.Printf("T[%1d][%1d] = %13.6e ", n, m, T[n][m])
-			fmt.Printf("%13.6e ", T[n][m])
+		if verbose {
+			for m := 0; m <= n; m++ {
+				// fmt.Printf("T[%1d][%1d] = %13.6e ", n, m, T[n][m])
+				fmt.Printf("%13.6e ", T[n][m])
+			}
+			fmt.Println()
 		}
-		fmt.Println()
 		//-----------------------------------------------------
 		area = A[0]
 		if math.Abs(aold-area) < eps*math.Abs(area) {
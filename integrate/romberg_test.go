@@ -51,3 +51,66 @@ func Fb(x float64) float64 {
 func Fc(x float64) float64 {
 	return 1.0 / x
 }
+
+func TestRombergBatch(t *testing.T) {
+	type args struct {
+		xa  float64
+		xb  float64
+		f   func(float64) float64
+		eps float64
+	}
+	tests := []struct {
+		name string
+		args args
+	}{
+		{"Case 1 : f(x) = 1.0 / math.Sqrt(1.0+x*x)", args{0.0, 1.0, Fa, 1.0e-6}},
+		{"Case 2 : f(x) = x * math.Sin(x)", args{-1.0, 1.0, Fb, 1.0e-6}},
+		{"Case 3 : f(x) = 1/x", args{1.0, 3.0, Fc, 1.0e-6}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wantArea := Romberg(tt.args.xa, tt.args.xb, tt.args.f, tt.args.eps)
+			for _, workers := range []int{1, 3, 8} {
+				gotArea := RombergBatch(tt.args.xa, tt.args.xb, Parallel(tt.args.f, workers), tt.args.eps)
+				if gotArea != wantArea {
+					t.Errorf("RombergBatch() workers = %d, got %v, want %v", workers, gotArea, wantArea)
+				}
+			}
+		})
+	}
+}
+
+func TestRombergCount(t *testing.T) {
+	tests := []struct {
+		name      string
+		f         func(float64) float64
+		wantEvals int
+	}{
+		// exact by the first extrapolation, so converged at level 2
+		{"x^2", func(x float64) float64 { return x * x }, 5},
+		{"1/sqrt(1+x^2)", Fa, 0},
+		{"exp", math.Exp, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := 0
+			counted := func(x float64) float64 { n++; return tt.f(x) }
+			wantArea := Romberg(0, 1, counted, 1e-8)
+			area, evals := RombergCount(0, 1, tt.f, 1e-8)
+			if area != wantArea || evals != n {
+				t.Errorf("RombergCount() = %v, %d, want %v, %d", area, evals, wantArea, n)
+			}
+			if tt.wantEvals > 0 && evals != tt.wantEvals {
+				t.Errorf("RombergCount() evals = %d, want %d", evals, tt.wantEvals)
+			}
+			// 2^n + 1 nodes after n levels
+			if m := evals - 1; m&(m-1) != 0 {
+				t.Errorf("RombergCount() evals = %d, want 2^n + 1", evals)
+			}
+			area, evals = RombergBatchCount(0, 1, Parallel(tt.f, 4), 1e-8)
+			if area != wantArea || evals != n {
+				t.Errorf("RombergBatchCount() = %v, %d, want %v, %d", area, evals, wantArea, n)
+			}
+		})
+	}
+}
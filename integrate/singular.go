@@ -0,0 +1,132 @@
+package integrate
+
+import (
+	"fmt"
+	"math"
+)
+
+// Qawc computes the Cauchy principal value of Int_a^b f(x)/(x-c) dx
+//
+// The singular part is subtracted analytically,
+//	PV Int_a^b f(x)/(x-c) dx = Int_a^b (f(x)-f(c))/(x-c) dx + f(c) log((b-c)/(c-a))
+// and the remaining regular integrand is integrated adaptively on [a,c]
+// and [c,b] by the 21-point Gauss-Kronrod rule, so f is never evaluated
+// at x = c except once for f(c).
+//
+// c		: the location of the singularity, a < c < b
+// limit	: the maximum number of subintervals (default 100)
+//
+// output
+//	result		: the principal value
+//	abserr		: estimate of the absolute error
+//	neval		: the number of evaluations of f
+func Qawc(f func(float64) float64, a, b, c, epsabs, epsrel float64, limit int) (result, abserr float64, neval int, err error) {
+	if epsabs <= 0.0 && epsrel <= 0.0 {
+		return 0.0, 0.0, 0, fmt.Errorf("Invalid tolerance, epsabs = %10.3e, epsrel = %10.3e", epsabs, epsrel)
+	}
+	sign := 1.0
+	if a > b {
+		a, b, sign = b, a, -1.0
+	}
+	if c <= a || c >= b {
+		return 0.0, 0.0, 0, fmt.Errorf("The singularity c = %13.6e is not inside (%13.6e, %13.6e)", c, a, b)
+	}
+	fc := f(c)
+	neval = 1
+	g := func(x float64) float64 {
+		return (f(x) - fc) / (x - c)
+	}
+	r1, e1, n1, err1 := qag(g, a, c, 0.5*epsabs, epsrel, limit)
+	r2, e2, n2, err2 := qag(g, c, b, 0.5*epsabs, epsrel, limit)
+	neval += n1 + n2
+	result = sign * (r1 + r2 + fc*math.Log((b-c)/(c-a)))
+	abserr = e1 + e2
+	if err1 != nil {
+		return result, abserr, neval, err1
+	}
+	return result, abserr, neval, err2
+}
+
+// LogWeight selects the logarithmic factor v(x) of the weight
+// w(x) = (x-a)^alpha (b-x)^beta v(x) used by Qaws
+type LogWeight int
+
+const (
+	NoLog LogWeight = iota // v(x) = 1
+	LogA                   // v(x) = log(x-a)
+	LogB                   // v(x) = log(b-x)
+	LogAB                  // v(x) = log(x-a) log(b-x)
+)
+
+// Qaws integrates f(x)*w(x) over [a,b] for the algebraic-logarithmic
+// end point weight w(x) = (x-a)^alpha (b-x)^beta v(x)
+//
+// [a,b] is split at its midpoint and each half is mapped by
+// x - a = h exp(-t) (or b - x = h exp(-t)), which turns the singular
+// factor of that end point into a smooth, exponentially decaying
+// integrand on [0,+inf). f itself must be smooth on [a,b].
+//
+// alpha, beta	: the exponents at a and b, both > -1
+// v			: NoLog, LogA, LogB or LogAB
+// limit		: the maximum number of subintervals (default 100)
+//
+// output
+//	result		: the approximation to Int_a^b f(x) w(x) dx
+//	abserr		: estimate of the absolute error
+//	neval		: the number of evaluations of f
+func Qaws(f func(float64) float64, a, b, alpha, beta float64, v LogWeight, epsabs, epsrel float64, limit int) (result, abserr float64, neval int, err error) {
+	if epsabs <= 0.0 && epsrel <= 0.0 {
+		return 0.0, 0.0, 0, fmt.Errorf("Invalid tolerance, epsabs = %10.3e, epsrel = %10.3e", epsabs, epsrel)
+	}
+	if b <= a {
+		return 0.0, 0.0, 0, fmt.Errorf("Qaws requires a < b, a = %13.6e, b = %13.6e", a, b)
+	}
+	if alpha <= -1.0 || beta <= -1.0 {
+		return 0.0, 0.0, 0, fmt.Errorf("The exponents must be > -1, alpha = %13.6e, beta = %13.6e", alpha, beta)
+	}
+	loga := v == LogA || v == LogAB
+	logb := v == LogB || v == LogAB
+	h := 0.5 * (b - a)
+	logh := math.Log(h)
+	//-----------------------------------------------------
+	// left half: x = a + h*exp(-t)
+	//-----------------------------------------------------
+	gl := func(t float64) float64 {
+		e := math.Exp(-t)
+		x := a + h*e
+		y := math.Pow(h, alpha+1.0) * math.Exp(-(alpha+1.0)*t)
+		if loga {
+			y *= logh - t
+		}
+		y *= math.Pow(b-x, beta)
+		if logb {
+			y *= math.Log(b - x)
+		}
+		return y * f(x)
+	}
+	//-----------------------------------------------------
+	// right half: x = b - h*exp(-t)
+	//-----------------------------------------------------
+	gr := func(t float64) float64 {
+		e := math.Exp(-t)
+		x := b - h*e
+		y := math.Pow(h, beta+1.0) * math.Exp(-(beta+1.0)*t)
+		if logb {
+			y *= logh - t
+		}
+		y *= math.Pow(x-a, alpha)
+		if loga {
+			y *= math.Log(x - a)
+		}
+		return y * f(x)
+	}
+	r1, e1, n1, err1 := qagi(gl, 0.0, 0.5*epsabs, epsrel, limit)
+	r2, e2, n2, err2 := qagi(gr, 0.0, 0.5*epsabs, epsrel, limit)
+	result = r1 + r2
+	abserr = e1 + e2
+	neval = n1 + n2
+	if err1 != nil {
+		return result, abserr, neval, err1
+	}
+	return result, abserr, neval, err2
+}
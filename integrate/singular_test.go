@@ -0,0 +1,135 @@
+package integrate
+
+import (
+	"math"
+	"testing"
+)
+
+func TestQawc(t *testing.T) {
+	type args struct {
+		f      func(float64) float64
+		a      float64
+		b      float64
+		c      float64
+		epsabs float64
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantResult float64
+		wantErr    bool
+	}{
+		{
+			"Case 1 : PV Int_-1^2 1/x dx",
+			args{func(x float64) float64 { return 1.0 }, -1.0, 2.0, 0.0, 1.0e-10},
+			math.Ln2,
+			false,
+		},
+		{
+			"Case 2 : PV Int_-1^5 1/(x(5x^3+6)) dx",
+			args{func(x float64) float64 { return 1.0 / (5.0*x*x*x + 6.0) }, -1.0, 5.0, 0.0, 1.0e-10},
+			-8.994400695837000137e-02,
+			false,
+		},
+		{
+			"Case 3 : PV Int_0^3 x/(x-1) dx",
+			args{func(x float64) float64 { return x }, 0.0, 3.0, 1.0, 1.0e-10},
+			3.0 + math.Ln2,
+			false,
+		},
+		{
+			"Case 4 : c outside (a,b)",
+			args{func(x float64) float64 { return x }, 0.0, 3.0, 3.0, 1.0e-10},
+			0.0,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotResult, _, _, err := Qawc(tt.args.f, tt.args.a, tt.args.b, tt.args.c, tt.args.epsabs, 0.0, 0)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Qawc() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if math.Abs(gotResult-tt.wantResult) > 10.0*tt.args.epsabs {
+				t.Errorf("Qawc() = %v, want %v", gotResult, tt.wantResult)
+			}
+		})
+	}
+}
+
+func TestQaws(t *testing.T) {
+	one := func(x float64) float64 { return 1.0 }
+	type args struct {
+		f      func(float64) float64
+		a      float64
+		b      float64
+		alpha  float64
+		beta   float64
+		v      LogWeight
+		epsabs float64
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantResult float64
+		wantErr    bool
+	}{
+		{
+			"Case 1 : Int_0^1 x^-1/2 dx",
+			args{one, 0.0, 1.0, -0.5, 0.0, NoLog, 1.0e-10},
+			2.0,
+			false,
+		},
+		{
+			"Case 2 : Int_0^1 x^-1/2 (1-x)^-1/2 dx",
+			args{one, 0.0, 1.0, -0.5, -0.5, NoLog, 1.0e-10},
+			math.Pi,
+			false,
+		},
+		{
+			"Case 3 : Int_0^1 log(x) dx",
+			args{one, 0.0, 1.0, 0.0, 0.0, LogA, 1.0e-10},
+			-1.0,
+			false,
+		},
+		{
+			"Case 4 : Int_0^1 log(x) log(1-x) dx",
+			args{one, 0.0, 1.0, 0.0, 0.0, LogAB, 1.0e-10},
+			2.0 - math.Pi*math.Pi/6.0,
+			false,
+		},
+		{
+			"Case 5 : Int_1^3 (x-1)^0.1 (3-x)^0.5 dx",
+			args{func(x float64) float64 { return x - 1.0 }, 1.0, 3.0, -0.9, 0.5, NoLog, 1.0e-10},
+			math.Pow(2.0, 1.6) * betaFunc(1.1, 1.5),
+			false,
+		},
+		{
+			"Case 6 : alpha <= -1",
+			args{one, 0.0, 1.0, -1.0, 0.0, NoLog, 1.0e-10},
+			0.0,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotResult, _, _, err := Qaws(tt.args.f, tt.args.a, tt.args.b, tt.args.alpha, tt.args.beta, tt.args.v, tt.args.epsabs, 0.0, 0)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Qaws() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if math.Abs(gotResult-tt.wantResult) > 10.0*tt.args.epsabs {
+				t.Errorf("Qaws() = %v, want %v", gotResult, tt.wantResult)
+			}
+		})
+	}
+}
+
+// betaFunc returns the complete beta function B(p,q)
+func betaFunc(p, q float64) float64 {
+	lp, _ := math.Lgamma(p)
+	lq, _ := math.Lgamma(q)
+	lpq, _ := math.Lgamma(p + q)
+	return math.Exp(lp + lq - lpq)
+}
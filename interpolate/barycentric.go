@@ -0,0 +1,108 @@
+package interpolate
+
+import (
+	"fmt"
+	"math"
+)
+
+// Barycentric is the interpolating polynomial in the second (true)
+// barycentric form
+//	P(x) = sum_i w_i y_i / (x - x_i) / sum_i w_i / (x - x_i)
+// which evaluates in O(n) and is stable for nodes clustered like
+// Chebyshev points
+type Barycentric struct {
+	xs, ys, w []float64
+}
+
+// NewBarycentric returns the barycentric interpolant through (xs[i],
+// ys[i]); the weights cost O(n^2)
+func NewBarycentric(xs, ys []float64) (*Barycentric, error) {
+	if err := checkData(xs, ys, 1); err != nil {
+		return nil, err
+	}
+	n := len(xs)
+	w := make([]float64, n)
+	//-----------------------------------------------------
+	// w_i = 1 / prod_{j != i} (x_i - x_j), scaled by the
+	// capacity 4/(b-a) against overflow
+	//-----------------------------------------------------
+	lo, hi := xs[0], xs[0]
+	for _, x := range xs {
+		lo, hi = math.Min(lo, x), math.Max(hi, x)
+	}
+	c := 1.0
+	if hi > lo {
+		c = 4.0 / (hi - lo)
+	}
+	for i := range w {
+		w[i] = 1.0
+		for j := range xs {
+			if j != i {
+				w[i] *= c * (xs[i] - xs[j])
+			}
+		}
+		w[i] = 1.0 / w[i]
+	}
+	return &Barycentric{xs: append([]float64(nil), xs...), ys: append([]float64(nil), ys...), w: w}, nil
+}
+
+// ChebyshevNodes returns the n Chebyshev points of the second kind
+// (the extrema of T_{n-1}) on [a, b] in increasing order; n >= 2
+func ChebyshevNodes(a, b float64, n int) []float64 {
+	xs := make([]float64, n)
+	for i := range xs {
+		t := -math.Cos(math.Pi * float64(i) / float64(n-1))
+		xs[i] = 0.5*(a+b) + 0.5*(b-a)*t
+	}
+	return xs
+}
+
+// NewChebyshev returns the barycentric interpolant of f at the n
+// Chebyshev points of the second kind on [a, b], whose weights are known
+// in closed form: w_i = (-1)^i, halved at both ends
+func NewChebyshev(f func(float64) float64, a, b float64, n int) (*Barycentric, error) {
+	if n < 2 {
+		return nil, fmt.Errorf("At least %d points are required, got %d", 2, n)
+	}
+	if !(a < b) {
+		return nil, fmt.Errorf("Empty interval [%v, %v]", a, b)
+	}
+	xs := ChebyshevNodes(a, b, n)
+	ys := make([]float64, n)
+	w := make([]float64, n)
+	for i, x := range xs {
+		ys[i] = f(x)
+		w[i] = 1.0
+		if i%2 == 1 {
+			w[i] = -1.0
+		}
+	}
+	w[0] *= 0.5
+	w[n-1] *= 0.5
+	return &Barycentric{xs: xs, ys: ys, w: w}, nil
+}
+
+// Nodes returns a copy of the interpolation nodes
+func (p *Barycentric) Nodes() []float64 {
+	return append([]float64(nil), p.xs...)
+}
+
+// Weights returns a copy of the barycentric weights
+func (p *Barycentric) Weights() []float64 {
+	return append([]float64(nil), p.w...)
+}
+
+// Eval returns P(x); at a node it returns the tabulated value
+func (p *Barycentric) Eval(x float64) float64 {
+	num, den := 0.0, 0.0
+	for i, xi := range p.xs {
+		d := x - xi
+		if d == 0.0 {
+			return p.ys[i]
+		}
+		t := p.w[i] / d
+		num += t * p.ys[i]
+		den += t
+	}
+	return num / den
+}
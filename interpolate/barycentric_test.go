@@ -0,0 +1,68 @@
+package interpolate
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBarycentric(t *testing.T) {
+	xs := []float64{-2, -0.5, 1, 1.5, 4}
+	p, err := NewBarycentric(xs, sample(cubic, xs))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, x := range []float64{-2, -1, 0.3, 1.5, 3.9} {
+		if got, want := p.Eval(x), cubic(x); math.Abs(got-want) > 1e-11 {
+			t.Errorf("Barycentric.Eval(%v) = %v, want %v", x, got, want)
+		}
+	}
+	if _, err := NewBarycentric(xs, xs[:2]); err == nil {
+		t.Errorf("NewBarycentric() with mismatched lengths: want an error")
+	}
+}
+
+func TestChebyshevNodes(t *testing.T) {
+	got := ChebyshevNodes(0, 2, 5)
+	want := []float64{0, 1 - math.Sqrt2/2, 1, 1 + math.Sqrt2/2, 2}
+	for i := range want {
+		if math.Abs(got[i]-want[i]) > 1e-15 {
+			t.Errorf("ChebyshevNodes() [%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestNewChebyshev(t *testing.T) {
+	tests := []struct {
+		name    string
+		f       func(float64) float64
+		a, b    float64
+		n       int
+		tol     float64
+		wantErr bool
+	}{
+		{"runge", runge, -1, 1, 200, 1e-13, false},
+		{"exp", math.Exp, 0, 3, 30, 1e-13, false},
+		{"abs", math.Abs, -1, 1, 101, 1e-2, false},
+		{"one point", math.Exp, 0, 1, 1, 0, true},
+		{"empty", math.Exp, 1, 1, 10, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewChebyshev(tt.f, tt.a, tt.b, tt.n)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewChebyshev() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			maxErr := 0.0
+			for i := 0; i <= 1000; i++ {
+				x := tt.a + (tt.b-tt.a)*float64(i)/1000.0
+				maxErr = math.Max(maxErr, math.Abs(p.Eval(x)-tt.f(x))/math.Max(1.0, math.Abs(tt.f(x))))
+			}
+			if maxErr > tt.tol {
+				t.Errorf("NewChebyshev() max error = %v, want <= %v", maxErr, tt.tol)
+			}
+		})
+	}
+}
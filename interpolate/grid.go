@@ -0,0 +1,206 @@
+package interpolate
+
+import (
+	"fmt"
+
+	"github.com/shyang107/gnum"
+)
+
+// Bilinear interpolates z(x, y) on a rectilinear grid linearly in each
+// direction; it is continuous, and exact for a + b x + c y + d x y
+type Bilinear struct {
+	xs, ys []float64
+	z      *gnum.Matrix
+}
+
+// NewBilinear returns the bilinear interpolant of the grid values
+// z(i,j) = f(xs[i], ys[j]); xs and ys must be strictly increasing and
+// have at least 2 points. z is copied.
+func NewBilinear(xs, ys []float64, z *gnum.Matrix) (*Bilinear, error) {
+	if err := checkGrid(xs, ys, z); err != nil {
+		return nil, err
+	}
+	return &Bilinear{xs: append([]float64(nil), xs...), ys: append([]float64(nil), ys...), z: z.Clone()}, nil
+}
+
+// Eval returns the interpolated value at (x, y); outside the grid the
+// border cells are extrapolated
+func (p *Bilinear) Eval(x, y float64) float64 {
+	i, j := findCell(p.xs, x), findCell(p.ys, y)
+	t := (x - p.xs[i]) / (p.xs[i+1] - p.xs[i])
+	u := (y - p.ys[j]) / (p.ys[j+1] - p.ys[j])
+	return (1.0-t)*(1.0-u)*p.z.At(i, j) + t*(1.0-u)*p.z.At(i+1, j) +
+		(1.0-t)*u*p.z.At(i, j+1) + t*u*p.z.At(i+1, j+1)
+}
+
+// Bicubic interpolates z(x, y) on a rectilinear grid by bicubic Hermite
+// patches; the derivatives z_x, z_y and z_xy at the nodes are estimated
+// by second order finite differences, so the interpolant is continuously
+// differentiable and exact for quadratics
+type Bicubic struct {
+	xs, ys         []float64
+	z, zx, zy, zxy *gnum.Matrix
+}
+
+// NewBicubic returns the bicubic interpolant of the grid values
+// z(i,j) = f(xs[i], ys[j]); xs and ys must be strictly increasing and
+// have at least 2 points. z is copied.
+func NewBicubic(xs, ys []float64, z *gnum.Matrix) (*Bicubic, error) {
+	if err := checkGrid(xs, ys, z); err != nil {
+		return nil, err
+	}
+	nx, ny := len(xs), len(ys)
+	p := &Bicubic{xs: append([]float64(nil), xs...), ys: append([]float64(nil), ys...), z: z.Clone(),
+		zx: gnum.NewMatrix(nx, ny, nil), zy: gnum.NewMatrix(nx, ny, nil), zxy: gnum.NewMatrix(nx, ny, nil)}
+	col := make([]float64, nx)
+	for j := 0; j < ny; j++ {
+		for i := range col {
+			col[i] = z.At(i, j)
+		}
+		for i, d := range gridSlopes(xs, col) {
+			p.zx.Set(i, j, d)
+		}
+	}
+	for i := 0; i < nx; i++ {
+		copy(p.zy.Row(i), gridSlopes(ys, p.z.Row(i)))
+		copy(p.zxy.Row(i), gridSlopes(ys, p.zx.Row(i)))
+	}
+	return p, nil
+}
+
+// Eval returns the interpolated value at (x, y); outside the grid the
+// border patches are extrapolated
+func (p *Bicubic) Eval(x, y float64) float64 {
+	i, j := findCell(p.xs, x), findCell(p.ys, y)
+	hx, hy := p.xs[i+1]-p.xs[i], p.ys[j+1]-p.ys[j]
+	t := (x - p.xs[i]) / hx
+	u := (y - p.ys[j]) / hy
+	//-----------------------------------------------------
+	// value basis f[k] and derivative basis g[k] of the
+	// cubic Hermite interpolant at the ends k = 0, 1
+	//-----------------------------------------------------
+	ft := [2]float64{(2.0*t-3.0)*t*t + 1.0, (3.0 - 2.0*t) * t * t}
+	gt := [2]float64{((t-2.0)*t + 1.0) * t, (t - 1.0) * t * t}
+	fu := [2]float64{(2.0*u-3.0)*u*u + 1.0, (3.0 - 2.0*u) * u * u}
+	gu := [2]float64{((u-2.0)*u + 1.0) * u, (u - 1.0) * u * u}
+	s := 0.0
+	for a := 0; a < 2; a++ {
+		for b := 0; b < 2; b++ {
+			ii, jj := i+a, j+b
+			s += ft[a]*fu[b]*p.z.At(ii, jj) + hx*gt[a]*fu[b]*p.zx.At(ii, jj) +
+				hy*ft[a]*gu[b]*p.zy.At(ii, jj) + hx*hy*gt[a]*gu[b]*p.zxy.At(ii, jj)
+		}
+	}
+	return s
+}
+
+// gridSlopes returns the derivatives of the data (xs, f) at the knots by
+// the three point formulas, central inside and one-sided at the ends;
+// two points give the chord slope
+func gridSlopes(xs, f []float64) []float64 {
+	n := len(xs)
+	d := make([]float64, n)
+	if n == 2 {
+		d[0] = (f[1] - f[0]) / (xs[1] - xs[0])
+		d[1] = d[0]
+		return d
+	}
+	h := make([]float64, n-1)
+	s := make([]float64, n-1)
+	for i := range h {
+		h[i] = xs[i+1] - xs[i]
+		s[i] = (f[i+1] - f[i]) / h[i]
+	}
+	for i := 1; i < n-1; i++ {
+		d[i] = (s[i-1]*h[i] + s[i]*h[i-1]) / (h[i-1] + h[i])
+	}
+	d[0] = ((2.0*h[0]+h[1])*s[0] - h[0]*s[1]) / (h[0] + h[1])
+	d[n-1] = ((2.0*h[n-2]+h[n-3])*s[n-2] - h[n-2]*s[n-3]) / (h[n-2] + h[n-3])
+	return d
+}
+
+// checkGrid checks the axes of a 2-D grid against the values z
+func checkGrid(xs, ys []float64, z *gnum.Matrix) error {
+	r, c := z.Dims()
+	if r != len(xs) || c != len(ys) {
+		return fmt.Errorf("z is %d x %d, want %d x %d", r, c, len(xs), len(ys))
+	}
+	for _, axis := range [][]float64{xs, ys} {
+		if len(axis) < 2 {
+			return fmt.Errorf("At least %d points are required, got %d", 2, len(axis))
+		}
+		if err := checkSorted(axis); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Multilinear interpolates on an N-D rectilinear grid linearly in each
+// direction; it generalizes Bilinear and costs 2^N values per evaluation
+type Multilinear struct {
+	grids  [][]float64
+	values []float64
+	stride []int
+}
+
+// NewMultilinear returns the multilinear interpolant of the grid values
+//	values[i_0 stride_0 + ... + i_{N-1}] = f(grids[0][i_0], ..., grids[N-1][i_{N-1}])
+// stored with the last axis varying fastest; every axis must be strictly
+// increasing and have at least 2 points. The data are copied.
+func NewMultilinear(grids [][]float64, values []float64) (*Multilinear, error) {
+	if len(grids) == 0 {
+		return nil, fmt.Errorf("At least one axis is required")
+	}
+	p := &Multilinear{grids: make([][]float64, len(grids)), stride: make([]int, len(grids))}
+	size := 1
+	for k := len(grids) - 1; k >= 0; k-- {
+		if len(grids[k]) < 2 {
+			return nil, fmt.Errorf("Axis %d: at least %d points are required, got %d", k, 2, len(grids[k]))
+		}
+		if err := checkSorted(grids[k]); err != nil {
+			return nil, fmt.Errorf("Axis %d: %v", k, err)
+		}
+		p.grids[k] = append([]float64(nil), grids[k]...)
+		p.stride[k] = size
+		size *= len(grids[k])
+	}
+	if len(values) != size {
+		return nil, fmt.Errorf("len(values) = %d, want %d", len(values), size)
+	}
+	p.values = append([]float64(nil), values...)
+	return p, nil
+}
+
+// Eval returns the interpolated value at x; outside the grid the border
+// cells are extrapolated
+func (p *Multilinear) Eval(x []float64) float64 {
+	d := len(p.grids)
+	if len(x) != d {
+		panic(fmt.Sprintf("interpolate: len(x) = %d, want %d", len(x), d))
+	}
+	base := 0
+	t := make([]float64, d)
+	for k, g := range p.grids {
+		i := findCell(g, x[k])
+		t[k] = (x[k] - g[i]) / (g[i+1] - g[i])
+		base += i * p.stride[k]
+	}
+	//-----------------------------------------------------
+	// bit k of the corner selects the upper node in axis k
+	//-----------------------------------------------------
+	s := 0.0
+	for corner := 0; corner < 1<<uint(d); corner++ {
+		w, off := 1.0, base
+		for k := 0; k < d; k++ {
+			if corner&(1<<uint(k)) != 0 {
+				w *= t[k]
+				off += p.stride[k]
+			} else {
+				w *= 1.0 - t[k]
+			}
+		}
+		s += w * p.values[off]
+	}
+	return s
+}
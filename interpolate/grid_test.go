@@ -0,0 +1,144 @@
+package interpolate
+
+import (
+	"math"
+	"testing"
+
+	"github.com/shyang107/gnum"
+)
+
+// tabulate returns z(i,j) = f(xs[i], ys[j])
+func tabulate(f func(x, y float64) float64, xs, ys []float64) *gnum.Matrix {
+	z := gnum.NewMatrix(len(xs), len(ys), nil)
+	for i, x := range xs {
+		for j, y := range ys {
+			z.Set(i, j, f(x, y))
+		}
+	}
+	return z
+}
+
+func TestBilinear(t *testing.T) {
+	xs := []float64{0, 0.5, 2, 3}
+	ys := []float64{-1, 0, 1.5}
+	f := func(x, y float64) float64 { return 1 + 2*x - y + 0.5*x*y }
+	var p Interpolator2D
+	p, err := NewBilinear(xs, ys, tabulate(f, xs, ys))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		x, y float64
+	}{
+		{"node", 0.5, 0},
+		{"inside", 1.3, 0.7},
+		{"edge", 3, -0.2},
+		{"outside", 3.5, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, want := p.Eval(tt.x, tt.y), f(tt.x, tt.y); math.Abs(got-want) > 1e-13 {
+				t.Errorf("Bilinear.Eval() = %v, want %v", got, want)
+			}
+		})
+	}
+	if _, err := NewBilinear(xs, ys, gnum.NewMatrix(3, 3, nil)); err == nil {
+		t.Errorf("NewBilinear() with a mismatched z: want an error")
+	}
+	if _, err := NewBilinear([]float64{0, 0}, ys, gnum.NewMatrix(2, 3, nil)); err == nil {
+		t.Errorf("NewBilinear() with unsorted xs: want an error")
+	}
+}
+
+func TestBicubic(t *testing.T) {
+	xs := []float64{0, 0.3, 0.8, 1.5, 2}
+	ys := []float64{-1, -0.2, 0.5, 1}
+	quad := func(x, y float64) float64 { return 1 + x - 2*y + x*x - x*y + 3*y*y }
+	p, err := NewBicubic(xs, ys, tabulate(quad, xs, ys))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, pt := range [][2]float64{{0, -1}, {0.1, 0.9}, {1.1, 0.1}, {2, 1}, {1.7, -0.5}} {
+		if got, want := p.Eval(pt[0], pt[1]), quad(pt[0], pt[1]); math.Abs(got-want) > 1e-12 {
+			t.Errorf("Bicubic.Eval(%v) = %v, want %v", pt, got, want)
+		}
+	}
+	//-----------------------------------------------------
+	// a smooth function on a 21 x 21 grid
+	//-----------------------------------------------------
+	g := make([]float64, 21)
+	for i := range g {
+		g[i] = float64(i) / 20.0
+	}
+	f := func(x, y float64) float64 { return math.Sin(2*x) * math.Exp(-y) }
+	p, err = NewBicubic(g, g, tabulate(f, g, g))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lin, err := NewBilinear(g, g, tabulate(f, g, g))
+	if err != nil {
+		t.Fatal(err)
+	}
+	maxCubic, maxLinear := 0.0, 0.0
+	for i := 0; i <= 50; i++ {
+		for j := 0; j <= 50; j++ {
+			x, y := float64(i)/50.0, float64(j)/50.0
+			maxCubic = math.Max(maxCubic, math.Abs(p.Eval(x, y)-f(x, y)))
+			maxLinear = math.Max(maxLinear, math.Abs(lin.Eval(x, y)-f(x, y)))
+		}
+	}
+	if maxCubic > 1e-4 || maxCubic > maxLinear/10 {
+		t.Errorf("Bicubic max error = %v, bilinear %v", maxCubic, maxLinear)
+	}
+}
+
+func Test_gridSlopes(t *testing.T) {
+	xs := []float64{0, 1, 1.5, 3}
+	got := gridSlopes(xs, sample(func(x float64) float64 { return x*x - x }, xs))
+	for i, x := range xs {
+		if want := 2*x - 1; math.Abs(got[i]-want) > 1e-14 {
+			t.Errorf("gridSlopes()[%d] = %v, want %v", i, got[i], want)
+		}
+	}
+}
+
+func TestMultilinear(t *testing.T) {
+	grids := [][]float64{{0, 1, 2}, {-1, 1}, {0, 0.5, 1, 4}}
+	f := func(x []float64) float64 { return 2 - x[0] + 3*x[1] + x[0]*x[1]*x[2] - x[2] }
+	var values []float64
+	for _, x := range grids[0] {
+		for _, y := range grids[1] {
+			for _, z := range grids[2] {
+				values = append(values, f([]float64{x, y, z}))
+			}
+		}
+	}
+	var p InterpolatorND
+	p, err := NewMultilinear(grids, values)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, x := range [][]float64{{0, -1, 0}, {1.5, 0.2, 0.7}, {2, 1, 4}, {0.3, -0.9, 3.9}, {2.5, 1.5, 5}} {
+		if got, want := p.Eval(x), f(x); math.Abs(got-want) > 1e-13 {
+			t.Errorf("Multilinear.Eval(%v) = %v, want %v", x, got, want)
+		}
+	}
+	tests := []struct {
+		name   string
+		grids  [][]float64
+		values []float64
+	}{
+		{"no axis", nil, nil},
+		{"short axis", [][]float64{{0}}, []float64{1}},
+		{"values", [][]float64{{0, 1}, {0, 1}}, []float64{1, 2, 3}},
+		{"unsorted", [][]float64{{1, 0}}, []float64{1, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewMultilinear(tt.grids, tt.values); err == nil {
+				t.Errorf("NewMultilinear() want an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package interpolate
+
+import "fmt"
+
+// Hermite is a piecewise cubic Hermite interpolant, continuously
+// differentiable, given by the values and the first derivatives at the
+// knots; PCHIP, Akima and Steffen choose the derivatives from the data
+type Hermite struct {
+	piecewiseCubic
+}
+
+// NewHermite returns the cubic Hermite interpolant with the values ys and
+// the first derivatives ms at the strictly increasing knots xs
+func NewHermite(xs, ys, ms []float64) (*Hermite, error) {
+	if err := checkData(xs, ys, 2); err != nil {
+		return nil, err
+	}
+	if len(ms) != len(xs) {
+		return nil, fmt.Errorf("len(ms) = %d, want %d", len(ms), len(xs))
+	}
+	if err := checkSorted(xs); err != nil {
+		return nil, err
+	}
+	p := &Hermite{}
+	p.hermite(xs, ys, ms)
+	return p, nil
+}
+
+// hermite sets the pieces from the values ys and the slopes ms at the
+// knots
+func (p *piecewiseCubic) hermite(xs, ys, ms []float64) {
+	n := len(xs)
+	p.xs = append([]float64(nil), xs...)
+	p.a = append([]float64(nil), ys[:n-1]...)
+	p.b = append([]float64(nil), ms[:n-1]...)
+	p.c = make([]float64, n-1)
+	p.d = make([]float64, n-1)
+	for i := 0; i < n-1; i++ {
+		h := xs[i+1] - xs[i]
+		s := (ys[i+1] - ys[i]) / h
+		p.c[i] = (3.0*s - 2.0*ms[i] - ms[i+1]) / h
+		p.d[i] = (ms[i] + ms[i+1] - 2.0*s) / (h * h)
+	}
+}
@@ -0,0 +1,39 @@
+package interpolate
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewHermite(t *testing.T) {
+	// the derivative of cubic
+	dcubic := func(x float64) float64 { return (6.0*x-2.0)*x + 3.0 }
+	xs := []float64{-1, 0, 0.5, 2}
+	p, err := NewHermite(xs, sample(cubic, xs), sample(dcubic, xs))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, x := range []float64{-1, -0.3, 0.25, 1.7, 2} {
+		if got, want := p.Eval(x), cubic(x); math.Abs(got-want) > 1e-13 {
+			t.Errorf("Hermite.Eval(%v) = %v, want %v", x, got, want)
+		}
+		if got, want := p.Deriv(x), dcubic(x); math.Abs(got-want) > 1e-12 {
+			t.Errorf("Hermite.Deriv(%v) = %v, want %v", x, got, want)
+		}
+	}
+	tests := []struct {
+		name       string
+		xs, ys, ms []float64
+	}{
+		{"slopes", []float64{0, 1}, []float64{0, 1}, []float64{0}},
+		{"unsorted", []float64{1, 0}, []float64{0, 1}, []float64{0, 0}},
+		{"one point", []float64{0}, []float64{0}, []float64{0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewHermite(tt.xs, tt.ys, tt.ms); err == nil {
+				t.Errorf("NewHermite() want an error")
+			}
+		})
+	}
+}
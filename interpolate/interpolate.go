@@ -0,0 +1,48 @@
+// Package interpolate interpolates functions given at tabulated points.
+// Every interpolant of one variable satisfies Interpolator; those of two
+// and more variables satisfy Interpolator2D and InterpolatorND.
+package interpolate
+
+import "fmt"
+
+// Interpolator is an interpolating function
+type Interpolator interface {
+	Eval(x float64) float64
+}
+
+// Interpolator2D is an interpolating function of two variables
+type Interpolator2D interface {
+	Eval(x, y float64) float64
+}
+
+// InterpolatorND is an interpolating function of several variables
+type InterpolatorND interface {
+	Eval(x []float64) float64
+}
+
+// Func adapts an ordinary function to Interpolator
+type Func func(x float64) float64
+
+// Eval returns f(x)
+func (f Func) Eval(x float64) float64 {
+	return f(x)
+}
+
+// checkData checks that xs and ys have the same length, at least min,
+// and that the abscissas are distinct
+func checkData(xs, ys []float64, min int) error {
+	if len(xs) != len(ys) {
+		return fmt.Errorf("len(xs) = %d, len(ys) = %d, want equal lengths", len(xs), len(ys))
+	}
+	if len(xs) < min {
+		return fmt.Errorf("At least %d points are required, got %d", min, len(xs))
+	}
+	seen := make(map[float64]bool, len(xs))
+	for _, x := range xs {
+		if seen[x] {
+			return fmt.Errorf("Duplicate abscissa x = %v", x)
+		}
+		seen[x] = true
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package interpolate
+
+import (
+	"math"
+	"testing"
+)
+
+// cubic is the test polynomial 2x^3 - x^2 + 3x - 5
+func cubic(x float64) float64 {
+	return ((2.0*x-1.0)*x+3.0)*x - 5.0
+}
+
+// runge is Runge's function 1/(1 + 25x^2)
+func runge(x float64) float64 {
+	return 1.0 / (1.0 + 25.0*x*x)
+}
+
+// sample returns f at xs
+func sample(f func(float64) float64, xs []float64) []float64 {
+	ys := make([]float64, len(xs))
+	for i, x := range xs {
+		ys[i] = f(x)
+	}
+	return ys
+}
+
+func Test_checkData(t *testing.T) {
+	tests := []struct {
+		name    string
+		xs, ys  []float64
+		min     int
+		wantErr bool
+	}{
+		{"ok", []float64{0, 1, 2}, []float64{1, 2, 3}, 1, false},
+		{"length", []float64{0, 1}, []float64{1}, 1, true},
+		{"too few", []float64{0}, []float64{1}, 2, true},
+		{"duplicate", []float64{0, 1, 0}, []float64{1, 2, 3}, 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkData(tt.xs, tt.ys, tt.min); (err != nil) != tt.wantErr {
+				t.Errorf("checkData() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFunc(t *testing.T) {
+	var p Interpolator = Func(math.Sqrt)
+	if got := p.Eval(4.0); got != 2.0 {
+		t.Errorf("Func.Eval() = %v, want 2", got)
+	}
+}
@@ -0,0 +1,33 @@
+package interpolate
+
+// Lagrange is the interpolating polynomial of degree n-1 through n
+// points in Lagrange form
+//	P(x) = sum_i y_i prod_{j != i} (x - x_j) / (x_i - x_j)
+// Each evaluation costs O(n^2); use Barycentric for many evaluations.
+type Lagrange struct {
+	xs, ys []float64
+}
+
+// NewLagrange returns the Lagrange interpolant through (xs[i], ys[i]);
+// the data are copied
+func NewLagrange(xs, ys []float64) (*Lagrange, error) {
+	if err := checkData(xs, ys, 1); err != nil {
+		return nil, err
+	}
+	return &Lagrange{xs: append([]float64(nil), xs...), ys: append([]float64(nil), ys...)}, nil
+}
+
+// Eval returns P(x)
+func (p *Lagrange) Eval(x float64) float64 {
+	s := 0.0
+	for i, xi := range p.xs {
+		l := 1.0
+		for j, xj := range p.xs {
+			if j != i {
+				l *= (x - xj) / (xi - xj)
+			}
+		}
+		s += p.ys[i] * l
+	}
+	return s
+}
@@ -0,0 +1,32 @@
+package interpolate
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLagrange(t *testing.T) {
+	xs := []float64{-1, 0, 0.5, 2}
+	p, err := NewLagrange(xs, sample(cubic, xs))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		x    float64
+	}{
+		{"node", 0.5},
+		{"inside", 1.3},
+		{"outside", 3.0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, want := p.Eval(tt.x), cubic(tt.x); math.Abs(got-want) > 1e-12 {
+				t.Errorf("Lagrange.Eval() = %v, want %v", got, want)
+			}
+		})
+	}
+	if _, err := NewLagrange([]float64{1, 1}, []float64{0, 0}); err == nil {
+		t.Errorf("NewLagrange() with duplicate abscissas: want an error")
+	}
+}
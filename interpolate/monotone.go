@@ -0,0 +1,148 @@
+package interpolate
+
+import "math"
+
+// NewPCHIP returns the piecewise cubic Hermite interpolant of Fritsch and
+// Carlson: the derivatives are weighted harmonic means of the adjacent
+// chord slopes (Fritsch and Butland), and zero at local extrema, so the
+// interpolant is monotone wherever the data are and never overshoots
+func NewPCHIP(xs, ys []float64) (*Hermite, error) {
+	h, s, err := chords(xs, ys)
+	if err != nil {
+		return nil, err
+	}
+	n := len(xs)
+	m := make([]float64, n)
+	if n == 2 {
+		m[0], m[1] = s[0], s[0]
+		return NewHermite(xs, ys, m)
+	}
+	for i := 1; i < n-1; i++ {
+		if s[i-1]*s[i] <= 0.0 {
+			continue
+		}
+		w1 := 2.0*h[i] + h[i-1]
+		w2 := h[i] + 2.0*h[i-1]
+		m[i] = (w1 + w2) / (w1/s[i-1] + w2/s[i])
+	}
+	m[0] = pchipEnd(h[0], h[1], s[0], s[1])
+	m[n-1] = pchipEnd(h[n-2], h[n-3], s[n-2], s[n-3])
+	return NewHermite(xs, ys, m)
+}
+
+// pchipEnd returns the end derivative from the one-sided three-point
+// formula, limited to keep the shape
+func pchipEnd(h0, h1, s0, s1 float64) float64 {
+	d := ((2.0*h0+h1)*s0 - h0*s1) / (h0 + h1)
+	switch {
+	case sign(d) != sign(s0):
+		d = 0.0
+	case sign(s0) != sign(s1) && math.Abs(d) > 3.0*math.Abs(s0):
+		d = 3.0 * s0
+	}
+	return d
+}
+
+// NewAkima returns the Akima interpolant: each derivative is the average
+// of the adjacent chord slopes weighted by the changes of the slopes
+// beyond them, which follows the data closely without the wiggles of a
+// spline; two extra slopes are extrapolated at each end
+func NewAkima(xs, ys []float64) (*Hermite, error) {
+	_, s, err := chords(xs, ys)
+	if err != nil {
+		return nil, err
+	}
+	n := len(xs)
+	//-----------------------------------------------------
+	// e[k+2] = s[k] for k = -2..n
+	//-----------------------------------------------------
+	e := make([]float64, n+3)
+	copy(e[2:], s)
+	e[1] = 2.0*e[2] - e[3]
+	if n == 2 {
+		e[1] = e[2]
+	}
+	e[0] = 2.0*e[1] - e[2]
+	e[n+1] = 2.0*e[n] - e[n-1]
+	if n == 2 {
+		e[n+1] = e[n]
+	}
+	e[n+2] = 2.0*e[n+1] - e[n]
+	m := make([]float64, n)
+	for i := range m {
+		w1 := math.Abs(e[i+3] - e[i+2])
+		w2 := math.Abs(e[i+1] - e[i])
+		if w1+w2 == 0.0 {
+			m[i] = 0.5 * (e[i+1] + e[i+2])
+			continue
+		}
+		m[i] = (w1*e[i+1] + w2*e[i+2]) / (w1 + w2)
+	}
+	return NewHermite(xs, ys, m)
+}
+
+// NewSteffen returns the monotone interpolant of Steffen (1990): the
+// derivative is the slope of the parabola through three neighbouring
+// points, limited by twice the adjacent chord slopes, so there are no
+// spurious extrema and local extrema occur only at the knots
+func NewSteffen(xs, ys []float64) (*Hermite, error) {
+	h, s, err := chords(xs, ys)
+	if err != nil {
+		return nil, err
+	}
+	n := len(xs)
+	m := make([]float64, n)
+	if n == 2 {
+		m[0], m[1] = s[0], s[0]
+		return NewHermite(xs, ys, m)
+	}
+	for i := 1; i < n-1; i++ {
+		p := (s[i-1]*h[i] + s[i]*h[i-1]) / (h[i-1] + h[i])
+		m[i] = (sign(s[i-1]) + sign(s[i])) * math.Min(math.Min(math.Abs(s[i-1]), math.Abs(s[i])), 0.5*math.Abs(p))
+	}
+	m[0] = steffenEnd(h[0], h[1], s[0], s[1])
+	m[n-1] = steffenEnd(h[n-2], h[n-3], s[n-2], s[n-3])
+	return NewHermite(xs, ys, m)
+}
+
+// steffenEnd returns the end derivative from the parabola through the
+// three end points, limited like the interior ones
+func steffenEnd(h0, h1, s0, s1 float64) float64 {
+	p := s0*(1.0+h0/(h0+h1)) - s1*h0/(h0+h1)
+	switch {
+	case p*s0 <= 0.0:
+		return 0.0
+	case math.Abs(p) > 2.0*math.Abs(s0):
+		return 2.0 * s0
+	}
+	return p
+}
+
+// chords checks the data and returns the knot spacings and the chord
+// slopes
+func chords(xs, ys []float64) (h, s []float64, err error) {
+	if err := checkData(xs, ys, 2); err != nil {
+		return nil, nil, err
+	}
+	if err := checkSorted(xs); err != nil {
+		return nil, nil, err
+	}
+	h = make([]float64, len(xs)-1)
+	s = make([]float64, len(xs)-1)
+	for i := range h {
+		h[i] = xs[i+1] - xs[i]
+		s[i] = (ys[i+1] - ys[i]) / h[i]
+	}
+	return h, s, nil
+}
+
+// sign returns -1, 0 or 1 as x is negative, zero or positive
+func sign(x float64) float64 {
+	switch {
+	case x > 0.0:
+		return 1.0
+	case x < 0.0:
+		return -1.0
+	}
+	return 0.0
+}
@@ -0,0 +1,132 @@
+package interpolate
+
+import (
+	"math"
+	"testing"
+)
+
+// shapes are the constructors of the shape preserving interpolants
+var shapes = []struct {
+	name string
+	new  func(xs, ys []float64) (*Hermite, error)
+}{
+	{"PCHIP", NewPCHIP},
+	{"Akima", NewAkima},
+	{"Steffen", NewSteffen},
+}
+
+func TestShapeLinear(t *testing.T) {
+	xs := []float64{0, 0.5, 2, 2.2, 4}
+	line := func(x float64) float64 { return 1.5 - 0.75*x }
+	for _, sh := range shapes {
+		t.Run(sh.name, func(t *testing.T) {
+			p, err := sh.new(xs, sample(line, xs))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, x := range []float64{0, 0.3, 1.1, 3.9} {
+				if got, want := p.Eval(x), line(x); math.Abs(got-want) > 1e-14 {
+					t.Errorf("%s.Eval(%v) = %v, want %v", sh.name, x, got, want)
+				}
+			}
+			if got, want := p.Integrate(0, 4), 1.5*4-0.75*8; math.Abs(got-want) > 1e-13 {
+				t.Errorf("%s.Integrate() = %v, want %v", sh.name, got, want)
+			}
+			//-----------------------------------------------------
+			// two points give the straight line
+			//-----------------------------------------------------
+			q, err := sh.new(xs[:2], sample(line, xs[:2]))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := q.Eval(0.2), line(0.2); math.Abs(got-want) > 1e-15 {
+				t.Errorf("%s.Eval() with two points = %v, want %v", sh.name, got, want)
+			}
+		})
+	}
+}
+
+func TestShapeStep(t *testing.T) {
+	//-----------------------------------------------------
+	// measured data with a sharp rise: a natural spline
+	// overshoots below zero, the shape preserving
+	// interpolants stay within [0, 1] and monotone
+	//-----------------------------------------------------
+	xs := []float64{0, 1, 2, 3, 4, 5, 6}
+	ys := []float64{0, 0, 0, 0.05, 1, 1, 1}
+	sp, err := NewCubicSpline(xs, ys, Natural)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if min := sp.Eval(2.5); min >= 0.0 {
+		t.Errorf("natural spline at 2.5 = %v, expected an overshoot below 0", min)
+	}
+	for _, sh := range shapes {
+		t.Run(sh.name, func(t *testing.T) {
+			p, err := sh.new(xs, ys)
+			if err != nil {
+				t.Fatal(err)
+			}
+			prev := p.Eval(0)
+			for i := 1; i <= 600; i++ {
+				x := 6.0 * float64(i) / 600.0
+				y := p.Eval(x)
+				if y < -1e-15 || y > 1.0+1e-15 {
+					t.Fatalf("%s.Eval(%v) = %v outside [0, 1]", sh.name, x, y)
+				}
+				if sh.name != "Akima" && y < prev-1e-15 {
+					t.Fatalf("%s decreases at x = %v: %v < %v", sh.name, x, y, prev)
+				}
+				prev = y
+			}
+			for i, x := range xs {
+				if got := p.Eval(x); math.Abs(got-ys[i]) > 1e-15 {
+					t.Errorf("%s.Eval(%v) = %v, want %v", sh.name, x, got, ys[i])
+				}
+			}
+		})
+	}
+}
+
+func TestShapeSmooth(t *testing.T) {
+	n := 41
+	xs := make([]float64, n)
+	for i := range xs {
+		xs[i] = math.Pi * float64(i) / float64(n-1)
+	}
+	ys := sample(math.Sin, xs)
+	for _, sh := range shapes {
+		t.Run(sh.name, func(t *testing.T) {
+			p, err := sh.new(xs, ys)
+			if err != nil {
+				t.Fatal(err)
+			}
+			maxErr := 0.0
+			for i := 0; i <= 300; i++ {
+				x := math.Pi * float64(i) / 300.0
+				maxErr = math.Max(maxErr, math.Abs(p.Eval(x)-math.Sin(x)))
+			}
+			if maxErr > 1e-3 {
+				t.Errorf("%s max error = %v, want <= 1e-3", sh.name, maxErr)
+			}
+			if got := p.Integrate(0, math.Pi); math.Abs(got-2.0) > 1e-4 {
+				t.Errorf("%s.Integrate(0, pi) = %v, want 2", sh.name, got)
+			}
+			//-----------------------------------------------------
+			// the first derivative is continuous at the knots
+			//-----------------------------------------------------
+			x := xs[10]
+			if math.Abs(p.Deriv(x-1e-10)-p.Deriv(x+1e-10)) > 1e-8 {
+				t.Errorf("%s derivative jumps at x = %v", sh.name, x)
+			}
+		})
+	}
+}
+
+func Test_sign(t *testing.T) {
+	for _, tt := range []struct{ x, want float64 }{{-2, -1}, {0, 0}, {3, 1}} {
+		if got := sign(tt.x); got != tt.want {
+			t.Errorf("sign(%v) = %v, want %v", tt.x, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package interpolate
+
+import "math"
+
+// Neville interpolates by Neville's algorithm, which builds the
+// interpolating polynomial from the lower degree ones around x and
+// reports the last correction as an error estimate
+type Neville struct {
+	xs, ys []float64
+}
+
+// NewNeville returns the Neville interpolant through (xs[i], ys[i]); the
+// data are copied
+func NewNeville(xs, ys []float64) (*Neville, error) {
+	if err := checkData(xs, ys, 1); err != nil {
+		return nil, err
+	}
+	return &Neville{xs: append([]float64(nil), xs...), ys: append([]float64(nil), ys...)}, nil
+}
+
+// Eval returns P(x)
+func (p *Neville) Eval(x float64) float64 {
+	y, _ := p.EvalErr(x)
+	return y
+}
+
+// EvalErr returns P(x) and an error estimate
+//
+// output
+//	y	: the interpolated value
+//	dy	: the last correction added to y, an estimate of its error
+func (p *Neville) EvalErr(x float64) (y, dy float64) {
+	n := len(p.xs)
+	c := append([]float64(nil), p.ys...)
+	d := append([]float64(nil), p.ys...)
+	//-----------------------------------------------------
+	// start from the nearest tabulated point
+	//-----------------------------------------------------
+	ns := 0
+	dif := math.Abs(x - p.xs[0])
+	for i := 1; i < n; i++ {
+		if dift := math.Abs(x - p.xs[i]); dift < dif {
+			ns, dif = i, dift
+		}
+	}
+	y = p.ys[ns]
+	ns--
+	for m := 1; m < n; m++ {
+		for i := 0; i < n-m; i++ {
+			ho := p.xs[i] - x
+			hp := p.xs[i+m] - x
+			w := (c[i+1] - d[i]) / (ho - hp)
+			d[i] = hp * w
+			c[i] = ho * w
+		}
+		//-----------------------------------------------------
+		// go up or down the tableau, whichever stays centred
+		//-----------------------------------------------------
+		if 2*(ns+1) < n-m {
+			dy = c[ns+1]
+		} else {
+			dy = d[ns]
+			ns--
+		}
+		y += dy
+	}
+	return y, dy
+}
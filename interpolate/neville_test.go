@@ -0,0 +1,47 @@
+package interpolate
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNeville(t *testing.T) {
+	tests := []struct {
+		name   string
+		f      func(float64) float64
+		xs     []float64
+		x      float64
+		tol    float64
+		exactE bool // the error estimate vanishes
+	}{
+		{"cubic", cubic, []float64{-1, 0, 1, 2, 3}, 0.7, 1e-12, true},
+		{"node", math.Exp, []float64{0, 0.5, 1}, 0.5, 0, false},
+		{"exp", math.Exp, []float64{0, 0.2, 0.4, 0.6, 0.8, 1.0}, 0.47, 1e-6, false},
+		{"sin", math.Sin, []float64{0, 0.5, 1, 1.5, 2, 2.5, 3}, 1.23, 1e-4, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewNeville(tt.xs, sample(tt.f, tt.xs))
+			if err != nil {
+				t.Fatal(err)
+			}
+			y, dy := p.EvalErr(tt.x)
+			want := tt.f(tt.x)
+			if math.Abs(y-want) > tt.tol {
+				t.Errorf("Neville.EvalErr() y = %v, want %v", y, want)
+			}
+			if tt.exactE && math.Abs(dy) > 1e-12 {
+				t.Errorf("Neville.EvalErr() dy = %v, want 0", dy)
+			}
+			//-----------------------------------------------------
+			// the estimate is of the order of the true error
+			//-----------------------------------------------------
+			if !tt.exactE && math.Abs(y-want) > 10.0*math.Abs(dy)+1e-15 {
+				t.Errorf("Neville.EvalErr() error %v not covered by dy = %v", y-want, dy)
+			}
+			if p.Eval(tt.x) != y {
+				t.Errorf("Neville.Eval() = %v, want %v", p.Eval(tt.x), y)
+			}
+		})
+	}
+}
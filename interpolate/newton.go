@@ -0,0 +1,67 @@
+package interpolate
+
+import "fmt"
+
+// Newton is the interpolating polynomial in Newton form
+//	P(x) = c_0 + c_1 (x - x_0) + ... + c_{n-1} (x - x_0)...(x - x_{n-2})
+// with the divided differences c_k = f[x_0, ..., x_k]. Points can be
+// added one at a time without recomputing the others.
+type Newton struct {
+	xs   []float64
+	coef []float64 // the divided differences f[x_0..x_k]
+	diag []float64 // diag[k] = f[x_{n-1-k}, ..., x_{n-1}]
+}
+
+// NewNewton returns the Newton interpolant through (xs[i], ys[i])
+func NewNewton(xs, ys []float64) (*Newton, error) {
+	if err := checkData(xs, ys, 1); err != nil {
+		return nil, err
+	}
+	p := &Newton{}
+	for i := range xs {
+		if err := p.Add(xs[i], ys[i]); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// Add adds the point (x, y), raising the degree by one, in O(n)
+func (p *Newton) Add(x, y float64) error {
+	for _, xi := range p.xs {
+		if xi == x {
+			return fmt.Errorf("Duplicate abscissa x = %v", x)
+		}
+	}
+	//-----------------------------------------------------
+	// new diagonal: d_k = f[x_{n-k}, ..., x_n]
+	//-----------------------------------------------------
+	n := len(p.xs)
+	d := make([]float64, n+1)
+	d[0] = y
+	for k := 1; k <= n; k++ {
+		d[k] = (d[k-1] - p.diag[k-1]) / (x - p.xs[n-k])
+	}
+	p.xs = append(p.xs, x)
+	p.coef = append(p.coef, d[n])
+	p.diag = d
+	return nil
+}
+
+// Coefficients returns a copy of the divided differences c_k
+func (p *Newton) Coefficients() []float64 {
+	return append([]float64(nil), p.coef...)
+}
+
+// Eval returns P(x) by the nested Horner scheme
+func (p *Newton) Eval(x float64) float64 {
+	n := len(p.coef)
+	if n == 0 {
+		return 0.0
+	}
+	s := p.coef[n-1]
+	for k := n - 2; k >= 0; k-- {
+		s = s*(x-p.xs[k]) + p.coef[k]
+	}
+	return s
+}
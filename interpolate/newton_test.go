@@ -0,0 +1,42 @@
+package interpolate
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewton(t *testing.T) {
+	xs := []float64{1, 2, 4, 5}
+	p, err := NewNewton(xs, sample(cubic, xs))
+	if err != nil {
+		t.Fatal(err)
+	}
+	//-----------------------------------------------------
+	// the leading divided difference of a cubic is its
+	// leading coefficient
+	//-----------------------------------------------------
+	c := p.Coefficients()
+	if want := []float64{cubic(1), cubic(2) - cubic(1)}; c[0] != want[0] || c[1] != want[1] {
+		t.Errorf("Newton.Coefficients() = %v, want %v...", c, want)
+	}
+	if math.Abs(c[3]-2.0) > 1e-13 {
+		t.Errorf("Newton.Coefficients()[3] = %v, want 2", c[3])
+	}
+	for _, x := range []float64{-1, 0, 1.5, 3, 6} {
+		if got, want := p.Eval(x), cubic(x); math.Abs(got-want) > 1e-11 {
+			t.Errorf("Newton.Eval(%v) = %v, want %v", x, got, want)
+		}
+	}
+	//-----------------------------------------------------
+	// a fifth point of a cubic adds a zero coefficient
+	//-----------------------------------------------------
+	if err := p.Add(-2, cubic(-2)); err != nil {
+		t.Fatal(err)
+	}
+	if c := p.Coefficients(); math.Abs(c[4]) > 1e-13 {
+		t.Errorf("Newton.Add() coefficient = %v, want 0", c[4])
+	}
+	if err := p.Add(2, 0); err == nil {
+		t.Errorf("Newton.Add() of a duplicate abscissa: want an error")
+	}
+}
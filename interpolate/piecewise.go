@@ -0,0 +1,123 @@
+package interpolate
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// piecewiseCubic is a piecewise cubic polynomial on the sorted knots xs;
+// on [xs[i], xs[i+1]] it is
+//	a[i] + b[i] dx + c[i] dx^2 + d[i] dx^3,	dx = x - xs[i]
+// Outside the knots the end pieces are extrapolated, or, if periodic, x
+// is reduced into [xs[0], xs[n-1]).
+type piecewiseCubic struct {
+	xs         []float64
+	a, b, c, d []float64
+	periodic   bool
+}
+
+// checkSorted checks that xs is strictly increasing
+func checkSorted(xs []float64) error {
+	for i := 1; i < len(xs); i++ {
+		if !(xs[i] > xs[i-1]) {
+			return fmt.Errorf("The knots must be strictly increasing: x[%d] = %v, x[%d] = %v", i-1, xs[i-1], i, xs[i])
+		}
+	}
+	return nil
+}
+
+// locate returns the piece containing x and x reduced into the period
+func (p *piecewiseCubic) locate(x float64) (int, float64) {
+	if p.periodic {
+		x = p.xs[0] + p.wrap(x-p.xs[0])
+	}
+	return findCell(p.xs, x), x
+}
+
+// findCell returns i with xs[i] <= x < xs[i+1] by binary search, clamped
+// to the first and the last cell outside the knots; len(xs) >= 2
+func findCell(xs []float64, x float64) int {
+	n := len(xs)
+	i := sort.SearchFloat64s(xs, x)
+	if i < n && xs[i] == x {
+		i++
+	}
+	i--
+	if i < 0 {
+		i = 0
+	}
+	if i > n-2 {
+		i = n - 2
+	}
+	return i
+}
+
+// wrap reduces t into [0, period)
+func (p *piecewiseCubic) wrap(t float64) float64 {
+	period := p.xs[len(p.xs)-1] - p.xs[0]
+	t = math.Mod(t, period)
+	if t < 0.0 {
+		t += period
+	}
+	return t
+}
+
+// Eval returns the value at x
+func (p *piecewiseCubic) Eval(x float64) float64 {
+	i, x := p.locate(x)
+	dx := x - p.xs[i]
+	return p.a[i] + dx*(p.b[i]+dx*(p.c[i]+dx*p.d[i]))
+}
+
+// Deriv returns the first derivative at x
+func (p *piecewiseCubic) Deriv(x float64) float64 {
+	i, x := p.locate(x)
+	dx := x - p.xs[i]
+	return p.b[i] + dx*(2.0*p.c[i]+dx*3.0*p.d[i])
+}
+
+// Deriv2 returns the second derivative at x
+func (p *piecewiseCubic) Deriv2(x float64) float64 {
+	i, x := p.locate(x)
+	dx := x - p.xs[i]
+	return 2.0*p.c[i] + 6.0*p.d[i]*dx
+}
+
+// Knots returns a copy of the knots
+func (p *piecewiseCubic) Knots() []float64 {
+	return append([]float64(nil), p.xs...)
+}
+
+// Integrate returns the exact integral over [a, b]; a > b gives the
+// negative of the integral over [b, a]
+func (p *piecewiseCubic) Integrate(a, b float64) float64 {
+	return p.primitive(b) - p.primitive(a)
+}
+
+// primitive returns the antiderivative that vanishes at xs[0]
+func (p *piecewiseCubic) primitive(x float64) float64 {
+	n := len(p.xs)
+	s := 0.0
+	if p.periodic {
+		period := p.xs[n-1] - p.xs[0]
+		k := math.Floor((x - p.xs[0]) / period)
+		if k != 0.0 {
+			for j := 0; j < n-1; j++ {
+				s += p.piece(j, p.xs[j+1]-p.xs[j])
+			}
+			s *= k
+			x = p.xs[0] + p.wrap(x-p.xs[0])
+		}
+	}
+	i, _ := p.locate(x)
+	for j := 0; j < i; j++ {
+		s += p.piece(j, p.xs[j+1]-p.xs[j])
+	}
+	return s + p.piece(i, x-p.xs[i])
+}
+
+// piece returns the integral of piece i over [xs[i], xs[i]+dx]
+func (p *piecewiseCubic) piece(i int, dx float64) float64 {
+	return dx * (p.a[i] + dx*(p.b[i]/2.0+dx*(p.c[i]/3.0+dx*p.d[i]/4.0)))
+}
@@ -0,0 +1,85 @@
+package interpolate
+
+import (
+	"math"
+	"testing"
+)
+
+func Test_checkSorted(t *testing.T) {
+	tests := []struct {
+		name    string
+		xs      []float64
+		wantErr bool
+	}{
+		{"increasing", []float64{0, 1, 3}, false},
+		{"equal", []float64{0, 1, 1}, true},
+		{"decreasing", []float64{2, 1}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkSorted(tt.xs); (err != nil) != tt.wantErr {
+				t.Errorf("checkSorted() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_piecewiseCubic(t *testing.T) {
+	//-----------------------------------------------------
+	// x^2 on [0,1] and 1 + 2(x-1) + (x-1)^2 + (x-1)^3 on [1,3]
+	//-----------------------------------------------------
+	p := &piecewiseCubic{
+		xs: []float64{0, 1, 3},
+		a:  []float64{0, 1},
+		b:  []float64{0, 2},
+		c:  []float64{1, 1},
+		d:  []float64{0, 1},
+	}
+	tests := []struct {
+		name       string
+		x          float64
+		f, df, d2f float64
+	}{
+		{"left end", 0, 0, 0, 2},
+		{"first piece", 0.5, 0.25, 1, 2},
+		{"knot", 1, 1, 2, 2},
+		{"second piece", 2, 5, 7, 8},
+		{"right end", 3, 17, 18, 14},
+		{"extrapolate left", -1, 1, -2, 2},
+		{"extrapolate right", 4, 43, 35, 20},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.Eval(tt.x); math.Abs(got-tt.f) > 1e-14 {
+				t.Errorf("Eval(%v) = %v, want %v", tt.x, got, tt.f)
+			}
+			if got := p.Deriv(tt.x); math.Abs(got-tt.df) > 1e-14 {
+				t.Errorf("Deriv(%v) = %v, want %v", tt.x, got, tt.df)
+			}
+			if got := p.Deriv2(tt.x); math.Abs(got-tt.d2f) > 1e-14 {
+				t.Errorf("Deriv2(%v) = %v, want %v", tt.x, got, tt.d2f)
+			}
+		})
+	}
+	// int_0^1 x^2 + int_0^2 (1 + 2t + t^2 + t^3) = 1/3 + 2 + 4 + 8/3 + 4
+	if got, want := p.Integrate(0, 3), 1.0/3.0+2.0+4.0+8.0/3.0+4.0; math.Abs(got-want) > 1e-13 {
+		t.Errorf("Integrate(0, 3) = %v, want %v", got, want)
+	}
+	if got, want := p.Integrate(0.5, 0), -1.0/24.0; math.Abs(got-want) > 1e-15 {
+		t.Errorf("Integrate(0.5, 0) = %v, want %v", got, want)
+	}
+	//-----------------------------------------------------
+	// periodic: the integral over k periods
+	//-----------------------------------------------------
+	p.periodic = true
+	one := p.Integrate(0, 3)
+	if got := p.Integrate(-3, 6); math.Abs(got-3.0*one) > 1e-12 {
+		t.Errorf("periodic Integrate(-3, 6) = %v, want %v", got, 3.0*one)
+	}
+	if got, want := p.Eval(3.5), p.Eval(0.5); got != want {
+		t.Errorf("periodic Eval(3.5) = %v, want %v", got, want)
+	}
+	if got := p.Knots(); len(got) != 3 || got[2] != 3 {
+		t.Errorf("Knots() = %v", got)
+	}
+}
@@ -0,0 +1,182 @@
+package interpolate
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/shyang107/gnum"
+	"github.com/shyang107/gnum/solveeqs"
+)
+
+// Kernel is the radial basis function phi(r) of an RBF interpolant
+type Kernel int
+
+const (
+	// Gaussian is phi(r) = exp(-(eps r)^2)
+	Gaussian Kernel = iota
+	// Multiquadric is phi(r) = sqrt(1 + (eps r)^2)
+	Multiquadric
+	// ThinPlate is phi(r) = r^2 log r, augmented by a linear polynomial;
+	// it has no shape parameter
+	ThinPlate
+)
+
+// String returns the name of the kernel
+func (k Kernel) String() string {
+	switch k {
+	case Gaussian:
+		return "gaussian"
+	case Multiquadric:
+		return "multiquadric"
+	case ThinPlate:
+		return "thin-plate"
+	}
+	return fmt.Sprintf("Kernel(%d)", int(k))
+}
+
+// RBF interpolates scattered data in any dimension by radial basis
+// functions
+//	s(x) = sum_i w_i phi(||x - x_i||) + p(x)
+// where p is a linear polynomial for ThinPlate and zero otherwise. With
+// a regularisation lambda > 0 the weights solve (Phi + lambda I) w = f,
+// so s smooths noisy data instead of interpolating it.
+type RBF struct {
+	centers [][]float64
+	w       []float64
+	poly    []float64 // c_0 + c_1 x_1 + ... + c_d x_d for ThinPlate
+	kernel  Kernel
+	eps     float64
+}
+
+// NewRBF returns the RBF interpolant of values[i] at points[i]; the data
+// are copied and the dense system costs O(n^3)
+//
+// kernel	: the radial basis function
+// eps		: the shape parameter of Gaussian and Multiquadric; eps <= 0
+//		  chooses 1 / (the mean spacing of the points)
+// lambda	: the regularisation parameter, 0 interpolates exactly
+func NewRBF(points [][]float64, values []float64, kernel Kernel, eps, lambda float64) (*RBF, error) {
+	n := len(points)
+	if n == 0 || len(values) != n {
+		return nil, fmt.Errorf("len(points) = %d, len(values) = %d, want equal nonzero lengths", n, len(values))
+	}
+	d := len(points[0])
+	for i, x := range points {
+		if len(x) != d || d == 0 {
+			return nil, fmt.Errorf("Point %d has dimension %d, want %d", i, len(x), d)
+		}
+	}
+	if kernel < Gaussian || kernel > ThinPlate {
+		return nil, fmt.Errorf("Unknown kernel %v", kernel)
+	}
+	if lambda < 0.0 {
+		return nil, fmt.Errorf("Negative regularisation parameter %v", lambda)
+	}
+	p := &RBF{centers: make([][]float64, n), kernel: kernel, eps: eps}
+	for i, x := range points {
+		p.centers[i] = append([]float64(nil), x...)
+	}
+	if p.eps <= 0.0 {
+		p.eps = 1.0 / meanSpacing(points)
+	}
+	//-----------------------------------------------------
+	// the system [Phi + lambda I, P; P^T, 0] [w; c] = [f; 0]
+	// with the polynomial block P = [1, x] for ThinPlate
+	//-----------------------------------------------------
+	m := 0
+	if kernel == ThinPlate {
+		m = d + 1
+	}
+	a := gnum.NewMatrix(n+m, n+m, nil)
+	b := make([]float64, n+m)
+	for i := 0; i < n; i++ {
+		for j := 0; j < i; j++ {
+			v := p.phi(dist(points[i], points[j]))
+			a.Set(i, j, v)
+			a.Set(j, i, v)
+		}
+		a.Set(i, i, p.phi(0.0)+lambda)
+		b[i] = values[i]
+		if m > 0 {
+			a.Set(i, n, 1.0)
+			a.Set(n, i, 1.0)
+			for k := 0; k < d; k++ {
+				a.Set(i, n+1+k, points[i][k])
+				a.Set(n+1+k, i, points[i][k])
+			}
+		}
+	}
+	x, err := solveeqs.Solve(a, b)
+	if err != nil {
+		return nil, fmt.Errorf("The RBF system is singular (duplicate points or too few for the polynomial?): %v", err)
+	}
+	p.w = x[:n]
+	if m > 0 {
+		p.poly = x[n:]
+	}
+	return p, nil
+}
+
+// phi returns the kernel at the distance r
+func (p *RBF) phi(r float64) float64 {
+	switch p.kernel {
+	case Gaussian:
+		return math.Exp(-(p.eps * r) * (p.eps * r))
+	case Multiquadric:
+		return math.Sqrt(1.0 + (p.eps*r)*(p.eps*r))
+	}
+	if r == 0.0 {
+		return 0.0
+	}
+	return r * r * math.Log(r)
+}
+
+// Eval returns s(x)
+func (p *RBF) Eval(x []float64) float64 {
+	if len(x) != len(p.centers[0]) {
+		panic(fmt.Sprintf("interpolate: len(x) = %d, want %d", len(x), len(p.centers[0])))
+	}
+	s := 0.0
+	for i, c := range p.centers {
+		s += p.w[i] * p.phi(dist(x, c))
+	}
+	if p.poly != nil {
+		s += p.poly[0]
+		for k, xk := range x {
+			s += p.poly[k+1] * xk
+		}
+	}
+	return s
+}
+
+// Weights returns a copy of the weights w_i
+func (p *RBF) Weights() []float64 {
+	return append([]float64(nil), p.w...)
+}
+
+// dist returns the Euclidean distance between x and y
+func dist(x, y []float64) float64 {
+	s := 0.0
+	for k := range x {
+		s += (x[k] - y[k]) * (x[k] - y[k])
+	}
+	return math.Sqrt(s)
+}
+
+// meanSpacing returns (volume of the bounding box / n)^(1/d), the typical
+// distance between the points, or 1 if they do not span a volume
+func meanSpacing(points [][]float64) float64 {
+	d := len(points[0])
+	vol := 1.0
+	for k := 0; k < d; k++ {
+		lo, hi := points[0][k], points[0][k]
+		for _, x := range points {
+			lo, hi = math.Min(lo, x[k]), math.Max(hi, x[k])
+		}
+		vol *= hi - lo
+	}
+	if vol <= 0.0 {
+		return 1.0
+	}
+	return math.Pow(vol/float64(len(points)), 1.0/float64(d))
+}
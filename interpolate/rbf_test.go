@@ -0,0 +1,135 @@
+package interpolate
+
+import (
+	"math"
+	"testing"
+)
+
+// scattered returns n pseudo-random points in the unit square and the
+// values of f at them
+func scattered(n int, f func(x, y float64) float64) ([][]float64, []float64) {
+	pts := make([][]float64, n)
+	vals := make([]float64, n)
+	seed := 7.0
+	next := func() float64 {
+		seed = math.Mod(seed*16807.0, 2147483647.0)
+		return seed / 2147483647.0
+	}
+	for i := range pts {
+		pts[i] = []float64{next(), next()}
+		vals[i] = f(pts[i][0], pts[i][1])
+	}
+	return pts, vals
+}
+
+func TestRBF(t *testing.T) {
+	f := func(x, y float64) float64 { return math.Exp(-x) * math.Cos(2*y) }
+	pts, vals := scattered(80, f)
+	tests := []struct {
+		name   string
+		kernel Kernel
+		eps    float64
+		tol    float64
+	}{
+		{"gaussian", Gaussian, 3, 1e-2},
+		{"multiquadric", Multiquadric, 0, 1e-2},
+		{"thin-plate", ThinPlate, 0, 1e-2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewRBF(pts, vals, tt.kernel, tt.eps, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i, x := range pts {
+				if got := p.Eval(x); math.Abs(got-vals[i]) > 1e-8 {
+					t.Errorf("%v RBF at point %d = %v, want %v", tt.kernel, i, got, vals[i])
+				}
+			}
+			maxErr := 0.0
+			for i := 1; i < 10; i++ {
+				for j := 1; j < 10; j++ {
+					x, y := 0.1+0.8*float64(i)/10.0, 0.1+0.8*float64(j)/10.0
+					maxErr = math.Max(maxErr, math.Abs(p.Eval([]float64{x, y})-f(x, y)))
+				}
+			}
+			if maxErr > tt.tol {
+				t.Errorf("%v RBF max error = %v, want <= %v", tt.kernel, maxErr, tt.tol)
+			}
+		})
+	}
+}
+
+func TestRBFThinPlateLinear(t *testing.T) {
+	//-----------------------------------------------------
+	// the polynomial part reproduces linear functions
+	//-----------------------------------------------------
+	pts, vals := scattered(20, func(x, y float64) float64 { return 1 + 2*x - 3*y })
+	p, err := NewRBF(pts, vals, ThinPlate, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, w := range p.Weights() {
+		if math.Abs(w) > 1e-9 {
+			t.Errorf("ThinPlate weight = %v, want 0", w)
+		}
+	}
+	if got := p.Eval([]float64{2, 2}); math.Abs(got-(1+4-6)) > 1e-9 {
+		t.Errorf("ThinPlate Eval(2, 2) = %v, want -1", got)
+	}
+}
+
+func TestRBFRegularisation(t *testing.T) {
+	f := func(x, y float64) float64 { return x + y }
+	pts, vals := scattered(60, f)
+	for i := range vals {
+		if i%2 == 0 {
+			vals[i] += 0.05
+		} else {
+			vals[i] -= 0.05
+		}
+	}
+	exact, err := NewRBF(pts, vals, ThinPlate, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	smooth, err := NewRBF(pts, vals, ThinPlate, 0, 1.0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	errExact, errSmooth := 0.0, 0.0
+	for i, x := range pts {
+		if d := math.Abs(exact.Eval(x) - vals[i]); d > 1e-8 {
+			t.Errorf("lambda = 0 does not interpolate point %d: %v", i, d)
+		}
+		errExact += math.Abs(exact.Eval(x) - f(x[0], x[1]))
+		errSmooth += math.Abs(smooth.Eval(x) - f(x[0], x[1]))
+	}
+	if errSmooth >= errExact {
+		t.Errorf("regularised error %v, want less than the interpolating %v", errSmooth, errExact)
+	}
+}
+
+func TestRBFErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		points [][]float64
+		values []float64
+		kernel Kernel
+		lambda float64
+	}{
+		{"empty", nil, nil, Gaussian, 0},
+		{"lengths", [][]float64{{0}, {1}}, []float64{1}, Gaussian, 0},
+		{"dimension", [][]float64{{0}, {1, 2}}, []float64{1, 2}, Gaussian, 0},
+		{"kernel", [][]float64{{0}, {1}}, []float64{1, 2}, Kernel(7), 0},
+		{"lambda", [][]float64{{0}, {1}}, []float64{1, 2}, Gaussian, -1},
+		{"duplicate", [][]float64{{0, 0}, {0, 0}, {1, 0}, {0, 1}}, []float64{1, 2, 3, 4}, ThinPlate, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewRBF(tt.points, tt.values, tt.kernel, 1, tt.lambda); err == nil {
+				t.Errorf("NewRBF() want an error")
+			}
+		})
+	}
+}
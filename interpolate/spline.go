@@ -0,0 +1,206 @@
+package interpolate
+
+import (
+	"fmt"
+
+	"github.com/shyang107/gnum/solveeqs"
+)
+
+// Boundary is the end condition of a cubic spline
+type Boundary int
+
+const (
+	// Natural has zero second derivatives at both ends
+	Natural Boundary = iota
+	// Clamped has the given first derivatives at both ends
+	Clamped
+	// NotAKnot has a continuous third derivative at the second and the
+	// next to last knots
+	NotAKnot
+	// Periodic has equal first and second derivatives at both ends;
+	// ys[0] must equal ys[n-1]
+	Periodic
+)
+
+// String returns the name of the boundary condition
+func (bc Boundary) String() string {
+	switch bc {
+	case Natural:
+		return "natural"
+	case Clamped:
+		return "clamped"
+	case NotAKnot:
+		return "not-a-knot"
+	case Periodic:
+		return "periodic"
+	}
+	return fmt.Sprintf("Boundary(%d)", int(bc))
+}
+
+// CubicSpline is an interpolating cubic spline, twice continuously
+// differentiable, on strictly increasing knots. Evaluation finds the
+// piece by binary search; Integrate is exact.
+type CubicSpline struct {
+	piecewiseCubic
+	bc Boundary
+}
+
+// NewCubicSpline returns the natural, not-a-knot or periodic cubic
+// spline through (xs[i], ys[i]); use NewClampedSpline for clamped ends
+func NewCubicSpline(xs, ys []float64, bc Boundary) (*CubicSpline, error) {
+	if bc == Clamped {
+		return nil, fmt.Errorf("Use NewClampedSpline for a clamped spline")
+	}
+	return newCubicSpline(xs, ys, bc, 0.0, 0.0)
+}
+
+// NewClampedSpline returns the cubic spline through (xs[i], ys[i]) with
+// the first derivatives d0 at xs[0] and dn at xs[n-1]
+func NewClampedSpline(xs, ys []float64, d0, dn float64) (*CubicSpline, error) {
+	return newCubicSpline(xs, ys, Clamped, d0, dn)
+}
+
+func newCubicSpline(xs, ys []float64, bc Boundary, d0, dn float64) (*CubicSpline, error) {
+	min := 2
+	if bc == Periodic {
+		min = 4
+	}
+	if err := checkData(xs, ys, min); err != nil {
+		return nil, err
+	}
+	if err := checkSorted(xs); err != nil {
+		return nil, err
+	}
+	n := len(xs)
+	h := make([]float64, n-1)
+	s := make([]float64, n-1) // the slopes of the chords
+	for i := range h {
+		h[i] = xs[i+1] - xs[i]
+		s[i] = (ys[i+1] - ys[i]) / h[i]
+	}
+	//-----------------------------------------------------
+	// the second derivatives m at the knots satisfy, inside,
+	// h[i-1] m[i-1] + 2 (h[i-1]+h[i]) m[i] + h[i] m[i+1]
+	//	= 6 (s[i] - s[i-1])
+	//-----------------------------------------------------
+	var (
+		m   []float64
+		err error
+	)
+	switch bc {
+	case Natural, Clamped:
+		m, err = splineTridiag(h, s, bc, d0, dn)
+	case NotAKnot:
+		m, err = splineNotAKnot(h, s)
+	case Periodic:
+		if ys[0] != ys[n-1] {
+			return nil, fmt.Errorf("A periodic spline needs ys[0] = ys[n-1], got %v and %v", ys[0], ys[n-1])
+		}
+		m, err = splinePeriodic(h, s)
+	default:
+		return nil, fmt.Errorf("Unknown boundary condition %v", bc)
+	}
+	if err != nil {
+		return nil, err
+	}
+	sp := &CubicSpline{bc: bc}
+	sp.periodic = bc == Periodic
+	sp.xs = append([]float64(nil), xs...)
+	sp.a = append([]float64(nil), ys[:n-1]...)
+	sp.b = make([]float64, n-1)
+	sp.c = make([]float64, n-1)
+	sp.d = make([]float64, n-1)
+	for i := 0; i < n-1; i++ {
+		sp.b[i] = s[i] - h[i]*(2.0*m[i]+m[i+1])/6.0
+		sp.c[i] = m[i] / 2.0
+		sp.d[i] = (m[i+1] - m[i]) / (6.0 * h[i])
+	}
+	return sp, nil
+}
+
+// Boundary returns the end condition of the spline
+func (sp *CubicSpline) Boundary() Boundary {
+	return sp.bc
+}
+
+// splineTridiag solves for the second derivatives of the natural and
+// the clamped spline
+func splineTridiag(h, s []float64, bc Boundary, d0, dn float64) ([]float64, error) {
+	n := len(h) + 1
+	a, b, c, r := make([]float64, n), make([]float64, n), make([]float64, n), make([]float64, n)
+	for i := 1; i < n-1; i++ {
+		a[i], b[i], c[i] = h[i-1], 2.0*(h[i-1]+h[i]), h[i]
+		r[i] = 6.0 * (s[i] - s[i-1])
+	}
+	if bc == Natural {
+		b[0], b[n-1] = 1.0, 1.0
+	} else {
+		b[0], c[0], r[0] = 2.0*h[0], h[0], 6.0*(s[0]-d0)
+		a[n-1], b[n-1], r[n-1] = h[n-2], 2.0*h[n-2], 6.0*(dn-s[n-2])
+	}
+	if err := solveeqs.TridiagInPlace(a, b, c, r, nil); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// splineNotAKnot solves for the second derivatives of the not-a-knot
+// spline; the end rows reach two knots inward, so the system is banded
+// with two sub- and super-diagonals
+func splineNotAKnot(h, s []float64) ([]float64, error) {
+	n := len(h) + 1
+	if n == 2 {
+		return make([]float64, 2), nil
+	}
+	band := solveeqs.NewBand(n, 2, 2)
+	r := make([]float64, n)
+	for i := 1; i < n-1; i++ {
+		band.Set(i, i-1, h[i-1])
+		band.Set(i, i, 2.0*(h[i-1]+h[i]))
+		band.Set(i, i+1, h[i])
+		r[i] = 6.0 * (s[i] - s[i-1])
+	}
+	if n == 3 {
+		//-----------------------------------------------------
+		// one parabola: m is constant
+		//-----------------------------------------------------
+		band.Set(0, 0, 1.0)
+		band.Set(0, 1, -1.0)
+		band.Set(2, 1, -1.0)
+		band.Set(2, 2, 1.0)
+	} else {
+		//-----------------------------------------------------
+		// (m1 - m0)/h0 = (m2 - m1)/h1 at both ends
+		//-----------------------------------------------------
+		band.Set(0, 0, h[1])
+		band.Set(0, 1, -(h[0] + h[1]))
+		band.Set(0, 2, h[0])
+		band.Set(n-1, n-3, h[n-2])
+		band.Set(n-1, n-2, -(h[n-3] + h[n-2]))
+		band.Set(n-1, n-1, h[n-3])
+	}
+	var lu solveeqs.BandLU
+	if err := lu.Factorize(band); err != nil {
+		return nil, err
+	}
+	if err := lu.SolveInPlace(r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// splinePeriodic solves the cyclic system for the second derivatives of
+// the periodic spline; m[n-1] = m[0]
+func splinePeriodic(h, s []float64) ([]float64, error) {
+	k := len(h) // the number of unknowns m[0..n-2]
+	a, b, c, r := make([]float64, k), make([]float64, k), make([]float64, k), make([]float64, k)
+	for i := 0; i < k; i++ {
+		im := (i + k - 1) % k
+		a[i], b[i], c[i] = h[im], 2.0*(h[im]+h[i]), h[i]
+		r[i] = 6.0 * (s[i] - s[im])
+	}
+	if err := solveeqs.CyclicTridiagInPlace(a, b, c, r, nil); err != nil {
+		return nil, err
+	}
+	return append(r, r[0]), nil
+}
@@ -0,0 +1,151 @@
+package interpolate
+
+import (
+	"math"
+	"testing"
+
+	"github.com/shyang107/gnum/integrate"
+)
+
+func TestCubicSpline(t *testing.T) {
+	xs := []float64{-1, -0.4, 0, 0.7, 1.5, 2}
+	tests := []struct {
+		name string
+		bc   Boundary
+		f    func(float64) float64
+		tol  float64
+	}{
+		// a not-a-knot spline reproduces cubics
+		{"not-a-knot cubic", NotAKnot, cubic, 1e-12},
+		// a natural spline reproduces straight lines
+		{"natural line", Natural, func(x float64) float64 { return 3*x - 1 }, 1e-13},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sp, err := NewCubicSpline(xs, sample(tt.f, xs), tt.bc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, x := range []float64{-1, -0.7, 0.2, 0.7, 1.9, 2} {
+				if got, want := sp.Eval(x), tt.f(x); math.Abs(got-want) > tt.tol {
+					t.Errorf("CubicSpline.Eval(%v) = %v, want %v", x, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestCubicSplineBoundary(t *testing.T) {
+	n := 21
+	xs := make([]float64, n)
+	for i := range xs {
+		xs[i] = 2.0 * math.Pi * float64(i) / float64(n-1)
+	}
+	ys := sample(math.Sin, xs)
+	ys[n-1] = ys[0]
+	tests := []struct {
+		name  string
+		sp    func() (*CubicSpline, error)
+		tol   float64
+		check func(sp *CubicSpline) bool
+	}{
+		{"natural", func() (*CubicSpline, error) { return NewCubicSpline(xs, ys, Natural) }, 1e-3,
+			func(sp *CubicSpline) bool {
+				return math.Abs(sp.Deriv2(xs[0])) < 1e-14 && math.Abs(sp.Deriv2(xs[n-1])) < 1e-14
+			}},
+		{"clamped", func() (*CubicSpline, error) { return NewClampedSpline(xs, ys, 1, 1) }, 1e-4,
+			func(sp *CubicSpline) bool {
+				return math.Abs(sp.Deriv(xs[0])-1) < 1e-13 && math.Abs(sp.Deriv(xs[n-1])-1) < 1e-13
+			}},
+		{"not-a-knot", func() (*CubicSpline, error) { return NewCubicSpline(xs, ys, NotAKnot) }, 1e-4,
+			func(sp *CubicSpline) bool {
+				return math.Abs(sp.d[0]-sp.d[1]) < 1e-12 && math.Abs(sp.d[n-3]-sp.d[n-2]) < 1e-12
+			}},
+		{"periodic", func() (*CubicSpline, error) { return NewCubicSpline(xs, ys, Periodic) }, 1e-4,
+			func(sp *CubicSpline) bool {
+				return math.Abs(sp.Deriv(xs[0]+1e-300)-sp.Deriv(xs[n-1]-1e-12)) < 1e-10 &&
+					math.Abs(sp.Eval(7.0)-sp.Eval(7.0-2*math.Pi)) < 1e-14
+			}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sp, err := tt.sp()
+			if err != nil {
+				t.Fatal(err)
+			}
+			maxErr := 0.0
+			for i := 0; i <= 200; i++ {
+				x := 2.0 * math.Pi * float64(i) / 200.0
+				maxErr = math.Max(maxErr, math.Abs(sp.Eval(x)-math.Sin(x)))
+			}
+			if maxErr > tt.tol {
+				t.Errorf("CubicSpline max error = %v, want <= %v", maxErr, tt.tol)
+			}
+			if !tt.check(sp) {
+				t.Errorf("CubicSpline does not satisfy the %v end condition", sp.Boundary())
+			}
+			//-----------------------------------------------------
+			// continuity of the first two derivatives at a knot
+			//-----------------------------------------------------
+			x := xs[7]
+			if math.Abs(sp.Deriv(x-1e-9)-sp.Deriv(x+1e-9)) > 1e-7 || math.Abs(sp.Deriv2(x-1e-9)-sp.Deriv2(x+1e-9)) > 1e-6 {
+				t.Errorf("CubicSpline derivatives jump at x = %v", x)
+			}
+		})
+	}
+}
+
+func TestCubicSplineIntegrate(t *testing.T) {
+	xs := []float64{0, 0.3, 1, 1.2, 2, 3}
+	sp, err := NewCubicSpline(xs, sample(cubic, xs), NotAKnot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the antiderivative of 2x^3 - x^2 + 3x - 5
+	prim := func(x float64) float64 { return x*x*x*x/2 - x*x*x/3 + 1.5*x*x - 5*x }
+	tests := []struct {
+		name string
+		a, b float64
+	}{
+		{"all", 0, 3},
+		{"inside", 0.5, 2.5},
+		{"one piece", 1.05, 1.15},
+		{"reversed", 2.5, 0.1},
+		{"extrapolated", -1, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, want := sp.Integrate(tt.a, tt.b), prim(tt.b)-prim(tt.a); math.Abs(got-want) > 1e-11 {
+				t.Errorf("CubicSpline.Integrate() = %v, want %v", got, want)
+			}
+		})
+	}
+	//-----------------------------------------------------
+	// the spline as an integrand of integrate
+	//-----------------------------------------------------
+	if got, want := integrate.RombergBatch(0, 3, integrate.Sequential(sp.Eval), 1e-12), sp.Integrate(0, 3); math.Abs(got-want) > 1e-9 {
+		t.Errorf("Romberg(spline) = %v, want %v", got, want)
+	}
+}
+
+func TestCubicSplineErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		xs, ys []float64
+		bc     Boundary
+	}{
+		{"unsorted", []float64{0, 2, 1}, []float64{0, 1, 2}, Natural},
+		{"one point", []float64{0}, []float64{0}, Natural},
+		{"clamped", []float64{0, 1}, []float64{0, 1}, Clamped},
+		{"not periodic", []float64{0, 1, 2, 3}, []float64{0, 1, 2, 3}, Periodic},
+		{"periodic too few", []float64{0, 1, 2}, []float64{0, 1, 0}, Periodic},
+		{"unknown", []float64{0, 1}, []float64{0, 1}, Boundary(9)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewCubicSpline(tt.xs, tt.ys, tt.bc); err == nil {
+				t.Errorf("NewCubicSpline() want an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,266 @@
+package gnum
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Matrix is a dense row-major matrix of float64; element (i,j) is
+// stored at data[i*stride+j]
+type Matrix struct {
+	rows, cols, stride int
+	data               []float64
+}
+
+// NewMatrix returns an r x c matrix backed by data, which is stored row
+// by row; a nil data allocates a zero matrix. NewMatrix panics if
+// len(data) != r*c.
+func NewMatrix(r, c int, data []float64) *Matrix {
+	if r < 0 || c < 0 {
+		panic(fmt.Sprintf("gnum: negative dimension %d x %d", r, c))
+	}
+	if data == nil {
+		data = make([]float64, r*c)
+	}
+	if len(data) != r*c {
+		panic(fmt.Sprintf("gnum: len(data) = %d, want %d x %d", len(data), r, c))
+	}
+	return &Matrix{rows: r, cols: c, stride: c, data: data}
+}
+
+// NewMatrixStride returns an r x c matrix that uses data as its backing
+// storage with element (i,j) at data[i*stride+j]; it is used to view
+// storage owned by other packages without copying
+func NewMatrixStride(r, c, stride int, data []float64) *Matrix {
+	if r < 0 || c < 0 || stride < c {
+		panic(fmt.Sprintf("gnum: bad shape %d x %d, stride %d", r, c, stride))
+	}
+	if r > 0 && len(data) < (r-1)*stride+c {
+		panic(fmt.Sprintf("gnum: len(data) = %d too short for %d x %d, stride %d", len(data), r, c, stride))
+	}
+	return &Matrix{rows: r, cols: c, stride: stride, data: data}
+}
+
+// Identity returns the n x n identity matrix
+func Identity(n int) *Matrix {
+	m := NewMatrix(n, n, nil)
+	for i := 0; i < n; i++ {
+		m.data[i*m.stride+i] = 1.0
+	}
+	return m
+}
+
+// Dims returns the number of rows and columns
+func (m *Matrix) Dims() (r, c int) {
+	return m.rows, m.cols
+}
+
+// At returns element (i,j)
+func (m *Matrix) At(i, j int) float64 {
+	m.check(i, j)
+	return m.data[i*m.stride+j]
+}
+
+// Set sets element (i,j) to v
+func (m *Matrix) Set(i, j int, v float64) {
+	m.check(i, j)
+	m.data[i*m.stride+j] = v
+}
+
+// Row returns row i as a slice that shares the storage of m
+func (m *Matrix) Row(i int) []float64 {
+	m.check(i, 0)
+	if m.cols == 0 {
+		return nil
+	}
+	return m.data[i*m.stride : i*m.stride+m.cols]
+}
+
+// RawMatrix returns the shape and the backing storage of m
+func (m *Matrix) RawMatrix() (r, c, stride int, data []float64) {
+	return m.rows, m.cols, m.stride, m.data
+}
+
+// Slice returns the view of rows [i0,i1) and columns [j0,j1) of m; the
+// view shares the storage of m
+func (m *Matrix) Slice(i0, i1, j0, j1 int) *Matrix {
+	if i0 < 0 || i1 < i0 || i1 > m.rows || j0 < 0 || j1 < j0 || j1 > m.cols {
+		panic(fmt.Sprintf("gnum: slice [%d:%d, %d:%d] out of range %d x %d", i0, i1, j0, j1, m.rows, m.cols))
+	}
+	v := &Matrix{rows: i1 - i0, cols: j1 - j0, stride: m.stride}
+	if v.rows > 0 && v.cols > 0 {
+		v.data = m.data[i0*m.stride+j0 : (i1-1)*m.stride+j1]
+	}
+	return v
+}
+
+// Col copies column j of m into dst and returns dst; a nil dst is
+// allocated
+func (m *Matrix) Col(dst Vector, j int) Vector {
+	m.check(0, j)
+	if dst == nil {
+		dst = make(Vector, m.rows)
+	}
+	checkLen(len(dst), m.rows)
+	for i := range dst {
+		dst[i] = m.data[i*m.stride+j]
+	}
+	return dst
+}
+
+// Copy copies the elements of a into m, which must have the same shape
+func (m *Matrix) Copy(a *Matrix) {
+	m.checkShape(a.rows, a.cols)
+	for i := 0; i < m.rows; i++ {
+		copy(m.Row(i), a.Row(i))
+	}
+}
+
+// Clone returns a copy of m with its own storage
+func (m *Matrix) Clone() *Matrix {
+	c := NewMatrix(m.rows, m.cols, nil)
+	for i := 0; i < m.rows; i++ {
+		copy(c.data[i*c.stride:], m.data[i*m.stride:i*m.stride+m.cols])
+	}
+	return c
+}
+
+// Transpose returns the transpose of m with its own storage
+func (m *Matrix) Transpose() *Matrix {
+	t := NewMatrix(m.cols, m.rows, nil)
+	for i := 0; i < m.rows; i++ {
+		for j, v := range m.Row(i) {
+			t.data[j*t.stride+i] = v
+		}
+	}
+	return t
+}
+
+// Zero sets all the elements of m to zero
+func (m *Matrix) Zero() {
+	for i := 0; i < m.rows; i++ {
+		r := m.Row(i)
+		for j := range r {
+			r[j] = 0.0
+		}
+	}
+}
+
+// Add sets m = a + b; m may be a or b
+func (m *Matrix) Add(a, b *Matrix) {
+	m.checkShape(a.rows, a.cols)
+	m.checkShape(b.rows, b.cols)
+	for i := 0; i < m.rows; i++ {
+		ra, rb, rm := a.Row(i), b.Row(i), m.Row(i)
+		for j := range rm {
+			rm[j] = ra[j] + rb[j]
+		}
+	}
+}
+
+// Sub sets m = a - b; m may be a or b
+func (m *Matrix) Sub(a, b *Matrix) {
+	m.checkShape(a.rows, a.cols)
+	m.checkShape(b.rows, b.cols)
+	for i := 0; i < m.rows; i++ {
+		ra, rb, rm := a.Row(i), b.Row(i), m.Row(i)
+		for j := range rm {
+			rm[j] = ra[j] - rb[j]
+		}
+	}
+}
+
+// Scale sets m = s * a; m may be a
+func (m *Matrix) Scale(s float64, a *Matrix) {
+	m.checkShape(a.rows, a.cols)
+	for i := 0; i < m.rows; i++ {
+		ra, rm := a.Row(i), m.Row(i)
+		for j := range rm {
+			rm[j] = s * ra[j]
+		}
+	}
+}
+
+// Mul sets m = a * b; m must not share storage with a or b
+func (m *Matrix) Mul(a, b *Matrix) {
+	if a.cols != b.rows {
+		panic(fmt.Sprintf("gnum: dimension mismatch %d x %d * %d x %d", a.rows, a.cols, b.rows, b.cols))
+	}
+	m.checkShape(a.rows, b.cols)
+	m.Zero()
+	for i := 0; i < a.rows; i++ {
+		rm := m.Row(i)
+		for k, aik := range a.Row(i) {
+			if aik == 0.0 {
+				continue
+			}
+			rb := b.Row(k)
+			for j := range rm {
+				rm[j] += aik * rb[j]
+			}
+		}
+	}
+}
+
+// Norm returns the matrix norm of m selected by ord
+//
+// ord	= 1		: the maximum absolute column sum
+//		= 2		: the Frobenius norm
+//		= +Inf	: the maximum absolute row sum
+func (m *Matrix) Norm(ord float64) float64 {
+	nrm := 0.0
+	switch {
+	case ord == 1:
+		for j := 0; j < m.cols; j++ {
+			s := 0.0
+			for i := 0; i < m.rows; i++ {
+				s += math.Abs(m.data[i*m.stride+j])
+			}
+			nrm = math.Max(nrm, s)
+		}
+	case ord == 2:
+		for i := 0; i < m.rows; i++ {
+			for _, v := range m.Row(i) {
+				nrm = math.Hypot(nrm, v)
+			}
+		}
+	case math.IsInf(ord, 1):
+		for i := 0; i < m.rows; i++ {
+			s := 0.0
+			for _, v := range m.Row(i) {
+				s += math.Abs(v)
+			}
+			nrm = math.Max(nrm, s)
+		}
+	default:
+		panic(fmt.Sprintf("gnum: unsupported matrix norm %v", ord))
+	}
+	return nrm
+}
+
+// check panics if (i,j) is out of range
+func (m *Matrix) check(i, j int) {
+	if i < 0 || i >= m.rows || j < 0 || (j >= m.cols && !(j == 0 && m.cols == 0)) {
+		panic(fmt.Sprintf("gnum: index (%d,%d) out of range %d x %d", i, j, m.rows, m.cols))
+	}
+}
+
+// checkShape panics if m is not r x c
+func (m *Matrix) checkShape(r, c int) {
+	if m.rows != r || m.cols != c {
+		panic(fmt.Sprintf("gnum: dimension mismatch %d x %d, want %d x %d", m.rows, m.cols, r, c))
+	}
+}
+
+// String formats m row by row
+func (m *Matrix) String() string {
+	var sb strings.Builder
+	for i := 0; i < m.rows; i++ {
+		for j := 0; j < m.cols; j++ {
+			fmt.Fprintf(&sb, "%13.6e ", m.data[i*m.stride+j])
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
@@ -0,0 +1,97 @@
+package gnum
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMatrix(t *testing.T) {
+	m := NewMatrix(2, 3, []float64{1, 2, 3, 4, 5, 6})
+	if r, c := m.Dims(); r != 2 || c != 3 {
+		t.Errorf("Matrix.Dims() = %v, %v, want 2, 3", r, c)
+	}
+	if got := m.At(1, 0); got != 4 {
+		t.Errorf("Matrix.At(1, 0) = %v, want 4", got)
+	}
+	c := m.Clone()
+	c.Set(1, 0, -4)
+	if m.At(1, 0) != 4 || c.At(1, 0) != -4 {
+		t.Errorf("Matrix.Clone() shares storage")
+	}
+	m.Row(0)[2] = 9
+	if got := m.At(0, 2); got != 9 {
+		t.Errorf("Matrix.Row() does not share storage, At(0, 2) = %v", got)
+	}
+	id := Identity(3)
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			want := 0.0
+			if i == j {
+				want = 1.0
+			}
+			if id.At(i, j) != want {
+				t.Errorf("Identity(3).At(%d, %d) = %v, want %v", i, j, id.At(i, j), want)
+			}
+		}
+	}
+}
+
+func TestMatrixOps(t *testing.T) {
+	a := NewMatrix(2, 3, []float64{1, 2, 3, 4, 5, 6})
+	at := a.Transpose()
+	if r, c := at.Dims(); r != 3 || c != 2 || at.At(2, 1) != 6 {
+		t.Errorf("Matrix.Transpose() = %v", at)
+	}
+	p := NewMatrix(2, 2, nil)
+	p.Mul(a, at)
+	want := []float64{14, 32, 32, 77}
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 2; j++ {
+			if p.At(i, j) != want[2*i+j] {
+				t.Errorf("Matrix.Mul() (%d,%d) = %v, want %v", i, j, p.At(i, j), want[2*i+j])
+			}
+		}
+	}
+	s := NewMatrix(2, 3, nil)
+	s.Add(a, a)
+	s.Sub(s, a)
+	s.Scale(-1, s)
+	if s.At(1, 2) != -6 {
+		t.Errorf("Matrix.Add(), Sub(), Scale() = %v", s)
+	}
+	tests := []struct {
+		name string
+		ord  float64
+		want float64
+	}{
+		{"1-norm", 1, 9},
+		{"Frobenius norm", 2, math.Sqrt(91)},
+		{"inf-norm", math.Inf(1), 15},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := a.Norm(tt.ord); math.Abs(got-tt.want) > 1e-14 {
+				t.Errorf("Matrix.Norm(%v) = %v, want %v", tt.ord, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatrixSlice(t *testing.T) {
+	m := NewMatrix(3, 4, []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12})
+	v := m.Slice(1, 3, 1, 3)
+	if r, c := v.Dims(); r != 2 || c != 2 || v.At(0, 0) != 6 || v.At(1, 1) != 11 {
+		t.Errorf("Matrix.Slice() = %v", v)
+	}
+	v.Set(1, 0, -10)
+	if m.At(2, 1) != -10 {
+		t.Errorf("Matrix.Slice() does not share storage, At(2, 1) = %v", m.At(2, 1))
+	}
+	if got := v.Clone(); got.At(1, 0) != -10 || got.At(0, 1) != 7 {
+		t.Errorf("Matrix.Clone() of a view = %v", got)
+	}
+	col := m.Col(nil, 3)
+	if col[0] != 4 || col[1] != 8 || col[2] != 12 {
+		t.Errorf("Matrix.Col() = %v", col)
+	}
+}
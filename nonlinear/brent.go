@@ -0,0 +1,111 @@
+package nonlinear
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/shyang107/gnum"
+)
+
+// Broot finds a root of f(xx) = 0 in the bracket [xa, xb], f(xa) f(xb) <= 0,
+// by Brent's method: inverse quadratic interpolation or the secant
+// method while they make progress, bisection otherwise, so the bracket
+// always shrinks and the root is found to eps in at most about
+// log2((xb - xa) / eps)^2 evaluations.
+//
+// f	: the function
+// xa, xb	: the bracket of the root
+// eps	: the absolute tolerance of xx (default 1e-12 max(|xa|, |xb|, 1))
+// itmax	: the maximum number of iterations (default 100)
+//
+// output
+//	xx	: the root
+//	fx	: f(xx)
+func Broot(f func(float64) float64, xa, xb, eps float64, itmax int) (xx, fx float64, err error) {
+	const epsm = 2.220446049250313e-16
+	if itmax <= 0 {
+		itmax = 100
+	}
+	if eps <= 0.0 {
+		eps = 1.0e-12 * math.Max(math.Max(math.Abs(xa), math.Abs(xb)), 1.0)
+	}
+	a, b := xa, xb
+	fa, fb := f(a), f(b)
+	if fa == 0.0 {
+		return a, fa, nil
+	}
+	if fb == 0.0 {
+		return b, fb, nil
+	}
+	if (fa > 0.0) == (fb > 0.0) {
+		return b, fb, fmt.Errorf("No root between %15.6e and %15.6e", xa, xb)
+	}
+	c, fc := b, fb
+	var d, e float64
+	for iter := 0; iter < itmax; iter++ {
+		if (fb > 0.0) == (fc > 0.0) {
+			//-----------------------------------------------------
+			// keep the root between b and c
+			//-----------------------------------------------------
+			c, fc = a, fa
+			d = b - a
+			e = d
+		}
+		if math.Abs(fc) < math.Abs(fb) {
+			a, b, c = b, c, b
+			fa, fb, fc = fb, fc, fb
+		}
+		tol1 := 2.0*epsm*math.Abs(b) + 0.5*eps
+		xm := 0.5 * (c - b)
+		if math.Abs(xm) <= tol1 || fb == 0.0 {
+			return b, fb, nil
+		}
+		if math.Abs(e) >= tol1 && math.Abs(fa) > math.Abs(fb) {
+			//-----------------------------------------------------
+			// inverse quadratic interpolation, or the secant
+			// method if only two points are distinct
+			//-----------------------------------------------------
+			s := fb / fa
+			var p, q float64
+			if a == c {
+				p = 2.0 * xm * s
+				q = 1.0 - s
+			} else {
+				q = fa / fc
+				r := fb / fc
+				p = s * (2.0*xm*q*(q-r) - (b-a)*(r-1.0))
+				q = (q - 1.0) * (r - 1.0) * (s - 1.0)
+			}
+			if p > 0.0 {
+				q = -q
+			}
+			p = math.Abs(p)
+			if 2.0*p < math.Min(3.0*xm*q-math.Abs(tol1*q), math.Abs(e*q)) {
+				e = d
+				d = p / q
+			} else {
+				d = xm
+				e = d
+			}
+		} else {
+			d = xm
+			e = d
+		}
+		a, fa = b, fb
+		if math.Abs(d) > tol1 {
+			b += d
+		} else {
+			b += sign(tol1, xm)
+		}
+		fb = f(b)
+	}
+	return b, fb, fmt.Errorf("Not convergence in %4d iterations within %10.3e", itmax, eps)
+}
+
+// BrootCount is Broot reporting the number of evaluations of f, counted
+// by a gnum.Evaluator
+func BrootCount(f func(float64) float64, xa, xb, eps float64, itmax int) (xx, fx float64, evals int, err error) {
+	e := gnum.NewEvaluator(f, false, false)
+	xx, fx, err = Broot(e.F, xa, xb, eps, itmax)
+	return xx, fx, e.Calls(), err
+}
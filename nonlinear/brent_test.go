@@ -0,0 +1,49 @@
+package nonlinear
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBroot(t *testing.T) {
+	tests := []struct {
+		name    string
+		f       func(float64) float64
+		xa, xb  float64
+		want    float64
+		wantErr bool
+	}{
+		{"x^2 - 3", func(x float64) float64 { return x*x - 3 }, 0, 3, math.Sqrt(3), false},
+		{"tan x - x", func(x float64) float64 { return math.Tan(x) - x }, 4, 4.7, 4.493409457909064, false},
+		{"cubic", func(x float64) float64 { return x*x*x - 2*x - 5 }, 2, 3, 2.0945514815423265, false},
+		{"reversed bracket", math.Cos, 3, 1, math.Pi / 2, false},
+		{"root at end", math.Sin, 0, 1, 0, false},
+		{"steep", func(x float64) float64 { return math.Exp(50*x) - 2 }, -1, 1, math.Log(2) / 50, false},
+		{"no bracket", func(x float64) float64 { return x*x + 1 }, -1, 1, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := 0
+			f := func(x float64) float64 { n++; return tt.f(x) }
+			xx, fx, err := Broot(f, tt.xa, tt.xb, 1e-13, 0)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Broot() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if math.Abs(xx-tt.want) > 1e-12 {
+				t.Errorf("Broot() xx = %v, want %v", xx, tt.want)
+			}
+			if fx != tt.f(xx) {
+				t.Errorf("Broot() fx = %v, want f(xx) = %v", fx, tt.f(xx))
+			}
+			if n > 60 {
+				t.Errorf("Broot() used %d evaluations", n)
+			}
+		})
+	}
+	if _, _, err := Broot(math.Cos, 1, 3, 1e-15, 3); err == nil {
+		t.Errorf("Broot() error = nil after 3 iterations")
+	}
+}
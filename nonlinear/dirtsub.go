@@ -3,6 +3,8 @@ package nonlinear
 import (
 	"fmt"
 	"math"
+
+	"github.com/shyang107/gnum"
 )
 
 // var targetfunc func(float64) float64
@@ -387,6 +389,14 @@ func Froot(f func(float64) float64, xini, dx, eps float64, itmax int, flmt float
 	return xx, fx, nil
 }
 
+// FrootCount is Froot reporting the number of evaluations of f, counted
+// by a gnum.Evaluator
+func FrootCount(f func(float64) float64, xini, dx, eps float64, itmax int, flmt float64) (xx, fx float64, evals int, err error) {
+	e := gnum.NewEvaluator(f, false, false)
+	xx, fx, err = Froot(e.F, xini, dx, eps, itmax, flmt)
+	return xx, fx, e.Calls(), err
+}
+
 // Groot is used to call Xzero to find roots
 func Groot(g func(float64) float64, xini, eps float64, itmax int) (xn, xx float64, err error) {
 	//-----------------------------------------------------
@@ -419,6 +429,14 @@ func Groot(g func(float64) float64, xini, eps float64, itmax int) (xn, xx float6
 	return xx, xn, nil
 }
 
+// GrootCount is Groot reporting the number of evaluations of g, counted
+// by a gnum.Evaluator
+func GrootCount(g func(float64) float64, xini, eps float64, itmax int) (xn, xx float64, evals int, err error) {
+	e := gnum.NewEvaluator(g, false, false)
+	xn, xx, err = Groot(e.F, xini, eps, itmax)
+	return xn, xx, e.Calls(), err
+}
+
 // Sroot is used to call Xzero to find roots
 func Sroot(f func(float64) float64, xini, xfin, dx, eps float64, itmax int, flmt float64) (xx, fx float64, err error) {
 	//-----------------------------------------------------
@@ -478,6 +496,14 @@ func Sroot(f func(float64) float64, xini, xfin, dx, eps float64, itmax int, flmt
 	return xx, fx, nil
 }
 
+// SrootCount is Sroot reporting the number of evaluations of f, counted
+// by a gnum.Evaluator
+func SrootCount(f func(float64) float64, xini, xfin, dx, eps float64, itmax int, flmt float64) (xx, fx float64, evals int, err error) {
+	e := gnum.NewEvaluator(f, false, false)
+	xx, fx, err = Sroot(e.F, xini, xfin, dx, eps, itmax, flmt)
+	return xx, fx, e.Calls(), err
+}
+
 // sign : sign(A,B) returns the value of A with the sign of B.
 func sign(a, b float64) float64 {
 	if b < 0 {
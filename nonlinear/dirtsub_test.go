@@ -269,3 +269,57 @@ func TestFroot(t *testing.T) {
 	}
 	fmt.Println(strings.Repeat("=", 60))
 }
+
+func TestRootCount(t *testing.T) {
+	n := 0
+	counted := func(f func(float64) float64) func(float64) float64 {
+		n = 0
+		return func(x float64) float64 { n++; return f(x) }
+	}
+	cubic := func(x float64) float64 { return x*x*x - 2*x - 5 }
+	tests := []struct {
+		name  string
+		count func(f func(float64) float64) (x float64, evals int)
+		f     func(float64) float64
+		// the evaluations if known in advance, 0 otherwise
+		wantEvals int
+	}{
+		{"Froot", func(f func(float64) float64) (float64, int) {
+			x, _, evals, _ := FrootCount(f, 0.1, 0.1, 1e-5, 20, 100)
+			return x, evals
+		}, g3, 0},
+		{"Froot, all iterations", func(f func(float64) float64) (float64, int) {
+			x, _, evals, _ := FrootCount(f, 1, 0.1, 1e-300, 7, 0)
+			return x, evals
+		}, func(x float64) float64 { return x*x + 1 }, 7},
+		{"Groot", func(g func(float64) float64) (float64, int) {
+			x, _, evals, _ := GrootCount(g, 1, 1e-6, 20)
+			return x, evals
+		}, g1, 0},
+		{"Sroot", func(f func(float64) float64) (float64, int) {
+			x, _, evals, _ := SrootCount(f, 2, 3, 0.1, 1e-6, 50, 0)
+			return x, evals
+		}, cubic, 0},
+		{"Broot", func(f func(float64) float64) (float64, int) {
+			x, _, evals, _ := BrootCount(f, 2, 3, 1e-12, 0)
+			return x, evals
+		}, cubic, 0},
+		// f(xa) and f(xb) only
+		{"Broot, no bracket", func(f func(float64) float64) (float64, int) {
+			x, _, evals, _ := BrootCount(f, -1, 1, 1e-12, 0)
+			return x, evals
+		}, func(x float64) float64 { return x*x + 1 }, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, evals := tt.count(tt.f)
+			_, _ = tt.count(counted(tt.f))
+			if evals != n || evals == 0 {
+				t.Errorf("evals = %d, want %d", evals, n)
+			}
+			if tt.wantEvals > 0 && evals != tt.wantEvals {
+				t.Errorf("evals = %d, want %d", evals, tt.wantEvals)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package nonlinear
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/shyang107/gnum"
+)
+
+// Newton returns the Newton-Raphson iteration function
+//	g(x) = x - f(x)/f'(x)
+// of f with f'(x) exact by dual numbers, so that it needs not be derived
+// by hand. A root of f is a fixed point of g; pass g to the direct
+// substitution (dirtsub) or to Groot. f is written against gnum.Number,
+//	f := func(x gnum.Dual) gnum.Dual { return x.Tan().Sub(x) }
+func Newton(f func(gnum.Dual) gnum.Dual) func(float64) float64 {
+	return func(x float64) float64 {
+		fx, dfx := gnum.DualDeriv(f, x)
+		return x - fx/dfx
+	}
+}
+
+// Nroot finds a root of f(xx) = 0 from the initial guess xini by the
+// Newton-Raphson method, f'(x) exact by dual numbers. Where f'(x) = 0 or
+// the Newton step is not finite, the next x is taken from Xzero instead:
+// by the secant or false-position method, or x + dx.
+//
+// f	: the function, written against gnum.Number
+// xini	: the initial guess
+// dx	: the step of Xzero when nothing better is known
+// eps	: stop when |x_(n+1) - x_n| <= eps max(|xini|, 1) (default 1e-6)
+// itmax	: the maximum number of iterations (default 12)
+// flmt	: the upper limit of |f(xx)| (default 1e30)
+//
+// output
+//	xx	: the root
+//	fx	: f(xx)
+func Nroot(f func(gnum.Dual) gnum.Dual, xini, dx, eps float64, itmax int, flmt float64) (xx, fx float64, err error) {
+	nstp := itmax
+	if nstp == 0 {
+		nstp = 12
+	}
+	errx := eps
+	if errx == 0.0 {
+		errx = 1.0e-6
+	}
+	errx *= math.Max(math.Abs(xini), 1.0)
+	flmy := flmt
+	if flmy == 0.0 {
+		flmy = 1.0e30
+	}
+	//-----------------------------------------------------
+	var buf xzeroParameters
+	xn := xini
+	for istep := 1; istep < nstp+1; istep++ {
+		var dfx float64
+		xx = xn
+		fx, dfx = gnum.DualDeriv(f, xx)
+		if fx == 0.0 {
+			return xx, fx, nil
+		}
+		if math.Abs(fx) > flmy {
+			return xx, fx, fmt.Errorf("f(xx) > limited function value of f(xx)")
+		}
+		//-----------------------------------------------------
+		// the Newton step, or Xzero if it fails
+		//-----------------------------------------------------
+		xz := Xzero(xx, fx, dx, istep, &buf)
+		xn = xx - fx/dfx
+		if dfx == 0.0 || math.IsNaN(xn) || math.IsInf(xn, 0) {
+			xn = xz
+		}
+		if math.Abs(xn-xx) <= errx {
+			return xn, f(gnum.Variable(xn)).Val, nil
+		}
+	}
+	//-----------------------------------------------------
+	return xx, fx, fmt.Errorf("Not convergence in %4d iterations within %10.3e", nstp, errx)
+}
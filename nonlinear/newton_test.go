@@ -0,0 +1,70 @@
+package nonlinear
+
+import (
+	"math"
+	"testing"
+
+	"github.com/shyang107/gnum"
+)
+
+// tanx is tan x - x
+func tanx(x gnum.Dual) gnum.Dual {
+	return x.Tan().Sub(x)
+}
+
+// sqr3 is x^2 - 3
+func sqr3(x gnum.Dual) gnum.Dual {
+	return x.Mul(x).Sub(x.Const(3))
+}
+
+func TestNewton(t *testing.T) {
+	// the Newton map of tan x - x, which g2 derives by hand
+	g := Newton(tanx)
+	x := 4.6
+	want := x - (math.Tan(x)-x)/(math.Tan(x)*math.Tan(x))
+	if got := g(x); math.Abs(got-want) > 1e-12 {
+		t.Errorf("Newton(tanx)(%v) = %v, want %v", x, got, want)
+	}
+	// direct substitution with the Newton map
+	xres, _, err := dirtsub(2, 1e-12, 20, Newton(sqr3))
+	if err != nil || math.Abs(xres-math.Sqrt(3)) > 1e-12 {
+		t.Errorf("dirtsub(Newton(sqr3)) = %v, %v, want %v", xres, err, math.Sqrt(3))
+	}
+}
+
+func TestNroot(t *testing.T) {
+	tests := []struct {
+		name    string
+		f       func(gnum.Dual) gnum.Dual
+		xini    float64
+		itmax   int
+		want    float64
+		wantErr bool
+	}{
+		{"sqrt 3", sqr3, 2, 0, math.Sqrt(3), false},
+		{"tan x = x", tanx, 4.6, 20, 4.493409457909064, false},
+		{"cubic", func(x gnum.Dual) gnum.Dual { return x.Pow(3).Sub(x.Scale(2)).Sub(x.Const(5)) }, 2, 0, 2.0945514815423265, false},
+		{"zero derivative", sqr3, 0, 20, math.Sqrt(3), false},
+		{"max iterations", tanx, 4.6, 2, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			xx, fx, err := Nroot(tt.f, tt.xini, 0.5, 1e-12, tt.itmax, 0)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Nroot() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if math.Abs(xx-tt.want) > 1e-10 {
+				t.Errorf("Nroot() xx = %v, want %v", xx, tt.want)
+			}
+			if math.Abs(fx) > 1e-9 {
+				t.Errorf("Nroot() fx = %v, want 0", fx)
+			}
+		})
+	}
+	if _, _, err := Nroot(tanx, 1.5707, 0.1, 1e-12, 20, 100); err == nil {
+		t.Errorf("Nroot() error = nil above flmt")
+	}
+}
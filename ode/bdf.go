@@ -0,0 +1,365 @@
+package ode
+
+import (
+	"fmt"
+	"math"
+)
+
+// bdfNewtonMax is the maximum number of Newton iterations of a step
+const bdfNewtonMax = 4
+
+// BDF solves the stiff system y' = f(t, y), y(t0) = y0 from t0 to t1 by
+// the backward differentiation formulas of orders 1 to s.MaxOrder with
+// variable step and order, in the quasi-constant step form of Shampine
+// and Reichelt (as ode15s of MATLAB and BDF of SciPy without the NDF
+// modification). The solution is kept as the backward differences D of
+// the interpolating polynomial; a change of step rescales D.
+//
+// Every step solves the implicit formula
+//	y_new = y_pred + d,	(I - c J) dy = c f(t_new, y) - psi - d
+// by a simplified Newton iteration with the Jacobian J. J is evaluated
+// at the start and kept for as many steps as the iteration converges;
+// only when it fails, J is evaluated anew, and if it still fails the
+// step is halved. The iteration matrix is factorized again only when
+// the step or the order changes. The local error is controlled as in
+// RKF45, and after order + 1 steps of equal size the order is changed
+// by one if the step can grow more with it.
+//
+// s	: the settings, nil means the defaults
+func BDF(f Func, t0, t1 float64, y0 []float64, s *Settings) (*Solution, error) {
+	const (
+		minFactor = 0.2
+		maxFactor = 10.0
+	)
+	st, err := s.defaults(t0, t1, y0)
+	if err != nil {
+		return nil, err
+	}
+	n := len(y0)
+	maxOrder := st.MaxOrder
+	dir := 1.0
+	if t1 < t0 {
+		dir = -1.0
+	}
+	sol := &Solution{T: []float64{t0}, Y: [][]float64{append([]float64(nil), y0...)}}
+	stats := &sol.Stats
+	ev := newEvents(st.Events, t0, y0, stats)
+	//-----------------------------------------------------
+	// gamma_k = sum_(j<=k) 1/j; the error constant of
+	// order k is 1/(k+1)
+	//-----------------------------------------------------
+	gamma := make([]float64, maxOrder+2)
+	errConst := make([]float64, maxOrder+2)
+	for k := 1; k < len(gamma); k++ {
+		gamma[k] = gamma[k-1] + 1.0/float64(k)
+	}
+	for k := range errConst {
+		errConst[k] = 1.0 / float64(k+1)
+	}
+	t := t0
+	f0 := make([]float64, n)
+	f(t, y0, f0)
+	stats.FuncEvals++
+	hAbs := st.Step
+	if hAbs == 0.0 {
+		hAbs = initialStep(f, t, dir, y0, f0, 2, &st, stats)
+	}
+	hAbs = math.Min(hAbs, st.MaxStep)
+	d := make([][]float64, maxOrder+3)
+	for i := range d {
+		d[i] = make([]float64, n)
+	}
+	copy(d[0], y0)
+	for i := range f0 {
+		d[1][i] = f0[i] * hAbs * dir
+	}
+	nw := newNewton(f, n, &st, stats)
+	nw.jacobian(t, y0, f0)
+	order, nEqual := 1, 0
+	newtonTol := math.Max(10.0*eps/st.RelTol, math.Min(0.03, math.Sqrt(st.RelTol)))
+	ypred := make([]float64, n)
+	psi := make([]float64, n)
+	scale := make([]float64, n)
+	e := make([]float64, n)
+	for t != t1 {
+		if stats.Accepted+stats.Rejected >= st.MaxSteps {
+			return sol, fmt.Errorf("Not convergence in %d steps at t = %13.6e", st.MaxSteps, t)
+		}
+		if hAbs > st.MaxStep {
+			changeD(d, order, st.MaxStep/hAbs)
+			hAbs = st.MaxStep
+			nEqual = 0
+			nw.lu = nil
+		}
+		current := false
+		var (
+			tnew, h, errNorm, safety float64
+			ynew, dd                 []float64
+			last                     bool
+		)
+		for {
+			if hAbs <= 16.0*eps*math.Abs(t) {
+				return sol, fmt.Errorf("Step size too small at t = %13.6e", t)
+			}
+			tnew = t + hAbs*dir
+			last = (tnew-t1)*dir >= 0.0
+			if last {
+				tnew = t1
+				changeD(d, order, math.Abs(tnew-t)/hAbs)
+				nEqual = 0
+				nw.lu = nil
+			}
+			h = tnew - t
+			hAbs = math.Abs(h)
+			//-----------------------------------------------------
+			// the predictor and psi of the implicit formula
+			//-----------------------------------------------------
+			for i := 0; i < n; i++ {
+				v, p := 0.0, 0.0
+				for k := 0; k <= order; k++ {
+					v += d[k][i]
+				}
+				for k := 1; k <= order; k++ {
+					p += gamma[k] * d[k][i]
+				}
+				ypred[i] = v
+				psi[i] = p / gamma[order]
+				scale[i] = st.AbsTol + st.RelTol*math.Abs(v)
+			}
+			c := h / gamma[order]
+			//-----------------------------------------------------
+			// the Newton iteration, with a new Jacobian if it
+			// fails with an old one
+			//-----------------------------------------------------
+			converged, iters := false, 0
+			for {
+				if nw.lu == nil {
+					if err := nw.factorize(c); err != nil {
+						break
+					}
+				}
+				converged, iters, ynew, dd = bdfNewton(nw, tnew, ypred, c, psi, scale, newtonTol)
+				if converged || current {
+					break
+				}
+				nw.jacobian(tnew, ypred, nil)
+				current = true
+			}
+			if !converged {
+				stats.NewtonFails++
+				stats.Rejected++
+				hAbs *= 0.5
+				changeD(d, order, 0.5)
+				nEqual = 0
+				nw.lu = nil
+				continue
+			}
+			//-----------------------------------------------------
+			// the error control
+			//-----------------------------------------------------
+			safety = 0.9 * float64(2*bdfNewtonMax+1) / float64(2*bdfNewtonMax+iters)
+			for i := 0; i < n; i++ {
+				scale[i] = st.AbsTol + st.RelTol*math.Abs(ynew[i])
+				e[i] = errConst[order] * dd[i]
+			}
+			errNorm = wnorm(e, scale)
+			if errNorm > 1.0 {
+				stats.Rejected++
+				factor := math.Max(minFactor, safety*math.Pow(errNorm, -1.0/float64(order+1)))
+				hAbs *= factor
+				changeD(d, order, factor)
+				nEqual = 0
+				nw.lu = nil
+				continue
+			}
+			break
+		}
+		//-----------------------------------------------------
+		// accept the step and update the differences
+		//-----------------------------------------------------
+		nEqual++
+		for i := 0; i < n; i++ {
+			d[order+2][i] = dd[i] - d[order+1][i]
+			d[order+1][i] = dd[i]
+		}
+		for k := order; k >= 0; k-- {
+			for i := 0; i < n; i++ {
+				d[k][i] += d[k+1][i]
+			}
+		}
+		seg := newBDFDense(tnew, h, order, d)
+		if ev.accept(sol, seg, t, tnew, ynew, last) {
+			break
+		}
+		t = tnew
+		if nEqual < order+1 {
+			continue
+		}
+		//-----------------------------------------------------
+		// change the order by -1, 0 or +1 to allow the
+		// largest step
+		//-----------------------------------------------------
+		errM, errP := math.Inf(1), math.Inf(1)
+		if order > 1 {
+			for i := 0; i < n; i++ {
+				e[i] = errConst[order-1] * d[order][i]
+			}
+			errM = wnorm(e, scale)
+		}
+		if order < maxOrder {
+			for i := 0; i < n; i++ {
+				e[i] = errConst[order+1] * d[order+2][i]
+			}
+			errP = wnorm(e, scale)
+		}
+		best, delta := 0.0, 0
+		for k, en := range []float64{errM, errNorm, errP} {
+			if fk := math.Pow(en, -1.0/float64(order+k)); fk > best {
+				best, delta = fk, k-1
+			}
+		}
+		order += delta
+		factor := math.Min(maxFactor, safety*best)
+		hAbs *= factor
+		changeD(d, order, factor)
+		nEqual = 0
+		nw.lu = nil
+	}
+	return sol, nil
+}
+
+// bdfNewton solves the implicit formula of a BDF step by the simplified
+// Newton iteration; it stops when the estimated error of the iterate,
+// from the rate of convergence, is below tol, and fails when the rate is
+// too slow to get there in bdfNewtonMax iterations.
+//
+// output
+//	converged	: the iteration converged
+//	iters	: the number of iterations
+//	y	: the solution y_pred + d
+//	d	: the correction
+func bdfNewton(nw *newton, t float64, ypred []float64, c float64, psi, scale []float64, tol float64) (converged bool, iters int, y, d []float64) {
+	n := len(ypred)
+	y = append([]float64(nil), ypred...)
+	d = make([]float64, n)
+	fy := make([]float64, n)
+	rhs := make([]float64, n)
+	normOld := -1.0
+	for k := 0; k < bdfNewtonMax; k++ {
+		iters = k + 1
+		nw.f(t, y, fy)
+		nw.stats.FuncEvals++
+		nw.stats.NewtonIters++
+		for i := 0; i < n; i++ {
+			if math.IsNaN(fy[i]) || math.IsInf(fy[i], 0) {
+				return false, iters, y, d
+			}
+			rhs[i] = c*fy[i] - psi[i] - d[i]
+		}
+		dy, err := nw.solve(rhs)
+		if err != nil {
+			return false, iters, y, d
+		}
+		norm := wnorm(dy, scale)
+		rate := -1.0
+		if normOld >= 0.0 {
+			rate = norm / normOld
+			if rate >= 1.0 || math.Pow(rate, float64(bdfNewtonMax-k))/(1.0-rate)*norm > tol {
+				return false, iters, y, d
+			}
+		}
+		for i := 0; i < n; i++ {
+			y[i] += dy[i]
+			d[i] += dy[i]
+		}
+		if norm == 0.0 || (rate >= 0.0 && rate/(1.0-rate)*norm < tol) {
+			return true, iters, y, d
+		}
+		normOld = norm
+	}
+	return false, iters, y, d
+}
+
+// changeD rescales the differences D_0, ..., D_order of the
+// interpolating polynomial from the step h to factor h
+func changeD(d [][]float64, order int, factor float64) {
+	r := bdfR(order, factor)
+	u := bdfR(order, 1.0)
+	n := len(d[0])
+	ru := make([][]float64, order+1)
+	for i := range ru {
+		ru[i] = make([]float64, order+1)
+		for j := range ru[i] {
+			for k := 0; k <= order; k++ {
+				ru[i][j] += r[i][k] * u[k][j]
+			}
+		}
+	}
+	nd := make([][]float64, order+1)
+	for j := range nd {
+		nd[j] = make([]float64, n)
+		for k := 0; k <= order; k++ {
+			for i := 0; i < n; i++ {
+				nd[j][i] += ru[k][j] * d[k][i]
+			}
+		}
+	}
+	for j := range nd {
+		copy(d[j], nd[j])
+	}
+}
+
+// bdfR returns the matrix R of the change of the differences by factor,
+// R_ij = prod_(k=1..i) (k - 1 - factor j) / k for i >= 1 and R_0j = 1
+func bdfR(order int, factor float64) [][]float64 {
+	r := make([][]float64, order+1)
+	for i := range r {
+		r[i] = make([]float64, order+1)
+	}
+	for j := 0; j <= order; j++ {
+		r[0][j] = 1.0
+		for i := 1; i <= order; i++ {
+			m := 0.0
+			if j >= 1 {
+				m = (float64(i) - 1.0 - factor*float64(j)) / float64(i)
+			}
+			r[i][j] = r[i-1][j] * m
+		}
+	}
+	return r
+}
+
+// bdfDense is the continuous extension of a BDF step ending at t with the
+// step h: the interpolating polynomial of the order through the last
+// order + 1 points from the differences D
+type bdfDense struct {
+	t, h  float64
+	order int
+	d     [][]float64
+}
+
+// newBDFDense returns the continuous extension of the step with a copy
+// of D_0, ..., D_order
+func newBDFDense(t, h float64, order int, d [][]float64) *bdfDense {
+	b := &bdfDense{t: t, h: h, order: order, d: make([][]float64, order+1)}
+	for k := range b.d {
+		b.d[k] = append([]float64(nil), d[k]...)
+	}
+	return b
+}
+
+// eval returns y = D_0 + sum_k D_(k+1) prod_(j<=k) (x - (t - j h)) / ((j + 1) h)
+func (b *bdfDense) eval(x float64, y []float64) []float64 {
+	if y == nil {
+		y = make([]float64, len(b.d[0]))
+	}
+	copy(y, b.d[0])
+	p := 1.0
+	for k := 0; k < b.order; k++ {
+		p *= (x - (b.t - float64(k)*b.h)) / (float64(k+1) * b.h)
+		for i := range y {
+			y[i] += p * b.d[k+1][i]
+		}
+	}
+	return y
+}
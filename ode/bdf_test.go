@@ -0,0 +1,162 @@
+package ode
+
+import (
+	"math"
+	"testing"
+
+	"github.com/shyang107/gnum"
+)
+
+// robertson is the stiff chemical kinetics of Robertson
+func robertson(t float64, y, dy []float64) {
+	dy[0] = -0.04*y[0] + 1e4*y[1]*y[2]
+	dy[2] = 3e7 * y[1] * y[1]
+	dy[1] = -dy[0] - dy[2]
+}
+
+// robertsonJac is the Jacobian of robertson
+func robertsonJac(t float64, y []float64, jac *gnum.Matrix) {
+	jac.Set(0, 0, -0.04)
+	jac.Set(0, 1, 1e4*y[2])
+	jac.Set(0, 2, 1e4*y[1])
+	jac.Set(2, 0, 0)
+	jac.Set(2, 1, 6e7*y[1])
+	jac.Set(2, 2, 0)
+	for j := 0; j < 3; j++ {
+		jac.Set(1, j, -jac.At(0, j)-jac.At(2, j))
+	}
+}
+
+// stiffLinear is y1' = -1000 (y1 - cos t), y2' = -y2 + y1 with the slow
+// solution near y1 = cos t
+func stiffLinear(t float64, y, dy []float64) {
+	dy[0] = -1000 * (y[0] - math.Cos(t))
+	dy[1] = -y[1] + y[0]
+}
+
+func TestBDFRobertson(t *testing.T) {
+	// the reference values at t = 40 of Hairer and Wanner
+	want := []float64{0.7158270687193e+00, 0.9185534764529e-05, 0.2841637457654e+00}
+	tests := []struct {
+		name string
+		s    *Settings
+	}{
+		{"finite differences", &Settings{RelTol: 1e-6, AbsTol: 1e-10}},
+		{"analytic Jacobian", &Settings{RelTol: 1e-6, AbsTol: 1e-10, Jacobian: robertsonJac}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sol, err := BDF(robertson, 0, 40, []float64{1, 0, 0}, tt.s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			y := sol.Y[len(sol.Y)-1]
+			for i := range want {
+				if math.Abs(y[i]-want[i]) > 1e-4*math.Abs(want[i]) {
+					t.Errorf("y(40)[%d] = %v, want %v", i, y[i], want[i])
+				}
+			}
+			if s := y[0] + y[1] + y[2]; math.Abs(s-1) > 1e-9 {
+				t.Errorf("sum y = %v, want 1", s)
+			}
+			st := sol.Stats
+			// the Jacobian is kept over many steps
+			if st.Accepted > 500 || st.JacEvals == 0 || st.JacEvals >= st.LUDecomps || st.JacReuses == 0 {
+				t.Errorf("Stats = %v", st)
+			}
+			if st.NewtonIters == 0 || st.LinSolves != st.NewtonIters {
+				t.Errorf("Stats = %v, want a solve per Newton iteration", st)
+			}
+		})
+	}
+}
+
+func TestBDFOrder(t *testing.T) {
+	// y' = -y + sin t: a higher order takes fewer steps
+	f := func(t float64, y, dy []float64) { dy[0] = -y[0] + math.Sin(t) }
+	exact := func(t float64) float64 { return 0.5*(math.Sin(t)-math.Cos(t)) + 1.5*math.Exp(-t) }
+	steps := make([]int, 0, 2)
+	for _, tt := range []struct {
+		order int
+		tol   float64
+	}{{1, 1e-3}, {5, 1e-5}} {
+		order := tt.order
+		sol, err := BDF(f, 0, 10, []float64{1}, &Settings{RelTol: 1e-6, AbsTol: 1e-9, MaxOrder: order})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := sol.Y[len(sol.Y)-1][0]; math.Abs(got-exact(10)) > tt.tol {
+			t.Errorf("order %d: y(10) = %v, want %v", order, got, exact(10))
+		}
+		for _, x := range []float64{0.3, 2.75, 9.1} {
+			if y, _ := sol.At(x); math.Abs(y[0]-exact(x)) > tt.tol {
+				t.Errorf("order %d: At(%v) = %v, want %v", order, x, y[0], exact(x))
+			}
+		}
+		steps = append(steps, sol.Stats.Accepted)
+	}
+	if steps[1]*3 > steps[0] {
+		t.Errorf("steps of orders 1 and 5 = %v", steps)
+	}
+}
+
+func TestBDFStiff(t *testing.T) {
+	// the explicit method is limited by stability, not by accuracy
+	s := &Settings{RelTol: 1e-5, AbsTol: 1e-8}
+	sol, err := BDF(stiffLinear, 0, 10, []float64{0, 0}, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ref, err := DormandPrince(stiffLinear, 0, 10, []float64{0, 0}, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	y, yr := sol.Y[len(sol.Y)-1], ref.Y[len(ref.Y)-1]
+	if math.Abs(y[0]-yr[0]) > 1e-4 || math.Abs(y[1]-yr[1]) > 1e-4 {
+		t.Errorf("y(10) = %v, want %v", y, yr)
+	}
+	if sol.Stats.Accepted*5 > ref.Stats.Accepted {
+		t.Errorf("BDF steps %d, DormandPrince steps %d", sol.Stats.Accepted, ref.Stats.Accepted)
+	}
+}
+
+func TestBDFEvents(t *testing.T) {
+	// y' = -y from 1: y = 0.5 at ln 2, backwards from 0 to -1
+	f := func(t float64, y, dy []float64) { dy[0] = -y[0] }
+	half := Event{G: func(t float64, y []float64) float64 { return y[0] - 0.5 }, Terminal: true}
+	sol, err := BDF(f, 0, 5, []float64{1}, &Settings{RelTol: 1e-8, AbsTol: 1e-10, Events: []Event{half}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sol.Events) != 1 || math.Abs(sol.Events[0].T-math.Ln2) > 1e-6 {
+		t.Errorf("Events = %v, want at %v", sol.Events, math.Ln2)
+	}
+	if end := sol.T[len(sol.T)-1]; end != sol.Events[0].T {
+		t.Errorf("last T = %v, want the event", end)
+	}
+	sol, err = BDF(f, 0, -1, []float64{1}, &Settings{RelTol: 1e-8, AbsTol: 1e-10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if y := sol.Y[len(sol.Y)-1][0]; math.Abs(y-math.E) > 1e-6 {
+		t.Errorf("y(-1) = %v, want %v", y, math.E)
+	}
+}
+
+func TestChangeD(t *testing.T) {
+	// the differences of a quadratic at t, t - h, t - 2h rescaled to the
+	// step h/2 are those at t, t - h/2, t - h
+	p := func(t float64) float64 { return 1 + 2*t - 3*t*t }
+	diffs := func(t, h float64) [][]float64 {
+		a, b, c := p(t), p(t-h), p(t-2*h)
+		return [][]float64{{a}, {a - b}, {a - 2*b + c}}
+	}
+	d := diffs(1, 0.4)
+	changeD(d, 2, 0.5)
+	want := diffs(1, 0.2)
+	for i := range want {
+		if math.Abs(d[i][0]-want[i][0]) > 1e-14 {
+			t.Errorf("D[%d] = %v, want %v", i, d[i][0], want[i][0])
+		}
+	}
+}
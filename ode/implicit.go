@@ -0,0 +1,93 @@
+package ode
+
+import (
+	"math"
+
+	"github.com/shyang107/gnum"
+	"github.com/shyang107/gnum/solveeqs"
+)
+
+// newton holds the Jacobian and the factorized iteration matrix
+// I - c J of an implicit method, and counts their use
+type newton struct {
+	f     Func
+	st    *Settings
+	stats *Stats
+	jac   *gnum.Matrix
+	uses  int // the factorizations with jac
+	lu    *solveeqs.LU
+	fy    []float64
+}
+
+// newNewton returns the Newton state of f of dimension n
+func newNewton(f Func, n int, st *Settings, stats *Stats) *newton {
+	return &newton{f: f, st: st, stats: stats, jac: gnum.NewMatrix(n, n, nil), fy: make([]float64, n)}
+}
+
+// jacobian evaluates J = df/dy at (t, y), by st.Jacobian or by forward
+// differences from fy = f(t, y), or nil to evaluate it; the
+// factorization is dropped
+func (nw *newton) jacobian(t float64, y, fy []float64) {
+	nw.stats.JacEvals++
+	nw.uses = 0
+	nw.lu = nil
+	if nw.st.Jacobian != nil {
+		nw.st.Jacobian(t, y, nw.jac)
+		return
+	}
+	if fy == nil {
+		fy = nw.fy
+		nw.f(t, y, fy)
+		nw.stats.FuncEvals++
+	}
+	n := len(y)
+	yt := append([]float64(nil), y...)
+	ft := make([]float64, n)
+	for j := 0; j < n; j++ {
+		h := math.Sqrt(eps) * math.Max(math.Abs(y[j]), 1.0e-5)
+		yt[j] = y[j] + h
+		h = yt[j] - y[j]
+		nw.f(t, yt, ft)
+		nw.stats.FuncEvals++
+		for i := 0; i < n; i++ {
+			nw.jac.Set(i, j, (ft[i]-fy[i])/h)
+		}
+		yt[j] = y[j]
+	}
+}
+
+// factorize factorizes the iteration matrix I - c J
+func (nw *newton) factorize(c float64) error {
+	n, _ := nw.jac.Dims()
+	m := gnum.NewMatrix(n, n, nil)
+	m.Scale(-c, nw.jac)
+	for i := 0; i < n; i++ {
+		m.Set(i, i, m.At(i, i)+1.0)
+	}
+	nw.stats.LUDecomps++
+	if nw.uses > 0 {
+		nw.stats.JacReuses++
+	}
+	nw.uses++
+	nw.lu = new(solveeqs.LU)
+	if err := nw.lu.Factorize(m); err != nil {
+		nw.lu = nil
+		return err
+	}
+	return nil
+}
+
+// solve returns (I - c J)^(-1) b
+func (nw *newton) solve(b []float64) ([]float64, error) {
+	nw.stats.LinSolves++
+	return nw.lu.Solve(b)
+}
+
+// wnorm returns the weighted root mean square norm of v with the scales sc
+func wnorm(v, sc []float64) float64 {
+	s := 0.0
+	for i := range v {
+		s += (v[i] / sc[i]) * (v[i] / sc[i])
+	}
+	return math.Sqrt(s / float64(len(v)))
+}
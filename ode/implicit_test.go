@@ -0,0 +1,73 @@
+package ode
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/shyang107/gnum"
+)
+
+func TestNewtonJacobian(t *testing.T) {
+	y := []float64{0.9, 2e-5, 0.1}
+	want := gnum.NewMatrix(3, 3, nil)
+	robertsonJac(0, y, want)
+	tests := []struct {
+		name      string
+		s         *Settings
+		wantEvals int
+	}{
+		{"finite differences", &Settings{}, 4},
+		{"analytic", &Settings{Jacobian: robertsonJac}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stats Stats
+			nw := newNewton(robertson, 3, tt.s, &stats)
+			nw.jacobian(0, y, nil)
+			for i := 0; i < 3; i++ {
+				for j := 0; j < 3; j++ {
+					if d := nw.jac.At(i, j) - want.At(i, j); math.Abs(d) > 1e-6*math.Max(math.Abs(want.At(i, j)), 1) {
+						t.Errorf("J[%d][%d] = %v, want %v", i, j, nw.jac.At(i, j), want.At(i, j))
+					}
+				}
+			}
+			if stats.FuncEvals != tt.wantEvals || stats.JacEvals != 1 {
+				t.Errorf("Stats = %+v", stats)
+			}
+			// (I - c J) x = b
+			c := 0.1
+			for k := 0; k < 2; k++ {
+				if err := nw.factorize(c); err != nil {
+					t.Fatal(err)
+				}
+			}
+			b := []float64{1, -2, 3}
+			x, err := nw.solve(b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := 0; i < 3; i++ {
+				v := x[i]
+				for j := 0; j < 3; j++ {
+					v -= c * nw.jac.At(i, j) * x[j]
+				}
+				if math.Abs(v-b[i]) > 1e-9*math.Abs(b[i]) {
+					t.Errorf("row %d: (I - cJ) x = %v, want %v", i, v, b[i])
+				}
+			}
+			if stats.LUDecomps != 2 || stats.JacReuses != 1 || stats.LinSolves != 1 {
+				t.Errorf("Stats = %+v", stats)
+			}
+			if s := stats.String(); !strings.Contains(s, "jac evals 1 (reused 1), LU 2, solves 1") {
+				t.Errorf("String() = %q", s)
+			}
+		})
+	}
+}
+
+func TestWnorm(t *testing.T) {
+	if got := wnorm([]float64{3, -4}, []float64{1, 2}); math.Abs(got-math.Sqrt(6.5)) > 1e-15 {
+		t.Errorf("wnorm() = %v, want %v", got, math.Sqrt(6.5))
+	}
+}
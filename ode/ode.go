@@ -0,0 +1,281 @@
+// Package ode solves initial value problems y' = f(t, y), y(t0) = y0, of
+// systems of ordinary differential equations: by the explicit
+// Runge-Kutta methods RK4 with a fixed step and the adaptive embedded
+// pairs RKF45 and Dormand-Prince 5(4), and, for stiff systems, by the
+// implicit BDF of orders 1 to 5 and a Rosenbrock method. Every solution
+// has a continuous extension (dense output) between the steps, locates
+// the zero crossings of event functions and reports step statistics.
+package ode
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/shyang107/gnum"
+	"github.com/shyang107/gnum/nonlinear"
+)
+
+// Func is the right-hand side of y' = f(t, y); it sets dy = f(t, y) and
+// must not keep y or dy
+type Func func(t float64, y, dy []float64)
+
+// Event is an event function g(t, y) whose zero crossings are located
+// during the integration
+type Event struct {
+	G func(t float64, y []float64) float64
+	// Direction selects the crossings: +1 from g < 0 to g > 0, -1 from
+	// g > 0 to g < 0, 0 both
+	Direction int
+	// Terminal stops the integration at the first crossing
+	Terminal bool
+}
+
+// EventHit is a located zero crossing of the event Index
+type EventHit struct {
+	Index int
+	T     float64
+	Y     []float64
+}
+
+// Stats are the step statistics of a solution
+type Stats struct {
+	Accepted   int     // the accepted steps
+	Rejected   int     // the steps rejected by the error control
+	FuncEvals  int     // the evaluations of f
+	EventEvals int     // the evaluations of the event functions
+	MinStep    float64 // the smallest accepted |h|, the last step excepted
+	MaxStep    float64 // the largest accepted |h|
+	//-----------------------------------------------------
+	// the implicit methods BDF and Rosenbrock
+	//-----------------------------------------------------
+	JacEvals    int // the evaluations of the Jacobian df/dy
+	JacReuses   int // the factorizations with a Jacobian kept from an earlier one
+	LUDecomps   int // the LU factorizations of the iteration matrix
+	LinSolves   int // the solutions with the factorized iteration matrix
+	NewtonIters int // the Newton iterations
+	NewtonFails int // the Newton iterations that did not converge
+}
+
+// String returns the statistics in one line
+func (s Stats) String() string {
+	str := fmt.Sprintf("accepted %d, rejected %d, f evals %d, event evals %d, |h| in [%10.3e, %10.3e]",
+		s.Accepted, s.Rejected, s.FuncEvals, s.EventEvals, s.MinStep, s.MaxStep)
+	if s.LUDecomps > 0 {
+		str += fmt.Sprintf(", jac evals %d (reused %d), LU %d, solves %d, Newton %d (failed %d)",
+			s.JacEvals, s.JacReuses, s.LUDecomps, s.LinSolves, s.NewtonIters, s.NewtonFails)
+	}
+	return str
+}
+
+// Settings controls the solvers; the zero value uses the defaults
+type Settings struct {
+	RelTol float64 // the relative tolerance of the local error (default 1e-6)
+	AbsTol float64 // the absolute tolerance of the local error (default 1e-9)
+	// Step is the step of RK4 (default (t1 - t0) / 100) and the initial
+	// step of the adaptive methods (default estimated from f)
+	Step     float64
+	MaxStep  float64 // the largest |h| (default |t1 - t0|)
+	MaxSteps int     // the maximum number of steps (default 100000)
+	Events   []Event
+	// Jacobian, if not nil, sets jac = df/dy at (t, y) for the implicit
+	// methods; the default is forward differences
+	Jacobian func(t float64, y []float64, jac *gnum.Matrix)
+	MaxOrder int // the maximum order of BDF, 1 to 5 (default 5)
+}
+
+// defaults returns a copy of s with the defaults filled in
+func (s *Settings) defaults(t0, t1 float64, y0 []float64) (Settings, error) {
+	var d Settings
+	if s != nil {
+		d = *s
+	}
+	if len(y0) == 0 {
+		return d, fmt.Errorf("Empty initial value")
+	}
+	if !(t1 != t0) {
+		return d, fmt.Errorf("t1 = t0 = %g", t0)
+	}
+	if d.RelTol <= 0.0 {
+		d.RelTol = 1.0e-6
+	}
+	if d.AbsTol <= 0.0 {
+		d.AbsTol = 1.0e-9
+	}
+	d.Step = math.Abs(d.Step)
+	if d.MaxStep <= 0.0 {
+		d.MaxStep = math.Abs(t1 - t0)
+	}
+	if d.MaxSteps <= 0 {
+		d.MaxSteps = 100000
+	}
+	if d.MaxOrder <= 0 || d.MaxOrder > 5 {
+		d.MaxOrder = 5
+	}
+	for i, e := range d.Events {
+		if e.G == nil {
+			return d, fmt.Errorf("Events[%d].G is nil", i)
+		}
+	}
+	return d, nil
+}
+
+// Solution is the solution of an initial value problem at the steps
+// with its continuous extension
+type Solution struct {
+	T      []float64   // the times of the steps, T[0] = t0
+	Y      [][]float64 // the solution at T
+	Events []EventHit  // the located events in the order of time
+	Stats  Stats
+	segs   []interpolant
+}
+
+// At returns the solution at t between T[0] and the last step by the
+// continuous extension of the method
+func (s *Solution) At(t float64) ([]float64, error) {
+	n := len(s.T)
+	dir := 1.0
+	if s.T[n-1] < s.T[0] {
+		dir = -1.0
+	}
+	if (t-s.T[0])*dir < 0.0 || (t-s.T[n-1])*dir > 0.0 {
+		return nil, fmt.Errorf("t = %g is outside [%g, %g]", t, s.T[0], s.T[n-1])
+	}
+	if n == 1 {
+		return append([]float64(nil), s.Y[0]...), nil
+	}
+	i := sort.Search(n-1, func(i int) bool { return (s.T[i+1]-t)*dir >= 0.0 })
+	return s.segs[i].eval(t, nil), nil
+}
+
+// interpolant is the continuous extension of a method over one step
+type interpolant interface {
+	// eval returns the solution at t in y, allocated if nil
+	eval(t float64, y []float64) []float64
+}
+
+// segment is the continuous extension over a step from t0 with the step
+// h in Hairer's form, with theta = (t - t0) / h,
+//	y = r1 + theta (r2 + (1 - theta) (r3 + theta (r4 + (1 - theta) r5)));
+// without r5 it is the cubic Hermite interpolant of y and f at both ends
+type segment struct {
+	t0, h              float64
+	r1, r2, r3, r4, r5 []float64
+}
+
+// newSegment returns the Hermite segment of the step from (t0, y0) to
+// (t0 + h, y1) with the slopes f0 and f1; r5 is set by the caller
+func newSegment(t0, h float64, y0, y1, f0, f1 []float64) segment {
+	n := len(y0)
+	s := segment{t0: t0, h: h, r1: append([]float64(nil), y0...),
+		r2: make([]float64, n), r3: make([]float64, n), r4: make([]float64, n)}
+	for i := range y0 {
+		s.r2[i] = y1[i] - y0[i]
+		s.r3[i] = h*f0[i] - s.r2[i]
+		s.r4[i] = s.r2[i] - h*f1[i] - s.r3[i]
+	}
+	return s
+}
+
+// eval returns the extension at t in y, allocated if nil
+func (s *segment) eval(t float64, y []float64) []float64 {
+	if y == nil {
+		y = make([]float64, len(s.r1))
+	}
+	th := (t - s.t0) / s.h
+	th1 := 1.0 - th
+	for i := range y {
+		v := s.r4[i]
+		if s.r5 != nil {
+			v += th1 * s.r5[i]
+		}
+		y[i] = s.r1[i] + th*(s.r2[i]+th1*(s.r3[i]+th*v))
+	}
+	return y
+}
+
+// events tracks the signs of the event functions between the steps
+type events struct {
+	ev    []Event
+	g     []float64
+	stats *Stats
+}
+
+// newEvents evaluates the event functions at the initial point
+func newEvents(ev []Event, t0 float64, y0 []float64, stats *Stats) *events {
+	e := &events{ev: ev, g: make([]float64, len(ev)), stats: stats}
+	for i, v := range ev {
+		e.g[i] = v.G(t0, y0)
+		stats.EventEvals++
+	}
+	return e
+}
+
+// check locates the crossings in the step from t0 to (t1, y1) by
+// Brent's method on the continuous extension seg. It returns the hits in
+// the order of time up to the first terminal one, and whether the
+// integration stops.
+func (e *events) check(seg interpolant, t0, t1 float64, y1 []float64) (hits []EventHit, stop bool) {
+	if len(e.ev) == 0 {
+		return nil, false
+	}
+	yt := make([]float64, len(y1))
+	for i, v := range e.ev {
+		g0 := e.g[i]
+		g1 := v.G(t1, y1)
+		e.stats.EventEvals++
+		e.g[i] = g1
+		if g0 == 0.0 || ((g0 > 0.0) == (g1 > 0.0) && g1 != 0.0) {
+			continue
+		}
+		if (v.Direction > 0 && g0 > 0.0) || (v.Direction < 0 && g0 < 0.0) {
+			continue
+		}
+		te := t1
+		if g1 != 0.0 {
+			g := func(t float64) float64 {
+				e.stats.EventEvals++
+				return v.G(t, seg.eval(t, yt))
+			}
+			te, _, _ = nonlinear.Broot(g, t0, t1, 4.0*eps*math.Max(math.Abs(t0), math.Abs(t1))+1.0e-14*math.Abs(t1-t0), 0)
+		}
+		hits = append(hits, EventHit{Index: i, T: te, Y: seg.eval(te, nil)})
+	}
+	sort.SliceStable(hits, func(a, b int) bool { return (hits[a].T-hits[b].T)*(t1-t0) < 0.0 })
+	for k, hit := range hits {
+		if e.ev[hit.Index].Terminal {
+			return hits[:k+1], true
+		}
+	}
+	return hits, false
+}
+
+// accept records the accepted step from t to (tnew, ynew) with the
+// continuous extension seg in sol: the statistics, the events and the new
+// point, or the point of a terminal event, when it returns true
+func (e *events) accept(sol *Solution, seg interpolant, t, tnew float64, ynew []float64, last bool) (stop bool) {
+	stats := &sol.Stats
+	h := math.Abs(tnew - t)
+	stats.Accepted++
+	if !last || stats.Accepted == 1 {
+		if stats.MinStep == 0.0 || h < stats.MinStep {
+			stats.MinStep = h
+		}
+	}
+	stats.MaxStep = math.Max(stats.MaxStep, h)
+	hits, stop := e.check(seg, t, tnew, ynew)
+	sol.Events = append(sol.Events, hits...)
+	sol.segs = append(sol.segs, seg)
+	if stop {
+		te := hits[len(hits)-1]
+		sol.T = append(sol.T, te.T)
+		sol.Y = append(sol.Y, append([]float64(nil), te.Y...))
+		return true
+	}
+	sol.T = append(sol.T, tnew)
+	sol.Y = append(sol.Y, append([]float64(nil), ynew...))
+	return false
+}
+
+// eps is the machine epsilon
+const eps = 2.220446049250313e-16
@@ -0,0 +1,142 @@
+package ode
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+// oscillator is the harmonic oscillator y = (cos t, -sin t) from (1, 0)
+func oscillator(t float64, y, dy []float64) {
+	dy[0] = y[1]
+	dy[1] = -y[0]
+}
+
+// projectile is the height and the velocity of a body under gravity 9.81
+func projectile(t float64, y, dy []float64) {
+	dy[0] = y[1]
+	dy[1] = -9.81
+}
+
+func TestSolutionAt(t *testing.T) {
+	for _, solver := range []struct {
+		name string
+		f    func(Func, float64, float64, []float64, *Settings) (*Solution, error)
+		tol  float64
+	}{
+		{"RK4", RK4, 1e-6},
+		{"RKF45", RKF45, 1e-6},
+		{"DormandPrince", DormandPrince, 1e-6},
+	} {
+		t.Run(solver.name, func(t *testing.T) {
+			sol, err := solver.f(oscillator, 0, 10, []float64{1, 0}, &Settings{RelTol: 1e-9, AbsTol: 1e-12, Step: 0.01})
+			if err != nil {
+				t.Fatal(err)
+			}
+			// between the steps by the continuous extension
+			for _, x := range []float64{0, 0.123, 3.3, 7.77, 10} {
+				y, err := sol.At(x)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if math.Abs(y[0]-math.Cos(x)) > solver.tol || math.Abs(y[1]+math.Sin(x)) > solver.tol {
+					t.Errorf("At(%v) = %v, want [%v %v]", x, y, math.Cos(x), -math.Sin(x))
+				}
+			}
+			// at the steps the extension is the solution
+			for i := range sol.T {
+				y, _ := sol.At(sol.T[i])
+				if math.Abs(y[0]-sol.Y[i][0]) > 1e-14 {
+					t.Errorf("At(T[%d]) = %v, want Y[%d] = %v", i, y, i, sol.Y[i])
+				}
+			}
+			for _, x := range []float64{-0.1, 10.1} {
+				if _, err := sol.At(x); err == nil {
+					t.Errorf("At(%v) error = nil, want outside", x)
+				}
+			}
+		})
+	}
+}
+
+func TestEvents(t *testing.T) {
+	// thrown up at 20 m/s from 1 m: the apex at v = 0 and the landing at
+	// height 0, falling
+	v0, x0, g := 20.0, 1.0, 9.81
+	apex := v0 / g
+	land := (v0 + math.Sqrt(v0*v0+2*g*x0)) / g
+	height := func(t float64, y []float64) float64 { return y[0] }
+	speed := func(t float64, y []float64) float64 { return y[1] }
+	tests := []struct {
+		name     string
+		events   []Event
+		wantT    []float64
+		wantEnd  float64
+		wantIdxs []int
+	}{
+		{"terminal landing", []Event{{G: height, Direction: -1, Terminal: true}}, []float64{land}, land, []int{0}},
+		{"apex and landing", []Event{{G: speed}, {G: height, Terminal: true}}, []float64{apex, land}, land, []int{0, 1}},
+		{"rising only", []Event{{G: speed, Direction: 1}}, nil, 10, nil},
+		{"falling speed", []Event{{G: speed, Direction: -1}}, []float64{apex}, 10, []int{0}},
+		{"nonterminal", []Event{{G: height}}, []float64{land}, 10, []int{0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Settings{Events: tt.events}
+			sol, err := DormandPrince(projectile, 0, 10, []float64{x0, v0}, s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(sol.Events) != len(tt.wantT) {
+				t.Fatalf("Events = %v, want at %v", sol.Events, tt.wantT)
+			}
+			for i, e := range sol.Events {
+				if math.Abs(e.T-tt.wantT[i]) > 1e-10 || e.Index != tt.wantIdxs[i] {
+					t.Errorf("Events[%d] = %+v, want event %d at %v", i, e, tt.wantIdxs[i], tt.wantT[i])
+				}
+				if want := x0 + v0*e.T - 0.5*g*e.T*e.T; math.Abs(e.Y[0]-want) > 1e-9 {
+					t.Errorf("Events[%d].Y = %v, want height %v", i, e.Y, want)
+				}
+			}
+			if end := sol.T[len(sol.T)-1]; math.Abs(end-tt.wantEnd) > 1e-10 {
+				t.Errorf("last T = %v, want %v", end, tt.wantEnd)
+			}
+			if sol.Stats.EventEvals == 0 {
+				t.Errorf("Stats = %v", sol.Stats)
+			}
+		})
+	}
+}
+
+func TestSettingsErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		t1     float64
+		y0     []float64
+		s      *Settings
+		wantOK bool
+	}{
+		{"empty", 1, nil, nil, false},
+		{"t1 = t0", 0, []float64{1}, nil, false},
+		{"nil event", 1, []float64{1}, &Settings{Events: []Event{{}}}, false},
+		{"max steps", 1, []float64{1, 0}, &Settings{MaxSteps: 3, Step: 0.01}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sol, err := RK4(oscillator, 0, tt.t1, tt.y0, tt.s)
+			if err == nil {
+				t.Fatalf("RK4() error = nil, want an error")
+			}
+			if (sol != nil) != tt.wantOK {
+				t.Errorf("RK4() solution = %v, want a partial solution %v", sol, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestStatsString(t *testing.T) {
+	s := Stats{Accepted: 3, Rejected: 1, FuncEvals: 20}
+	if got := s.String(); !strings.Contains(got, "accepted 3, rejected 1, f evals 20") {
+		t.Errorf("String() = %q", got)
+	}
+}
@@ -0,0 +1,273 @@
+package ode
+
+import (
+	"fmt"
+	"math"
+)
+
+// tableau is the Butcher tableau of an explicit Runge-Kutta method; the
+// solution advances with b, and e = b - b* of the embedded method of
+// order q estimates the local error if it is not nil
+type tableau struct {
+	c    []float64
+	a    [][]float64
+	b, e []float64
+	q    int       // the order of the error estimate
+	fsal bool      // the last stage is f at the new point
+	d    []float64 // the dense output weights of r5, or nil for Hermite
+}
+
+// rk4 is the classical Runge-Kutta method of order 4
+var rk4 = &tableau{
+	c: []float64{0, 0.5, 0.5, 1},
+	a: [][]float64{nil, {0.5}, {0, 0.5}, {0, 0, 1}},
+	b: []float64{1.0 / 6.0, 1.0 / 3.0, 1.0 / 3.0, 1.0 / 6.0},
+}
+
+// rkf45 is the Runge-Kutta-Fehlberg pair 4(5), advancing with the fifth
+// order solution (local extrapolation)
+var rkf45 = &tableau{
+	c: []float64{0, 1.0 / 4.0, 3.0 / 8.0, 12.0 / 13.0, 1, 1.0 / 2.0},
+	a: [][]float64{
+		nil,
+		{1.0 / 4.0},
+		{3.0 / 32.0, 9.0 / 32.0},
+		{1932.0 / 2197.0, -7200.0 / 2197.0, 7296.0 / 2197.0},
+		{439.0 / 216.0, -8, 3680.0 / 513.0, -845.0 / 4104.0},
+		{-8.0 / 27.0, 2, -3544.0 / 2565.0, 1859.0 / 4104.0, -11.0 / 40.0},
+	},
+	b: []float64{16.0 / 135.0, 0, 6656.0 / 12825.0, 28561.0 / 56430.0, -9.0 / 50.0, 2.0 / 55.0},
+	e: []float64{16.0/135.0 - 25.0/216.0, 0, 6656.0/12825.0 - 1408.0/2565.0,
+		28561.0/56430.0 - 2197.0/4104.0, -9.0/50.0 + 1.0/5.0, 2.0 / 55.0},
+	q: 4,
+}
+
+// dopri5 is the Dormand-Prince pair 5(4) with the continuous extension
+// of order 4 of Hairer, Norsett and Wanner
+var dopri5 = &tableau{
+	c: []float64{0, 1.0 / 5.0, 3.0 / 10.0, 4.0 / 5.0, 8.0 / 9.0, 1, 1},
+	a: [][]float64{
+		nil,
+		{1.0 / 5.0},
+		{3.0 / 40.0, 9.0 / 40.0},
+		{44.0 / 45.0, -56.0 / 15.0, 32.0 / 9.0},
+		{19372.0 / 6561.0, -25360.0 / 2187.0, 64448.0 / 6561.0, -212.0 / 729.0},
+		{9017.0 / 3168.0, -355.0 / 33.0, 46732.0 / 5247.0, 49.0 / 176.0, -5103.0 / 18656.0},
+		{35.0 / 384.0, 0, 500.0 / 1113.0, 125.0 / 192.0, -2187.0 / 6784.0, 11.0 / 84.0},
+	},
+	b: []float64{35.0 / 384.0, 0, 500.0 / 1113.0, 125.0 / 192.0, -2187.0 / 6784.0, 11.0 / 84.0, 0},
+	e: []float64{35.0/384.0 - 5179.0/57600.0, 0, 500.0/1113.0 - 7571.0/16695.0, 125.0/192.0 - 393.0/640.0,
+		-2187.0/6784.0 + 92097.0/339200.0, 11.0/84.0 - 187.0/2100.0, -1.0 / 40.0},
+	q:    4,
+	fsal: true,
+	d: []float64{-12715105075.0 / 11282082432.0, 0, 87487479700.0 / 32700410799.0,
+		-10690763975.0 / 1880347072.0, 701980252875.0 / 199316789632.0,
+		-1453857185.0 / 822651844.0, 69997945.0 / 29380423.0},
+}
+
+// RK4 solves y' = f(t, y), y(t0) = y0 from t0 to t1 (t1 < t0 integrates
+// backwards) by the classical Runge-Kutta method of order 4 with the
+// fixed step s.Step, the last step shortened to end at t1. The
+// continuous extension is the cubic Hermite interpolant.
+//
+// s	: the settings, nil means the defaults; RelTol and AbsTol are not used
+func RK4(f Func, t0, t1 float64, y0 []float64, s *Settings) (*Solution, error) {
+	return solve(f, t0, t1, y0, rk4, s)
+}
+
+// RKF45 solves y' = f(t, y), y(t0) = y0 from t0 to t1 by the adaptive
+// Runge-Kutta-Fehlberg pair 4(5). The step is controlled so that the
+// local error e of every step satisfies
+//	sqrt(1/n sum_i (e_i / (AbsTol + RelTol max(|y_i|, |y_i^new|)))^2) <= 1.
+// The continuous extension is the cubic Hermite interpolant. If MaxSteps
+// are exceeded or the step underflows, the solution so far is returned
+// with an error.
+//
+// s	: the settings, nil means the defaults
+func RKF45(f Func, t0, t1 float64, y0 []float64, s *Settings) (*Solution, error) {
+	return solve(f, t0, t1, y0, rkf45, s)
+}
+
+// DormandPrince solves y' = f(t, y), y(t0) = y0 from t0 to t1 by the
+// adaptive Dormand-Prince pair 5(4) (DOPRI5, as ode45 of MATLAB), with
+// the error control of RKF45. The last stage is reused as the first of
+// the next step, so an accepted step costs 6 evaluations of f, and the
+// continuous extension is of order 4.
+//
+// s	: the settings, nil means the defaults
+func DormandPrince(f Func, t0, t1 float64, y0 []float64, s *Settings) (*Solution, error) {
+	return solve(f, t0, t1, y0, dopri5, s)
+}
+
+// solve integrates with the tableau tab, with a fixed step if tab.e is nil
+func solve(f Func, t0, t1 float64, y0 []float64, tab *tableau, s *Settings) (*Solution, error) {
+	st, err := s.defaults(t0, t1, y0)
+	if err != nil {
+		return nil, err
+	}
+	n := len(y0)
+	ns := len(tab.c)
+	adaptive := tab.e != nil
+	dir := 1.0
+	if t1 < t0 {
+		dir = -1.0
+	}
+	sol := &Solution{T: []float64{t0}, Y: [][]float64{append([]float64(nil), y0...)}}
+	stats := &sol.Stats
+	ev := newEvents(st.Events, t0, y0, stats)
+	k := make([][]float64, ns)
+	for i := range k {
+		k[i] = make([]float64, n)
+	}
+	t, y := t0, append([]float64(nil), y0...)
+	yt := make([]float64, n)
+	f(t, y, k[0])
+	stats.FuncEvals++
+	//-----------------------------------------------------
+	// the initial step
+	//-----------------------------------------------------
+	h := st.Step
+	if h == 0.0 {
+		if adaptive {
+			h = initialStep(f, t, dir, y, k[0], tab.q+1, &st, stats)
+		} else {
+			h = math.Abs(t1-t0) / 100.0
+		}
+	}
+	h = dir * math.Min(h, st.MaxStep)
+	rejected := false
+	for t != t1 {
+		if stats.Accepted+stats.Rejected >= st.MaxSteps {
+			return sol, fmt.Errorf("Not convergence in %d steps at t = %13.6e", st.MaxSteps, t)
+		}
+		last := (t+h-t1)*dir >= 0.0
+		if last {
+			h = t1 - t
+		}
+		//-----------------------------------------------------
+		// the stages and the new point
+		//-----------------------------------------------------
+		for j := 1; j < ns; j++ {
+			for i := 0; i < n; i++ {
+				v := y[i]
+				for l, a := range tab.a[j] {
+					v += h * a * k[l][i]
+				}
+				yt[i] = v
+			}
+			f(t+tab.c[j]*h, yt, k[j])
+			stats.FuncEvals++
+		}
+		ynew := make([]float64, n)
+		for i := 0; i < n; i++ {
+			v := y[i]
+			for l, b := range tab.b {
+				v += h * b * k[l][i]
+			}
+			ynew[i] = v
+		}
+		//-----------------------------------------------------
+		// the error control
+		//-----------------------------------------------------
+		fac := 1.0
+		if adaptive {
+			e := 0.0
+			for i := 0; i < n; i++ {
+				v := 0.0
+				for l, c := range tab.e {
+					v += c * k[l][i]
+				}
+				sc := st.AbsTol + st.RelTol*math.Max(math.Abs(y[i]), math.Abs(ynew[i]))
+				e += (h * v / sc) * (h * v / sc)
+			}
+			e = math.Sqrt(e / float64(n))
+			fac = math.Min(5.0, math.Max(0.2, 0.9*math.Pow(e, -1.0/float64(tab.q+1))))
+			if e > 1.0 || math.IsNaN(e) {
+				if math.IsNaN(e) {
+					fac = 0.2
+				}
+				stats.Rejected++
+				rejected = true
+				h *= fac
+				if math.Abs(h) <= 16.0*eps*math.Abs(t) {
+					return sol, fmt.Errorf("Step size too small at t = %13.6e", t)
+				}
+				continue
+			}
+		}
+		//-----------------------------------------------------
+		// accept the step
+		//-----------------------------------------------------
+		tnew := t + h
+		if last {
+			tnew = t1
+		}
+		fnew := k[ns-1]
+		if !tab.fsal {
+			fnew = make([]float64, n)
+			f(tnew, ynew, fnew)
+			stats.FuncEvals++
+		}
+		seg := newSegment(t, h, y, ynew, k[0], fnew)
+		if tab.d != nil {
+			seg.r5 = make([]float64, n)
+			for i := 0; i < n; i++ {
+				v := 0.0
+				for l, d := range tab.d {
+					v += d * k[l][i]
+				}
+				seg.r5[i] = h * v
+			}
+		}
+		if ev.accept(sol, &seg, t, tnew, ynew, last) {
+			break
+		}
+		t, y = tnew, append(y[:0], ynew...)
+		copy(k[0], fnew)
+		if adaptive {
+			if rejected {
+				fac = math.Min(fac, 1.0)
+			}
+			h = dir * math.Min(math.Abs(h)*fac, st.MaxStep)
+			rejected = false
+		}
+	}
+	return sol, nil
+}
+
+// initialStep estimates the first step of a method of order p by the
+// algorithm of Hairer, Norsett and Wanner; the trial step is taken in
+// the direction dir = +-1 of the integration
+func initialStep(f Func, t, dir float64, y, f0 []float64, p int, st *Settings, stats *Stats) float64 {
+	n := len(y)
+	d0, d1 := 0.0, 0.0
+	for i := 0; i < n; i++ {
+		sc := st.AbsTol + st.RelTol*math.Abs(y[i])
+		d0 += (y[i] / sc) * (y[i] / sc)
+		d1 += (f0[i] / sc) * (f0[i] / sc)
+	}
+	d0, d1 = math.Sqrt(d0/float64(n)), math.Sqrt(d1/float64(n))
+	h0 := 1.0e-6
+	if d0 >= 1.0e-5 && d1 >= 1.0e-5 {
+		h0 = 0.01 * d0 / d1
+	}
+	h0 = math.Min(h0, st.MaxStep)
+	y1 := make([]float64, n)
+	f1 := make([]float64, n)
+	for i := range y1 {
+		y1[i] = y[i] + dir*h0*f0[i]
+	}
+	f(t+dir*h0, y1, f1)
+	stats.FuncEvals++
+	d2 := 0.0
+	for i := 0; i < n; i++ {
+		sc := st.AbsTol + st.RelTol*math.Abs(y[i])
+		d2 += ((f1[i] - f0[i]) / sc) * ((f1[i] - f0[i]) / sc)
+	}
+	d2 = math.Sqrt(d2/float64(n)) / h0
+	h1 := math.Max(1.0e-6, h0*1.0e-3)
+	if m := math.Max(d1, d2); m > 1.0e-15 {
+		h1 = math.Pow(0.01/m, 1.0/float64(p))
+	}
+	return math.Min(100.0*h0, h1)
+}
@@ -0,0 +1,136 @@
+package ode
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRK4Order(t *testing.T) {
+	// halving the step reduces the global error by 2^4
+	errAt := func(h float64) float64 {
+		sol, err := RK4(oscillator, 0, 5, []float64{1, 0}, &Settings{Step: h})
+		if err != nil {
+			t.Fatal(err)
+		}
+		y := sol.Y[len(sol.Y)-1]
+		return math.Hypot(y[0]-math.Cos(5), y[1]+math.Sin(5))
+	}
+	e1, e2 := errAt(0.1), errAt(0.05)
+	if r := e1 / e2; r < 14 || r > 18 {
+		t.Errorf("error ratio %v, want about 16", r)
+	}
+	sol, _ := RK4(oscillator, 0, 1, []float64{1, 0}, &Settings{Step: 0.3})
+	if n := len(sol.T); n != 5 || sol.T[n-1] != 1 {
+		t.Errorf("T = %v, want 4 steps ending at 1", sol.T)
+	}
+	if sol.Stats.FuncEvals != 1+4*4 {
+		t.Errorf("FuncEvals = %d, want %d", sol.Stats.FuncEvals, 1+4*4)
+	}
+}
+
+func TestAdaptive(t *testing.T) {
+	// y' = -2 t y^2, y(0) = 1, y = 1 / (1 + t^2)
+	f := func(t float64, y, dy []float64) { dy[0] = -2 * t * y[0] * y[0] }
+	exact := func(t float64) float64 { return 1 / (1 + t*t) }
+	for _, solver := range []struct {
+		name string
+		f    func(Func, float64, float64, []float64, *Settings) (*Solution, error)
+	}{
+		{"RKF45", RKF45},
+		{"DormandPrince", DormandPrince},
+	} {
+		t.Run(solver.name, func(t *testing.T) {
+			prev := 0
+			for _, tol := range []float64{1e-4, 1e-7, 1e-10} {
+				sol, err := solver.f(f, 0, 10, []float64{1}, &Settings{RelTol: tol, AbsTol: tol})
+				if err != nil {
+					t.Fatal(err)
+				}
+				got := sol.Y[len(sol.Y)-1][0]
+				if e := math.Abs(got - exact(10)); e > 100*tol {
+					t.Errorf("tol %v: y(10) = %v, error %v", tol, got, e)
+				}
+				if sol.T[len(sol.T)-1] != 10 {
+					t.Errorf("last T = %v, want 10", sol.T[len(sol.T)-1])
+				}
+				st := sol.Stats
+				if st.Accepted <= prev || st.Accepted != len(sol.T)-1 || st.MinStep <= 0 || st.MaxStep < st.MinStep {
+					t.Errorf("tol %v: Stats = %v", tol, st)
+				}
+				prev = st.Accepted
+			}
+		})
+	}
+}
+
+func TestDormandPrinceFSAL(t *testing.T) {
+	sol, err := DormandPrince(oscillator, 0, 20, []float64{1, 0}, &Settings{RelTol: 1e-8})
+	if err != nil {
+		t.Fatal(err)
+	}
+	st := sol.Stats
+	// f(t0), the initial step estimate and 6 stages per step
+	if want := 2 + 6*(st.Accepted+st.Rejected); st.FuncEvals != want {
+		t.Errorf("FuncEvals = %d, want %d (%v)", st.FuncEvals, want, st)
+	}
+	// the continuous extension of order 4 is accurate between the steps
+	for i := 0; i+1 < len(sol.T); i += 7 {
+		x := 0.5 * (sol.T[i] + sol.T[i+1])
+		y, _ := sol.At(x)
+		if math.Abs(y[0]-math.Cos(x)) > 1e-6 {
+			t.Errorf("At(%v) = %v, want %v", x, y[0], math.Cos(x))
+		}
+	}
+}
+
+func TestBackward(t *testing.T) {
+	// from (cos 3, -sin 3) at t = 3 back to t = 0
+	y3 := []float64{math.Cos(3), -math.Sin(3)}
+	for _, solver := range []func(Func, float64, float64, []float64, *Settings) (*Solution, error){RK4, RKF45, DormandPrince} {
+		sol, err := solver(oscillator, 3, 0, y3, &Settings{RelTol: 1e-10, AbsTol: 1e-12, Step: 0.001})
+		if err != nil {
+			t.Fatal(err)
+		}
+		y := sol.Y[len(sol.Y)-1]
+		if sol.T[len(sol.T)-1] != 0 || math.Abs(y[0]-1) > 1e-8 || math.Abs(y[1]) > 1e-8 {
+			t.Errorf("y(0) = %v at %v, want [1 0]", y, sol.T[len(sol.T)-1])
+		}
+		if y, err := sol.At(1.5); err != nil || math.Abs(y[0]-math.Cos(1.5)) > 1e-8 {
+			t.Errorf("At(1.5) = %v, %v", y, err)
+		}
+	}
+}
+
+func TestBackwardInterval(t *testing.T) {
+	// f is evaluated only in [0, 1] when the first step is estimated and
+	// when df/dt is differenced on the way back from t = 1
+	for name, solver := range map[string]func(Func, float64, float64, []float64, *Settings) (*Solution, error){
+		"RKF45": RKF45, "DormandPrince": DormandPrince, "BDF": BDF, "Rosenbrock": Rosenbrock,
+	} {
+		lo, hi := 0.0, 1.0
+		f := func(t float64, y, dy []float64) {
+			lo, hi = math.Min(lo, t), math.Max(hi, t)
+			dy[0] = -y[0]
+		}
+		sol, err := solver(f, 1, 0, []float64{1}, &Settings{RelTol: 1e-6, AbsTol: 1e-9})
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if lo < 0 || hi > 1 {
+			t.Errorf("%s: f evaluated in [%v, %v], want [0, 1]", name, lo, hi)
+		}
+		if y := sol.Y[len(sol.Y)-1][0]; math.Abs(y-math.E) > 1e-4 {
+			t.Errorf("%s: y(0) = %v, want %v", name, y, math.E)
+		}
+	}
+}
+
+func TestMaxStep(t *testing.T) {
+	sol, err := DormandPrince(func(t float64, y, dy []float64) { dy[0] = 0 }, 0, 1, []float64{1}, &Settings{MaxStep: 0.1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sol.Stats.MaxStep > 0.1+1e-15 || sol.Stats.Accepted < 10 {
+		t.Errorf("Stats = %v, want steps <= 0.1", sol.Stats)
+	}
+}
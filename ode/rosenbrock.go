@@ -0,0 +1,179 @@
+package ode
+
+import (
+	"fmt"
+	"math"
+)
+
+// Rosenbrock solves the stiff system y' = f(t, y), y(t0) = y0 from t0 to
+// t1 by the linearly implicit Rosenbrock pair 2(3) of Shampine and
+// Reichelt (ode23s of MATLAB). A step solves three linear systems with
+// the one matrix W = I - h d J, d = 1 / (2 + sqrt(2)), and needs no
+// Newton iteration:
+//	k1 = W^(-1) (f(t, y) + h d T)
+//	k2 = W^(-1) (f(t + h/2, y + h/2 k1) - k1) + k1
+//	y_new = y + h k2
+//	k3 = W^(-1) (f(t_new, y_new) - e32 (k2 - F1) - 2 (k1 - f(t, y)) + h d T)
+// with T = df/dt by a one-sided difference toward t1 and
+// e32 = 6 + sqrt(2); the local error h/6 (k1 - 2 k2 + k3) is controlled
+// as in RKF45. J is evaluated once per accepted step and reused by the
+// rejected ones. The continuous extension is of order 2.
+//
+// s	: the settings, nil means the defaults
+func Rosenbrock(f Func, t0, t1 float64, y0 []float64, s *Settings) (*Solution, error) {
+	d := 1.0 / (2.0 + math.Sqrt2)
+	e32 := 6.0 + math.Sqrt2
+	st, err := s.defaults(t0, t1, y0)
+	if err != nil {
+		return nil, err
+	}
+	n := len(y0)
+	dir := 1.0
+	if t1 < t0 {
+		dir = -1.0
+	}
+	sol := &Solution{T: []float64{t0}, Y: [][]float64{append([]float64(nil), y0...)}}
+	stats := &sol.Stats
+	ev := newEvents(st.Events, t0, y0, stats)
+	t, y := t0, append([]float64(nil), y0...)
+	f0 := make([]float64, n)
+	f(t, y, f0)
+	stats.FuncEvals++
+	h := st.Step
+	if h == 0.0 {
+		h = initialStep(f, t, dir, y, f0, 3, &st, stats)
+	}
+	h = dir * math.Min(h, st.MaxStep)
+	nw := newNewton(f, n, &st, stats)
+	ft := make([]float64, n)
+	dfdt := make([]float64, n)
+	yt := make([]float64, n)
+	f1 := make([]float64, n)
+	b := make([]float64, n)
+	rejected := false
+	for t != t1 {
+		if stats.Accepted+stats.Rejected >= st.MaxSteps {
+			return sol, fmt.Errorf("Not convergence in %d steps at t = %13.6e", st.MaxSteps, t)
+		}
+		//-----------------------------------------------------
+		// J and T at the start of the step
+		//-----------------------------------------------------
+		if !rejected {
+			nw.jacobian(t, y, f0)
+			dt := math.Sqrt(eps) * math.Max(math.Abs(t), math.Abs(h))
+			f(t+dir*dt, y, ft)
+			stats.FuncEvals++
+			for i := range dfdt {
+				dfdt[i] = (ft[i] - f0[i]) / (dir * dt)
+			}
+		}
+		last := (t+h-t1)*dir >= 0.0
+		if last {
+			h = t1 - t
+		}
+		//-----------------------------------------------------
+		// the three stages
+		//-----------------------------------------------------
+		var k1, k2, k3 []float64
+		err := nw.factorize(h * d)
+		if err == nil {
+			for i := range b {
+				b[i] = f0[i] + h*d*dfdt[i]
+			}
+			k1, err = nw.solve(b)
+		}
+		if err == nil {
+			for i := range yt {
+				yt[i] = y[i] + 0.5*h*k1[i]
+			}
+			f(t+0.5*h, yt, f1)
+			stats.FuncEvals++
+			for i := range b {
+				b[i] = f1[i] - k1[i]
+			}
+			k2, err = nw.solve(b)
+		}
+		ynew := make([]float64, n)
+		fnew := make([]float64, n)
+		if err == nil {
+			for i := range k2 {
+				k2[i] += k1[i]
+				ynew[i] = y[i] + h*k2[i]
+			}
+			f(t+h, ynew, fnew)
+			stats.FuncEvals++
+			for i := range b {
+				b[i] = fnew[i] - e32*(k2[i]-f1[i]) - 2.0*(k1[i]-f0[i]) + h*d*dfdt[i]
+			}
+			k3, err = nw.solve(b)
+		}
+		//-----------------------------------------------------
+		// the error control
+		//-----------------------------------------------------
+		e := math.NaN()
+		if err == nil {
+			e = 0.0
+			for i := 0; i < n; i++ {
+				sc := st.AbsTol + st.RelTol*math.Max(math.Abs(y[i]), math.Abs(ynew[i]))
+				v := h / 6.0 * (k1[i] - 2.0*k2[i] + k3[i]) / sc
+				e += v * v
+			}
+			e = math.Sqrt(e / float64(n))
+		}
+		fac := math.Min(5.0, math.Max(0.2, 0.9*math.Pow(e, -1.0/3.0)))
+		if e > 1.0 || math.IsNaN(e) {
+			if math.IsNaN(e) {
+				fac = 0.2
+			}
+			stats.Rejected++
+			rejected = true
+			h *= fac
+			if math.Abs(h) <= 16.0*eps*math.Abs(t) {
+				return sol, fmt.Errorf("Step size too small at t = %13.6e", t)
+			}
+			continue
+		}
+		//-----------------------------------------------------
+		// accept the step
+		//-----------------------------------------------------
+		tnew := t + h
+		if last {
+			tnew = t1
+		}
+		seg := &rosenbrockDense{t0: t, h: h, c1: d, y: append([]float64(nil), y...),
+			k1: append([]float64(nil), k1...), k2: append([]float64(nil), k2...)}
+		if ev.accept(sol, seg, t, tnew, ynew, last) {
+			break
+		}
+		t, y = tnew, append(y[:0], ynew...)
+		copy(f0, fnew)
+		if rejected {
+			fac = math.Min(fac, 1.0)
+		}
+		h = dir * math.Min(math.Abs(h)*fac, st.MaxStep)
+		rejected = false
+	}
+	return sol, nil
+}
+
+// rosenbrockDense is the continuous extension of a Rosenbrock step from
+// t0 with the step h,
+//	y(t0 + s h) = y + h (s (1 - s) k1 + s (s - 2 d) k2) / (1 - 2 d)
+type rosenbrockDense struct {
+	t0, h, c1 float64
+	y, k1, k2 []float64
+}
+
+// eval returns the extension at t
+func (r *rosenbrockDense) eval(t float64, y []float64) []float64 {
+	if y == nil {
+		y = make([]float64, len(r.y))
+	}
+	s := (t - r.t0) / r.h
+	a := r.h * s * (1.0 - s) / (1.0 - 2.0*r.c1)
+	b := r.h * s * (s - 2.0*r.c1) / (1.0 - 2.0*r.c1)
+	for i := range y {
+		y[i] = r.y[i] + a*r.k1[i] + b*r.k2[i]
+	}
+	return y
+}
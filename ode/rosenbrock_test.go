@@ -0,0 +1,79 @@
+package ode
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRosenbrock(t *testing.T) {
+	tests := []struct {
+		name string
+		f    Func
+		t1   float64
+		y0   []float64
+		s    *Settings
+		want []float64
+		tol  float64
+	}{
+		{"Robertson", robertson, 40, []float64{1, 0, 0}, &Settings{RelTol: 1e-5, AbsTol: 1e-10},
+			[]float64{0.7158270687193e+00, 0.9185534764529e-05, 0.2841637457654e+00}, 1e-3},
+		{"Robertson, analytic Jacobian", robertson, 40, []float64{1, 0, 0}, &Settings{RelTol: 1e-5, AbsTol: 1e-10, Jacobian: robertsonJac},
+			[]float64{0.7158270687193e+00, 0.9185534764529e-05, 0.2841637457654e+00}, 1e-3},
+		{"oscillator", oscillator, 5, []float64{1, 0}, &Settings{RelTol: 1e-7, AbsTol: 1e-9},
+			[]float64{math.Cos(5), -math.Sin(5)}, 1e-4},
+		{"backwards", oscillator, -2, []float64{1, 0}, &Settings{RelTol: 1e-7, AbsTol: 1e-9},
+			[]float64{math.Cos(2), math.Sin(2)}, 1e-4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sol, err := Rosenbrock(tt.f, 0, tt.t1, tt.y0, tt.s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			y := sol.Y[len(sol.Y)-1]
+			for i := range tt.want {
+				if math.Abs(y[i]-tt.want[i]) > tt.tol*math.Max(math.Abs(tt.want[i]), 1e-2) {
+					t.Errorf("y(%v)[%d] = %v, want %v", tt.t1, i, y[i], tt.want[i])
+				}
+			}
+			st := sol.Stats
+			// a Jacobian and a factorization per step, three solves
+			if st.JacEvals != st.Accepted || st.LUDecomps != st.Accepted+st.Rejected ||
+				st.LinSolves != 3*st.LUDecomps || st.JacReuses != st.Rejected || st.NewtonIters != 0 {
+				t.Errorf("Stats = %v", st)
+			}
+		})
+	}
+}
+
+func TestRosenbrockDense(t *testing.T) {
+	sol, err := Rosenbrock(oscillator, 0, 3, []float64{1, 0}, &Settings{RelTol: 1e-8, AbsTol: 1e-10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, x := range []float64{0.05, 1.234, 2.9} {
+		y, err := sol.At(x)
+		if err != nil || math.Abs(y[0]-math.Cos(x)) > 1e-5 || math.Abs(y[1]+math.Sin(x)) > 1e-5 {
+			t.Errorf("At(%v) = %v, %v, want [%v %v]", x, y, err, math.Cos(x), -math.Sin(x))
+		}
+	}
+}
+
+func TestRosenbrockStiff(t *testing.T) {
+	s := &Settings{RelTol: 1e-4, AbsTol: 1e-7}
+	sol, err := Rosenbrock(stiffLinear, 0, 10, []float64{0, 0}, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ref, err := RKF45(stiffLinear, 0, 10, []float64{0, 0}, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	y, yr := sol.Y[len(sol.Y)-1], ref.Y[len(ref.Y)-1]
+	if math.Abs(y[0]-yr[0]) > 1e-3 || math.Abs(y[1]-yr[1]) > 1e-3 {
+		t.Errorf("y(10) = %v, want %v", y, yr)
+	}
+	if sol.Stats.Accepted*5 > ref.Stats.Accepted {
+		t.Errorf("Rosenbrock steps %d, RKF45 steps %d", sol.Stats.Accepted, ref.Stats.Accepted)
+	}
+}
@@ -0,0 +1,222 @@
+package solveeqs
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/shyang107/gnum"
+)
+
+// Band is an n x n band matrix with kl sub-diagonals and ku
+// super-diagonals; element (i,j) with -kl <= j-i <= ku is stored at
+// data[i*(kl+ku+1)+j-i+kl]
+type Band struct {
+	n, kl, ku int
+	data      []float64
+}
+
+// NewBand returns a zero n x n band matrix with kl sub-diagonals and ku
+// super-diagonals
+func NewBand(n, kl, ku int) *Band {
+	if n < 0 || kl < 0 || ku < 0 {
+		panic(fmt.Sprintf("solveeqs: bad band shape n = %d, kl = %d, ku = %d", n, kl, ku))
+	}
+	return &Band{n: n, kl: kl, ku: ku, data: make([]float64, n*(kl+ku+1))}
+}
+
+// Dims returns the number of rows and columns
+func (m *Band) Dims() (r, c int) {
+	return m.n, m.n
+}
+
+// Bandwidth returns the number of sub- and super-diagonals
+func (m *Band) Bandwidth() (kl, ku int) {
+	return m.kl, m.ku
+}
+
+// At returns element (i,j)
+func (m *Band) At(i, j int) float64 {
+	if i < 0 || i >= m.n || j < 0 || j >= m.n {
+		panic(fmt.Sprintf("solveeqs: index (%d,%d) out of range %d x %d", i, j, m.n, m.n))
+	}
+	if j-i < -m.kl || j-i > m.ku {
+		return 0.0
+	}
+	return m.data[i*(m.kl+m.ku+1)+j-i+m.kl]
+}
+
+// Set sets element (i,j), which must lie inside the band, to v
+func (m *Band) Set(i, j int, v float64) {
+	if i < 0 || i >= m.n || j < 0 || j >= m.n || j-i < -m.kl || j-i > m.ku {
+		panic(fmt.Sprintf("solveeqs: index (%d,%d) outside the band", i, j))
+	}
+	m.data[i*(m.kl+m.ku+1)+j-i+m.kl] = v
+}
+
+// MulVec sets dst = A x
+func (m *Band) MulVec(dst, x []float64) {
+	w := m.kl + m.ku + 1
+	for i := 0; i < m.n; i++ {
+		s := 0.0
+		for j := imax(0, i-m.kl); j <= imin(m.n-1, i+m.ku); j++ {
+			s += m.data[i*w+j-i+m.kl] * x[j]
+		}
+		dst[i] = s
+	}
+}
+
+// Dense returns A as a dense matrix
+func (m *Band) Dense() *gnum.Matrix {
+	a := gnum.NewMatrix(m.n, m.n, nil)
+	for i := 0; i < m.n; i++ {
+		for j := imax(0, i-m.kl); j <= imin(m.n-1, i+m.ku); j++ {
+			a.Set(i, j, m.data[i*(m.kl+m.ku+1)+j-i+m.kl])
+		}
+	}
+	return a
+}
+
+// BandLU is the LU factorization with partial pivoting of a band matrix.
+// The row interchanges widen U to kl+ku super-diagonals; the storage is
+// reused when the same BandLU factorizes another matrix, so repeated
+// factorizations of one size do not allocate.
+type BandLU struct {
+	n, kl, ku int
+	lu        []float64 // row i holds columns i-kl..i+kl+ku, L below the diagonal
+	piv       []int     // row k was interchanged with row piv[k]
+	sign      float64
+}
+
+// at returns the address of element (i,j) of the factors
+func (f *BandLU) at(i, j int) *float64 {
+	return &f.lu[i*(2*f.kl+f.ku+1)+j-i+f.kl]
+}
+
+// Factorize computes the LU factorization of a; a is not modified
+func (f *BandLU) Factorize(a *Band) error {
+	n, kl, ku := a.n, a.kl, a.ku
+	f.n, f.kl, f.ku = n, kl, ku
+	f.lu = resize(f.lu, n*(2*kl+ku+1))
+	for i := range f.lu {
+		f.lu[i] = 0.0
+	}
+	if cap(f.piv) < n {
+		f.piv = make([]int, n)
+	}
+	f.piv = f.piv[:n]
+	f.sign = 1.0
+	for i := 0; i < n; i++ {
+		for j := imax(0, i-kl); j <= imin(n-1, i+ku); j++ {
+			*f.at(i, j) = a.data[i*(kl+ku+1)+j-i+kl]
+		}
+	}
+	//-----------------------------------------------------
+	// Gaussian elimination; the interchanges only move the
+	// columns >= k, so the multipliers stay in LINPACK order
+	//-----------------------------------------------------
+	var err error
+	for k := 0; k < n; k++ {
+		last := imin(n-1, k+kl)
+		right := imin(n-1, k+kl+ku)
+		p := k
+		for i := k + 1; i <= last; i++ {
+			if math.Abs(*f.at(i, k)) > math.Abs(*f.at(p, k)) {
+				p = i
+			}
+		}
+		f.piv[k] = p
+		if p != k {
+			for j := k; j <= right; j++ {
+				*f.at(k, j), *f.at(p, j) = *f.at(p, j), *f.at(k, j)
+			}
+			f.sign = -f.sign
+		}
+		ukk := *f.at(k, k)
+		if ukk == 0.0 {
+			if err == nil {
+				err = fmt.Errorf("Singular matrix: zero pivot in column %d", k)
+			}
+			continue
+		}
+		for i := k + 1; i <= last; i++ {
+			m := *f.at(i, k) / ukk
+			*f.at(i, k) = m
+			if m == 0.0 {
+				continue
+			}
+			for j := k + 1; j <= right; j++ {
+				*f.at(i, j) -= m * *f.at(k, j)
+			}
+		}
+	}
+	return err
+}
+
+// Solve solves A x = b
+func (f *BandLU) Solve(b []float64) (x []float64, err error) {
+	x = append([]float64(nil), b...)
+	if err := f.SolveInPlace(x); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// SolveInPlace overwrites b by the solution of A x = b
+func (f *BandLU) SolveInPlace(b []float64) error {
+	n, kl := f.n, f.kl
+	if len(b) != n {
+		return fmt.Errorf("len(b) = %d, want %d", len(b), n)
+	}
+	for k := 0; k < n; k++ {
+		if *f.at(k, k) == 0.0 {
+			return fmt.Errorf("Singular matrix: zero pivot in column %d", k)
+		}
+	}
+	for k := 0; k < n; k++ {
+		if p := f.piv[k]; p != k {
+			b[k], b[p] = b[p], b[k]
+		}
+		for i := k + 1; i <= imin(n-1, k+kl); i++ {
+			b[i] -= *f.at(i, k) * b[k]
+		}
+	}
+	for i := n - 1; i >= 0; i-- {
+		s := b[i]
+		for j := i + 1; j <= imin(n-1, i+kl+f.ku); j++ {
+			s -= *f.at(i, j) * b[j]
+		}
+		b[i] = s / *f.at(i, i)
+	}
+	return nil
+}
+
+// Det returns the determinant of A
+func (f *BandLU) Det() float64 {
+	d := f.sign
+	for i := 0; i < f.n; i++ {
+		d *= *f.at(i, i)
+	}
+	return d
+}
+
+// resize returns s with length n, reusing its storage if possible
+func resize(s []float64, n int) []float64 {
+	if cap(s) < n {
+		return make([]float64, n)
+	}
+	return s[:n]
+}
+
+func imin(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func imax(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,126 @@
+package solveeqs
+
+import (
+	"math"
+	"testing"
+)
+
+// randomBand returns an n x n band matrix with pseudo-random entries,
+// which is not diagonally dominant so that pivoting is needed
+func randomBand(n, kl, ku int) *Band {
+	a := NewBand(n, kl, ku)
+	seed := 1.0
+	for i := 0; i < n; i++ {
+		for j := imax(0, i-kl); j <= imin(n-1, i+ku); j++ {
+			seed = math.Mod(seed*16807.0, 2147483647.0)
+			a.Set(i, j, seed/2147483647.0-0.5)
+		}
+	}
+	return a
+}
+
+func TestBandLU(t *testing.T) {
+	tests := []struct {
+		name    string
+		n       int
+		kl, ku  int
+		wantErr bool
+	}{
+		{"diagonal", 5, 0, 0, false},
+		{"tridiagonal", 8, 1, 1, false},
+		{"lower", 10, 3, 0, false},
+		{"upper", 10, 0, 2, false},
+		{"wide", 20, 3, 4, false},
+		{"full", 6, 5, 5, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := randomBand(tt.n, tt.kl, tt.ku)
+			x := make([]float64, tt.n)
+			for i := range x {
+				x[i] = float64(i+1) / float64(tt.n)
+			}
+			b := make([]float64, tt.n)
+			a.MulVec(b, x)
+			var f BandLU
+			err := f.Factorize(a)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("BandLU.Factorize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			got, err := f.Solve(b)
+			if err != nil {
+				t.Fatalf("BandLU.Solve() error = %v", err)
+			}
+			for i := range got {
+				if math.Abs(got[i]-x[i]) > 1e-10 {
+					t.Errorf("BandLU.Solve() x[%d] = %v, want %v", i, got[i], x[i])
+				}
+			}
+			//-----------------------------------------------------
+			// compare the determinant with the dense LU
+			//-----------------------------------------------------
+			var lu LU
+			if err := lu.Factorize(a.Dense()); err != nil {
+				t.Fatal(err)
+			}
+			if want := lu.Det(); math.Abs(f.Det()-want) > 1e-12*math.Max(1.0, math.Abs(want)) {
+				t.Errorf("BandLU.Det() = %v, want %v", f.Det(), want)
+			}
+		})
+	}
+}
+
+func TestBandLUSingular(t *testing.T) {
+	a := NewBand(3, 1, 1)
+	a.Set(0, 0, 1.0)
+	a.Set(0, 1, 2.0)
+	a.Set(1, 0, 2.0)
+	a.Set(1, 1, 4.0)
+	a.Set(2, 2, 1.0)
+	var f BandLU
+	if err := f.Factorize(a); err == nil {
+		t.Errorf("BandLU.Factorize() of a singular matrix: want an error")
+	}
+	if _, err := f.Solve([]float64{1, 2, 3}); err == nil {
+		t.Errorf("BandLU.Solve() of a singular matrix: want an error")
+	}
+	if d := f.Det(); d != 0.0 {
+		t.Errorf("BandLU.Det() = %v, want 0", d)
+	}
+}
+
+func TestBandLUInPlace(t *testing.T) {
+	n := 40
+	a := randomBand(n, 2, 3)
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = math.Cos(float64(i))
+	}
+	b := make([]float64, n)
+	a.MulVec(b, x)
+	var f BandLU
+	if err := f.Factorize(a); err != nil {
+		t.Fatal(err)
+	}
+	rhs := make([]float64, n)
+	if allocs := testing.AllocsPerRun(5, func() {
+		if err := f.Factorize(a); err != nil {
+			t.Fatal(err)
+		}
+		copy(rhs, b)
+		if err := f.SolveInPlace(rhs); err != nil {
+			t.Fatal(err)
+		}
+	}); allocs != 0 {
+		t.Errorf("BandLU refactorize and SolveInPlace allocate %v times, want 0", allocs)
+	}
+	for i := range x {
+		if math.Abs(rhs[i]-x[i]) > 1e-10 {
+			t.Errorf("BandLU.SolveInPlace() x[%d] = %v, want %v", i, rhs[i], x[i])
+		}
+	}
+	//-----------------------------------------------------
+	// Band is an Operator for the iterative solvers
+	//-----------------------------------------------------
+	var _ Operator = a
+}
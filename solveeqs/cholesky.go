@@ -0,0 +1,147 @@
+package solveeqs
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/shyang107/gnum"
+)
+
+// Cholesky is the factorization A = L L^T of a symmetric positive
+// definite matrix A, with L lower triangular
+type Cholesky struct {
+	l     *gnum.Matrix
+	anorm float64 // the 1-norm of A
+}
+
+// Factorize computes the Cholesky factorization of the symmetric
+// positive definite matrix a; only the lower triangle of a is read and
+// a is not modified
+func (f *Cholesky) Factorize(a *gnum.Matrix) error {
+	n, c := a.Dims()
+	if n != c {
+		return fmt.Errorf("Cholesky requires a square matrix, got %d x %d", n, c)
+	}
+	f.l = nil
+	l := gnum.NewMatrix(n, n, nil)
+	for j := 0; j < n; j++ {
+		rj := l.Row(j)
+		d := a.At(j, j)
+		for k := 0; k < j; k++ {
+			d -= rj[k] * rj[k]
+		}
+		if d <= 0.0 || math.IsNaN(d) {
+			return fmt.Errorf("The matrix is not positive definite, leading minor %d", j+1)
+		}
+		rj[j] = math.Sqrt(d)
+		for i := j + 1; i < n; i++ {
+			ri := l.Row(i)
+			s := a.At(i, j)
+			for k := 0; k < j; k++ {
+				s -= ri[k] * rj[k]
+			}
+			ri[j] = s / rj[j]
+		}
+	}
+	//-----------------------------------------------------
+	// the 1-norm of the symmetric A from its lower triangle
+	//-----------------------------------------------------
+	f.anorm = 0.0
+	for j := 0; j < n; j++ {
+		s := 0.0
+		for i := 0; i < n; i++ {
+			if i >= j {
+				s += math.Abs(a.At(i, j))
+			} else {
+				s += math.Abs(a.At(j, i))
+			}
+		}
+		f.anorm = math.Max(f.anorm, s)
+	}
+	f.l = l
+	return nil
+}
+
+// L returns the lower triangular factor, or nil if A is not factorized
+func (f *Cholesky) L() *gnum.Matrix {
+	if f.l == nil {
+		return nil
+	}
+	return f.l.Clone()
+}
+
+// Solve solves A x = b
+func (f *Cholesky) Solve(b []float64) (x []float64, err error) {
+	if f.l == nil {
+		return nil, fmt.Errorf("Cholesky: the matrix is not factorized")
+	}
+	n, _ := f.l.Dims()
+	if len(b) != n {
+		return nil, fmt.Errorf("len(b) = %d, want %d", len(b), n)
+	}
+	x = append([]float64(nil), b...)
+	f.solve(x)
+	return x, nil
+}
+
+// solve overwrites x by the solution of L L^T x = x
+func (f *Cholesky) solve(x []float64) {
+	n := len(x)
+	for i := 0; i < n; i++ {
+		r := f.l.Row(i)
+		for j := 0; j < i; j++ {
+			x[i] -= r[j] * x[j]
+		}
+		x[i] /= r[i]
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := i + 1; j < n; j++ {
+			x[i] -= f.l.At(j, i) * x[j]
+		}
+		x[i] /= f.l.At(i, i)
+	}
+}
+
+// Det returns the determinant of A; it is NaN if A is not factorized
+func (f *Cholesky) Det() float64 {
+	if f.l == nil {
+		return math.NaN()
+	}
+	n, _ := f.l.Dims()
+	d := 1.0
+	for i := 0; i < n; i++ {
+		d *= f.l.At(i, i)
+	}
+	return d * d
+}
+
+// Inverse returns the inverse of A
+func (f *Cholesky) Inverse() (*gnum.Matrix, error) {
+	if f.l == nil {
+		return nil, fmt.Errorf("Cholesky: the matrix is not factorized")
+	}
+	n, _ := f.l.Dims()
+	inv := gnum.NewMatrix(n, n, nil)
+	x := make([]float64, n)
+	for j := 0; j < n; j++ {
+		for i := range x {
+			x[i] = 0.0
+		}
+		x[j] = 1.0
+		f.solve(x)
+		for i := range x {
+			inv.Set(i, j, x[i])
+		}
+	}
+	return inv, nil
+}
+
+// Cond returns an estimate of the 1-norm condition number
+// ||A||_1 ||A^-1||_1
+func (f *Cholesky) Cond() float64 {
+	if f.l == nil {
+		return math.Inf(1)
+	}
+	n, _ := f.l.Dims()
+	return f.anorm * normInv1(n, f.solve, f.solve)
+}
@@ -0,0 +1,102 @@
+package solveeqs
+
+import (
+	"math"
+	"testing"
+
+	"github.com/shyang107/gnum"
+)
+
+func TestCholesky(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        *gnum.Matrix
+		x        []float64
+		wantDet  float64
+		wantCond float64
+		wantErr  bool
+	}{
+		{
+			"Case 1 : 3 x 3 SPD",
+			gnum.NewMatrix(3, 3, []float64{4, 12, -16, 12, 37, -43, -16, -43, 98}),
+			[]float64{1, 2, 3},
+			36.0,
+			0.0,
+			false,
+		},
+		{
+			"Case 2 : Hilbert 4 x 4",
+			hilbert(4),
+			[]float64{1, -1, 1, -1},
+			1.0 / 6048000.0,
+			28375.0,
+			false,
+		},
+		{
+			"Case 3 : indefinite",
+			gnum.NewMatrix(2, 2, []float64{1, 2, 2, 1}),
+			nil,
+			0.0,
+			0.0,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ch Cholesky
+			err := ch.Factorize(tt.a)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Cholesky.Factorize() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				// the failed factorization is not used
+				if got := ch.Det(); !math.IsNaN(got) {
+					t.Errorf("Cholesky.Det() = %v, want NaN", got)
+				}
+				return
+			}
+			if got := ch.Det(); math.Abs(got-tt.wantDet) > 1.0e-12*math.Max(1.0, math.Abs(tt.wantDet)) {
+				t.Errorf("Cholesky.Det() = %v, want %v", got, tt.wantDet)
+			}
+			if tt.wantCond != 0.0 {
+				if got := ch.Cond(); math.Abs(got-tt.wantCond) > 1.0e-6*tt.wantCond {
+					t.Errorf("Cholesky.Cond() = %v, want %v", got, tt.wantCond)
+				}
+			}
+			gotX, err := ch.Solve(mulVec(tt.a, tt.x))
+			if err != nil {
+				t.Errorf("Cholesky.Solve() error = %v", err)
+				return
+			}
+			for i := range gotX {
+				if math.Abs(gotX[i]-tt.x[i]) > 1.0e-10 {
+					t.Errorf("Cholesky.Solve() x[%d] = %v, want %v", i, gotX[i], tt.x[i])
+				}
+			}
+			inv, err := ch.Inverse()
+			if err != nil || !isIdentity(tt.a, inv, 1.0e-10) {
+				t.Errorf("Cholesky.Inverse() = %v, error = %v", inv, err)
+			}
+		})
+	}
+}
+
+func TestCholeskyNotFactorized(t *testing.T) {
+	var ch Cholesky
+	if got := ch.Det(); !math.IsNaN(got) {
+		t.Errorf("Cholesky.Det() = %v, want NaN", got)
+	}
+	if l := ch.L(); l != nil {
+		t.Errorf("Cholesky.L() = %v, want nil", l)
+	}
+	if x, err := ch.Solve([]float64{1}); err == nil {
+		t.Errorf("Cholesky.Solve() = %v, want an error", x)
+	}
+	if inv, err := ch.Inverse(); err == nil {
+		t.Errorf("Cholesky.Inverse() = %v, want an error", inv)
+	}
+	if got := ch.Cond(); !math.IsInf(got, 1) {
+		t.Errorf("Cholesky.Cond() = %v, want +Inf", got)
+	}
+}
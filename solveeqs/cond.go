@@ -0,0 +1,73 @@
+package solveeqs
+
+import "math"
+
+// normInv1 estimates ||A^-1||_1 by Hager's method with Higham's
+// refinement, using only solves with A and A^T
+//
+// solve	: overwrites x by A^-1 x
+// solveT	: overwrites x by A^-T x
+func normInv1(n int, solve, solveT func(x []float64)) float64 {
+	if n == 0 {
+		return 0.0
+	}
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = 1.0 / float64(n)
+	}
+	est := 0.0
+	jold := -1
+	for iter := 0; iter < 5; iter++ {
+		y := append([]float64(nil), x...)
+		solve(y)
+		est = 0.0
+		for i := range y {
+			est += math.Abs(y[i])
+			x[i] = 1.0
+			if y[i] < 0.0 {
+				x[i] = -1.0
+			}
+		}
+		solveT(x)
+		//-----------------------------------------------------
+		// stop if the gradient gives no better unit vector
+		//-----------------------------------------------------
+		j, zmax := 0, 0.0
+		for i := range x {
+			if math.Abs(x[i]) > zmax {
+				j, zmax = i, math.Abs(x[i])
+			}
+		}
+		ztx := 0.0
+		if jold >= 0 {
+			ztx = x[jold]
+		} else {
+			for i := range x {
+				ztx += x[i] / float64(n)
+			}
+		}
+		if zmax <= ztx || j == jold {
+			break
+		}
+		for i := range x {
+			x[i] = 0.0
+		}
+		x[j] = 1.0
+		jold = j
+	}
+	//-----------------------------------------------------
+	// Higham's alternative estimate on an alternating vector
+	//-----------------------------------------------------
+	for i := range x {
+		x[i] = 1.0 + float64(i)/math.Max(float64(n-1), 1.0)
+		if i%2 == 1 {
+			x[i] = -x[i]
+		}
+	}
+	solve(x)
+	alt := 0.0
+	for i := range x {
+		alt += math.Abs(x[i])
+	}
+	return math.Max(est, 2.0*alt/(3.0*float64(n)))
+}
@@ -0,0 +1,343 @@
+package eigen
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"sort"
+
+	"github.com/shyang107/gnum"
+)
+
+// maxHQRIter is the maximum number of QR iterations for one eigenvalue
+const maxHQRIter = 100
+
+// General computes the eigenvalues of the general real square matrix a
+// by balancing, reduction to upper Hessenberg form and the Francis
+// double shift QR algorithm; a is not modified. Complex eigenvalues come
+// in conjugate pairs; the result is sorted by real part and then by
+// imaginary part.
+func General(a *gnum.Matrix) ([]complex128, error) {
+	n, c := a.Dims()
+	if n != c {
+		return nil, fmt.Errorf("General requires a square matrix, got %d x %d", n, c)
+	}
+	h := a.Clone()
+	balance(rows(h))
+	hessenberg(rows(h), nil)
+	w, err := hqr(rows(h))
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(w, func(i, j int) bool {
+		if real(w[i]) != real(w[j]) {
+			return real(w[i]) < real(w[j])
+		}
+		return imag(w[i]) < imag(w[j])
+	})
+	return w, nil
+}
+
+// Hessenberg reduces the square matrix a to the upper Hessenberg matrix
+// H = Q^T A Q by Householder reflections; a is not modified
+//
+// output
+//	h	: the upper Hessenberg matrix
+//	q	: the orthogonal matrix Q
+func Hessenberg(a *gnum.Matrix) (h, q *gnum.Matrix, err error) {
+	n, c := a.Dims()
+	if n != c {
+		return nil, nil, fmt.Errorf("Hessenberg requires a square matrix, got %d x %d", n, c)
+	}
+	h = a.Clone()
+	q = gnum.Identity(n)
+	hessenberg(rows(h), rows(q))
+	return h, q, nil
+}
+
+// hessenberg reduces h in place to upper Hessenberg form, accumulating
+// the reflections into q if it is not nil
+func hessenberg(h, q [][]float64) {
+	n := len(h)
+	v := make([]float64, n)
+	for k := 0; k < n-2; k++ {
+		xnorm := 0.0
+		for i := k + 1; i < n; i++ {
+			xnorm = math.Hypot(xnorm, h[i][k])
+		}
+		if xnorm == 0.0 {
+			continue
+		}
+		alpha := -math.Copysign(xnorm, h[k+1][k])
+		for i := k + 1; i < n; i++ {
+			v[i] = h[i][k]
+		}
+		v[k+1] -= alpha
+		vv := 0.0
+		for i := k + 1; i < n; i++ {
+			vv += v[i] * v[i]
+		}
+		if vv == 0.0 {
+			continue
+		}
+		//-----------------------------------------------------
+		// H = P H P with P = I - 2 v v^T / vv
+		//-----------------------------------------------------
+		for j := k; j < n; j++ {
+			s := 0.0
+			for i := k + 1; i < n; i++ {
+				s += v[i] * h[i][j]
+			}
+			s *= 2.0 / vv
+			for i := k + 1; i < n; i++ {
+				h[i][j] -= s * v[i]
+			}
+		}
+		for i := 0; i < n; i++ {
+			reflectRow(h[i], v, k+1, vv)
+			if q != nil {
+				reflectRow(q[i], v, k+1, vv)
+			}
+		}
+		h[k+1][k] = alpha
+		for i := k + 2; i < n; i++ {
+			h[i][k] = 0.0
+		}
+	}
+}
+
+// reflectRow sets x[k:] = x[k:] (I - 2 v v^T / vv)
+func reflectRow(x, v []float64, k int, vv float64) {
+	s := 0.0
+	for j := k; j < len(x); j++ {
+		s += x[j] * v[j]
+	}
+	s *= 2.0 / vv
+	for j := k; j < len(x); j++ {
+		x[j] -= s * v[j]
+	}
+}
+
+// balance scales the rows and columns of a in place by powers of 2 so
+// that their norms are about equal; the eigenvalues are unchanged and
+// their sensitivity to rounding is reduced
+func balance(a [][]float64) {
+	const radix = 2.0
+	n := len(a)
+	for done := false; !done; {
+		done = true
+		for i := 0; i < n; i++ {
+			r, c := 0.0, 0.0
+			for j := 0; j < n; j++ {
+				if j != i {
+					c += math.Abs(a[j][i])
+					r += math.Abs(a[i][j])
+				}
+			}
+			if c == 0.0 || r == 0.0 {
+				continue
+			}
+			g := r / radix
+			f := 1.0
+			s := c + r
+			for c < g {
+				f *= radix
+				c *= radix * radix
+			}
+			g = r * radix
+			for c > g {
+				f /= radix
+				c /= radix * radix
+			}
+			if (c+r)/f < 0.95*s {
+				done = false
+				g = 1.0 / f
+				for j := 0; j < n; j++ {
+					a[i][j] *= g
+				}
+				for j := 0; j < n; j++ {
+					a[j][i] *= f
+				}
+			}
+		}
+	}
+}
+
+// hqr computes the eigenvalues of the upper Hessenberg matrix a by the
+// Francis double shift QR algorithm; a is destroyed
+func hqr(a [][]float64) ([]complex128, error) {
+	n := len(a)
+	w := make([]complex128, n)
+	const eps = 2.220446049250313e-16
+	anorm := 0.0
+	for i := 0; i < n; i++ {
+		for j := imax(i-1, 0); j < n; j++ {
+			anorm += math.Abs(a[i][j])
+		}
+	}
+	var p, q, r, s, t, u, v, x, y, z float64
+	nn := n - 1
+	for nn >= 0 {
+		its := 0
+		for {
+			//-----------------------------------------------------
+			// look for a single small subdiagonal element
+			//-----------------------------------------------------
+			l := nn
+			for ; l > 0; l-- {
+				s = math.Abs(a[l-1][l-1]) + math.Abs(a[l][l])
+				if s == 0.0 {
+					s = anorm
+				}
+				if math.Abs(a[l][l-1]) <= eps*s {
+					a[l][l-1] = 0.0
+					break
+				}
+			}
+			x = a[nn][nn]
+			if l == nn {
+				w[nn] = complex(x+t, 0.0)
+				nn--
+				break
+			}
+			y = a[nn-1][nn-1]
+			v = a[nn][nn-1] * a[nn-1][nn]
+			if l == nn-1 {
+				//-----------------------------------------------------
+				// a 2 x 2 block: two real roots or a complex pair
+				//-----------------------------------------------------
+				p = 0.5 * (y - x)
+				q = p*p + v
+				z = math.Sqrt(math.Abs(q))
+				x += t
+				if q >= 0.0 {
+					z = p + math.Copysign(z, p)
+					w[nn-1] = complex(x+z, 0.0)
+					w[nn] = w[nn-1]
+					if z != 0.0 {
+						w[nn] = complex(x-v/z, 0.0)
+					}
+				} else {
+					w[nn] = complex(x+p, -z)
+					w[nn-1] = cmplx.Conj(w[nn])
+				}
+				nn -= 2
+				break
+			}
+			if its == maxHQRIter {
+				return nil, fmt.Errorf("Not convergence in %4d QR iterations for eigenvalue %d", maxHQRIter, nn)
+			}
+			if its > 0 && its%10 == 0 {
+				//-----------------------------------------------------
+				// exceptional shift every 10 iterations
+				//-----------------------------------------------------
+				t += x
+				for i := 0; i <= nn; i++ {
+					a[i][i] -= x
+				}
+				s = math.Abs(a[nn][nn-1]) + math.Abs(a[nn-1][nn-2])
+				x = 0.75 * s
+				y = x
+				v = -0.4375 * s * s
+			}
+			its++
+			//-----------------------------------------------------
+			// look for two consecutive small subdiagonal elements
+			//-----------------------------------------------------
+			m := nn - 2
+			for ; m >= l; m-- {
+				z = a[m][m]
+				r = x - z
+				s = y - z
+				p = (r*s-v)/a[m+1][m] + a[m][m+1]
+				q = a[m+1][m+1] - z - r - s
+				r = a[m+2][m+1]
+				s = math.Abs(p) + math.Abs(q) + math.Abs(r)
+				p /= s
+				q /= s
+				r /= s
+				if m == l {
+					break
+				}
+				u = math.Abs(a[m][m-1]) * (math.Abs(q) + math.Abs(r))
+				if u <= eps*math.Abs(p)*(math.Abs(a[m-1][m-1])+math.Abs(z)+math.Abs(a[m+1][m+1])) {
+					break
+				}
+			}
+			for i := m; i < nn-1; i++ {
+				a[i+2][i] = 0.0
+				if i != m {
+					a[i+2][i-1] = 0.0
+				}
+			}
+			//-----------------------------------------------------
+			// double QR step on rows l..nn and columns m..nn
+			//-----------------------------------------------------
+			for k := m; k < nn; k++ {
+				if k != m {
+					p = a[k][k-1]
+					q = a[k+1][k-1]
+					r = 0.0
+					if k+1 != nn {
+						r = a[k+2][k-1]
+					}
+					if x = math.Abs(p) + math.Abs(q) + math.Abs(r); x != 0.0 {
+						p /= x
+						q /= x
+						r /= x
+					}
+				}
+				if s = math.Copysign(math.Sqrt(p*p+q*q+r*r), p); s == 0.0 {
+					continue
+				}
+				if k == m {
+					if l != m {
+						a[k][k-1] = -a[k][k-1]
+					}
+				} else {
+					a[k][k-1] = -s * x
+				}
+				p += s
+				x = p / s
+				y = q / s
+				z = r / s
+				q /= p
+				r /= p
+				for j := k; j <= nn; j++ {
+					p = a[k][j] + q*a[k+1][j]
+					if k+1 != nn {
+						p += r * a[k+2][j]
+						a[k+2][j] -= p * z
+					}
+					a[k+1][j] -= p * y
+					a[k][j] -= p * x
+				}
+				mmin := imin(nn, k+3)
+				for i := l; i <= mmin; i++ {
+					p = x*a[i][k] + y*a[i][k+1]
+					if k+1 != nn {
+						p += z * a[i][k+2]
+						a[i][k+2] -= p * r
+					}
+					a[i][k+1] -= p * q
+					a[i][k] -= p
+				}
+			}
+		}
+	}
+	return w, nil
+}
+
+func imin(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func imax(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,126 @@
+package eigen
+
+import (
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+
+	"github.com/shyang107/gnum"
+)
+
+func TestGeneral(t *testing.T) {
+	tests := []struct {
+		name    string
+		a       *gnum.Matrix
+		want    []complex128
+		wantErr bool
+	}{
+		{"rotation", gnum.NewMatrix(2, 2, []float64{0, -1, 1, 0}), []complex128{-1i, 1i}, false},
+		{"triangular", gnum.NewMatrix(3, 3, []float64{1, 2, 3, 0, 4, 5, 0, 0, 6}), []complex128{1, 4, 6}, false},
+		{"companion", gnum.NewMatrix(4, 4, []float64{
+			// (x-1)(x-2)(x^2+1) = x^4 - 3x^3 + 3x^2 - 3x + 2
+			3, -3, 3, -2,
+			1, 0, 0, 0,
+			0, 1, 0, 0,
+			0, 0, 1, 0}), []complex128{-1i, 1i, 1, 2}, false},
+		{"badly scaled", gnum.NewMatrix(3, 3, []float64{
+			1, 1e6, 0,
+			1e-6, 2, 1e6,
+			0, 1e-6, 3}), []complex128{complex(2-math.Sqrt(3), 0), 2, complex(2+math.Sqrt(3), 0)}, false},
+		{"1x1", gnum.NewMatrix(1, 1, []float64{-7}), []complex128{-7}, false},
+		{"not square", gnum.NewMatrix(2, 3, nil), nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := General(tt.a)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("General() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			for i := range tt.want {
+				if cmplx.Abs(got[i]-tt.want[i]) > 1e-12 {
+					t.Errorf("General() w[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+// TestGeneralSlowConvergence is a matrix on which the QR iteration needs
+// more than two exceptional shifts for one eigenvalue; it is checked by the
+// invariants trace(A) and trace(A^2)
+func TestGeneralSlowConvergence(t *testing.T) {
+	n := 45
+	r := rand.New(rand.NewSource(208))
+	a := gnum.NewMatrix(n, n, nil)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			a.Set(i, j, r.NormFloat64())
+		}
+	}
+	w, err := General(a)
+	if err != nil {
+		t.Fatalf("General() error = %v", err)
+	}
+	a2 := gnum.NewMatrix(n, n, nil)
+	a2.Mul(a, a)
+	tr, tr2 := 0.0, 0.0
+	for i := 0; i < n; i++ {
+		tr += a.At(i, i)
+		tr2 += a2.At(i, i)
+	}
+	var sum, sum2 complex128
+	for _, x := range w {
+		sum += x
+		sum2 += x * x
+	}
+	if cmplx.Abs(sum-complex(tr, 0)) > 1e-10 {
+		t.Errorf("General() sum of the eigenvalues = %v, want %v", sum, tr)
+	}
+	if cmplx.Abs(sum2-complex(tr2, 0)) > 1e-9 {
+		t.Errorf("General() sum of the squares = %v, want %v", sum2, tr2)
+	}
+}
+
+func TestHessenberg(t *testing.T) {
+	n := 6
+	a := gnum.NewMatrix(n, n, nil)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			a.Set(i, j, math.Sin(float64(i*n+j+1)))
+		}
+	}
+	h, q, err := Hessenberg(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 2; i < n; i++ {
+		for j := 0; j < i-1; j++ {
+			if h.At(i, j) != 0.0 {
+				t.Errorf("Hessenberg() h[%d][%d] = %v, want 0", i, j, h.At(i, j))
+			}
+		}
+	}
+	//-----------------------------------------------------
+	// Q H Q^T = A and Q^T Q = I
+	//-----------------------------------------------------
+	qh := gnum.NewMatrix(n, n, nil)
+	qh.Mul(q, h)
+	back := gnum.NewMatrix(n, n, nil)
+	back.Mul(qh, q.Transpose())
+	qtq := gnum.NewMatrix(n, n, nil)
+	qtq.Mul(q.Transpose(), q)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if math.Abs(back.At(i, j)-a.At(i, j)) > 1e-13 {
+				t.Errorf("Hessenberg() (Q H Q^T)[%d][%d] = %v, want %v", i, j, back.At(i, j), a.At(i, j))
+			}
+			if want := gnum.Identity(n).At(i, j); math.Abs(qtq.At(i, j)-want) > 1e-14 {
+				t.Errorf("Hessenberg() (Q^T Q)[%d][%d] = %v, want %v", i, j, qtq.At(i, j), want)
+			}
+		}
+	}
+}
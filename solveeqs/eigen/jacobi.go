@@ -0,0 +1,95 @@
+package eigen
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/shyang107/gnum"
+)
+
+// maxSweeps is the maximum number of Jacobi sweeps
+const maxSweeps = 50
+
+// Jacobi computes all eigenvalues and eigenvectors of the symmetric
+// matrix a by cyclic Jacobi rotations. It costs several times more than
+// Symmetric, but is simple and computes small eigenvalues to high
+// relative accuracy; use it for small matrices. Only the lower triangle
+// of a is used and a is not modified.
+//
+// output
+//	w	: the eigenvalues in ascending order
+//	v	: the orthonormal eigenvectors as the columns of v
+//	sweeps	: the number of sweeps over the off-diagonal elements
+func Jacobi(a *gnum.Matrix) (w []float64, v *gnum.Matrix, sweeps int, err error) {
+	n, c := a.Dims()
+	if n != c {
+		return nil, nil, 0, fmt.Errorf("Jacobi requires a square matrix, got %d x %d", n, c)
+	}
+	t := a.Clone()
+	h := rows(t)
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			h[i][j] = h[j][i]
+		}
+	}
+	v = gnum.Identity(n)
+	vr := rows(v)
+	const eps = 2.220446049250313e-16
+	for sweeps = 0; ; sweeps++ {
+		off, total := 0.0, 0.0
+		for i := 0; i < n; i++ {
+			total += h[i][i] * h[i][i]
+			for j := i + 1; j < n; j++ {
+				off += 2.0 * h[i][j] * h[i][j]
+			}
+		}
+		total += off
+		if off <= eps*eps*total {
+			break
+		}
+		if sweeps == maxSweeps {
+			return nil, nil, sweeps, fmt.Errorf("Not convergence in %4d sweeps within %10.3e", maxSweeps, eps)
+		}
+		for p := 0; p < n-1; p++ {
+			for q := p + 1; q < n; q++ {
+				apq := h[p][q]
+				if apq == 0.0 {
+					continue
+				}
+				//-----------------------------------------------------
+				// the rotation (c, s) zeroes h[p][q]; t is the smaller
+				// root of t^2 + 2 theta t - 1 = 0
+				//-----------------------------------------------------
+				theta := (h[q][q] - h[p][p]) / (2.0 * apq)
+				t := 1.0 / (math.Abs(theta) + math.Sqrt(theta*theta+1.0))
+				if theta < 0.0 {
+					t = -t
+				}
+				c := 1.0 / math.Sqrt(t*t+1.0)
+				s := t * c
+				for k := 0; k < n; k++ {
+					hkp, hkq := h[k][p], h[k][q]
+					h[k][p] = c*hkp - s*hkq
+					h[k][q] = s*hkp + c*hkq
+				}
+				for k := 0; k < n; k++ {
+					hpk, hqk := h[p][k], h[q][k]
+					h[p][k] = c*hpk - s*hqk
+					h[q][k] = s*hpk + c*hqk
+				}
+				h[p][q], h[q][p] = 0.0, 0.0
+				for k := 0; k < n; k++ {
+					vkp, vkq := vr[k][p], vr[k][q]
+					vr[k][p] = c*vkp - s*vkq
+					vr[k][q] = s*vkp + c*vkq
+				}
+			}
+		}
+	}
+	w = make([]float64, n)
+	for i := range w {
+		w[i] = h[i][i]
+	}
+	sortEigen(w, v)
+	return w, v, sweeps, nil
+}
@@ -0,0 +1,73 @@
+package eigen
+
+import (
+	"math"
+	"testing"
+
+	"github.com/shyang107/gnum"
+)
+
+func TestJacobi(t *testing.T) {
+	tests := []struct {
+		name string
+		a    *gnum.Matrix
+		want []float64
+	}{
+		{"2x2", gnum.NewMatrix(2, 2, []float64{2, 1, 1, 2}), []float64{1, 3}},
+		{"diagonal", gnum.NewMatrix(3, 3, []float64{5, 0, 0, 0, -2, 0, 0, 0, 1}), []float64{-2, 1, 5}},
+		{"laplacian", laplacian(6), []float64{
+			laplacianEig(6, 1), laplacianEig(6, 2), laplacianEig(6, 3),
+			laplacianEig(6, 4), laplacianEig(6, 5), laplacianEig(6, 6)}},
+		{"graded", gnum.NewMatrix(3, 3, []float64{
+			1e20, 1e9, 1,
+			1e9, 1e-1, 1e-11,
+			1, 1e-11, 1e-20}), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, v, sweeps, err := Jacobi(tt.a)
+			if err != nil {
+				t.Fatalf("Jacobi() error = %v", err)
+			}
+			if sweeps > 10 {
+				t.Errorf("Jacobi() sweeps = %d, want <= 10", sweeps)
+			}
+			for i := range tt.want {
+				if math.Abs(w[i]-tt.want[i]) > 1e-13*math.Max(1.0, math.Abs(tt.want[i])) {
+					t.Errorf("Jacobi() w[%d] = %v, want %v", i, w[i], tt.want[i])
+				}
+			}
+			if tt.want != nil {
+				checkEigen(t, tt.a, w, v, 1e-12)
+			}
+		})
+	}
+}
+
+func TestJacobiSymmetric(t *testing.T) {
+	a := gnum.NewMatrix(5, 5, nil)
+	for i := 0; i < 5; i++ {
+		for j := 0; j < 5; j++ {
+			a.Set(i, j, 1.0/float64(i+j+1))
+		}
+	}
+	wj, _, _, err := Jacobi(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ws, _, err := Symmetric(a, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	//-----------------------------------------------------
+	// the smallest eigenvalue of the Hilbert matrix of order 5
+	//-----------------------------------------------------
+	if want := 3.287928772171e-06; math.Abs(wj[0]-want) > 1e-12*want*1e6 {
+		t.Errorf("Jacobi() smallest eigenvalue = %v, want %v", wj[0], want)
+	}
+	for i := range wj {
+		if math.Abs(wj[i]-ws[i]) > 1e-13 {
+			t.Errorf("Jacobi() w[%d] = %v, Symmetric() %v", i, wj[i], ws[i])
+		}
+	}
+}
@@ -0,0 +1,324 @@
+package eigen
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/shyang107/gnum"
+	"github.com/shyang107/gnum/solveeqs"
+)
+
+// dense is a gnum.Matrix as a solveeqs.Operator
+type dense struct {
+	m *gnum.Matrix
+}
+
+// Dense returns the dense matrix a as a solveeqs.Operator for Power and
+// Extremal
+func Dense(a *gnum.Matrix) solveeqs.Operator {
+	return dense{m: a}
+}
+
+// Dims returns the number of rows and columns
+func (d dense) Dims() (r, c int) {
+	return d.m.Dims()
+}
+
+// MulVec sets dst = A x
+func (d dense) MulVec(dst, x []float64) {
+	gnum.Vector(dst).MulVec(d.m, gnum.Vector(x))
+}
+
+// Power finds the eigenvalue of largest magnitude of a and its
+// eigenvector by the power method with the Rayleigh quotient. The
+// dominant eigenvalue must be real and strictly largest in magnitude;
+// convergence is linear with the ratio |lambda2/lambda1|.
+//
+// x0	: the initial vector, nil means (1, 1, ..., 1)
+// tol	: stop when ||A v - lambda v|| <= tol |lambda| (default 1e-10)
+// maxIter	: the maximum number of iterations (default 1000)
+// output
+//	lambda	: the eigenvalue
+//	v	: the unit eigenvector
+//	iter	: the number of iterations
+func Power(a solveeqs.Operator, x0 []float64, tol float64, maxIter int) (lambda float64, v []float64, iter int, err error) {
+	n, err := square(a, x0)
+	if err != nil {
+		return 0.0, nil, 0, err
+	}
+	tol, maxIter = settings(tol, maxIter, 1000)
+	v = startVector(n, x0)
+	y := make([]float64, n)
+	for iter = 1; iter <= maxIter; iter++ {
+		a.MulVec(y, v)
+		lambda = dot(v, y)
+		if resid(y, v, lambda) <= tol*math.Abs(lambda) {
+			return lambda, v, iter, nil
+		}
+		ynorm := norm2(y)
+		if ynorm == 0.0 {
+			return 0.0, v, iter, nil
+		}
+		for i := range v {
+			v[i] = y[i] / ynorm
+		}
+	}
+	return lambda, v, maxIter, fmt.Errorf("Not convergence in %4d iterations within %10.3e", maxIter, tol)
+}
+
+// InverseIteration finds the eigenvalue of a nearest to the shift sigma
+// and its eigenvector by shifted inverse iteration; A - sigma I is
+// factorized once by LU, and the eigenvalue is the Rayleigh quotient of
+// A. A shift that is an exact eigenvalue is perturbed slightly.
+//
+// sigma	: the shift
+// x0	: the initial vector, nil means (1, 1, ..., 1)
+// tol	: stop when ||A v - lambda v|| <= tol ||A||_1 (default 1e-10)
+// maxIter	: the maximum number of iterations (default 100)
+// output
+//	lambda	: the eigenvalue
+//	v	: the unit eigenvector
+//	iter	: the number of iterations
+func InverseIteration(a *gnum.Matrix, sigma float64, x0 []float64, tol float64, maxIter int) (lambda float64, v []float64, iter int, err error) {
+	n, err := square(Dense(a), x0)
+	if err != nil {
+		return 0.0, nil, 0, err
+	}
+	tol, maxIter = settings(tol, maxIter, 100)
+	anorm := a.Norm(1)
+	if anorm == 0.0 {
+		anorm = 1.0
+	}
+	var lu solveeqs.LU
+	shifted := a.Clone()
+	for i := 0; i < n; i++ {
+		shifted.Set(i, i, a.At(i, i)-sigma)
+	}
+	if lu.Factorize(shifted) != nil {
+		delta := 1.0e-10 * anorm
+		for i := 0; i < n; i++ {
+			shifted.Set(i, i, shifted.At(i, i)-delta)
+		}
+		if err := lu.Factorize(shifted); err != nil {
+			return 0.0, nil, 0, err
+		}
+	}
+	v = startVector(n, x0)
+	y := make([]float64, n)
+	for iter = 1; iter <= maxIter; iter++ {
+		x, err := lu.Solve(v)
+		if err != nil {
+			return 0.0, nil, iter, err
+		}
+		xnorm := norm2(x)
+		for i := range v {
+			v[i] = x[i] / xnorm
+		}
+		gnum.Vector(y).MulVec(a, v)
+		lambda = dot(v, y)
+		if resid(y, v, lambda) <= tol*anorm {
+			return lambda, v, iter, nil
+		}
+	}
+	return lambda, v, maxIter, fmt.Errorf("Not convergence in %4d iterations within %10.3e", maxIter, tol)
+}
+
+// Extremal finds the k eigenvalues of largest magnitude of the symmetric
+// operator a and their eigenvectors by subspace iteration with
+// Rayleigh-Ritz projection on a block of min(n, 2k+2) vectors; the
+// projected problems are solved by Jacobi
+//
+// k	: the number of eigenpairs
+// tol	: stop when ||A v - lambda v|| <= tol |lambda_1| for all k pairs
+//	  (default 1e-10)
+// maxIter	: the maximum number of iterations (default 1000)
+// output
+//	w	: the k eigenvalues in decreasing magnitude
+//	v	: the orthonormal eigenvectors as the columns of the n x k v
+//	iter	: the number of iterations
+func Extremal(a solveeqs.Operator, k int, tol float64, maxIter int) (w []float64, v *gnum.Matrix, iter int, err error) {
+	n, err := square(a, nil)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	if k < 1 || k > n {
+		return nil, nil, 0, fmt.Errorf("k = %d out of range [1, %d]", k, n)
+	}
+	tol, maxIter = settings(tol, maxIter, 1000)
+	p := imin(n, 2*k+2)
+	//-----------------------------------------------------
+	// the block is stored by columns: x[j] is vector j;
+	// the start block is pseudo-random
+	//-----------------------------------------------------
+	x := make([][]float64, p)
+	ax := make([][]float64, p)
+	seed := 1.0
+	for j := range x {
+		x[j] = make([]float64, n)
+		ax[j] = make([]float64, n)
+		for i := range x[j] {
+			seed = math.Mod(seed*16807.0, 2147483647.0)
+			x[j][i] = seed/2147483647.0 - 0.5
+		}
+	}
+	orthonormalize(x)
+	t := gnum.NewMatrix(p, p, nil)
+	for iter = 1; iter <= maxIter; iter++ {
+		//-----------------------------------------------------
+		// Rayleigh-Ritz: T = X^T A X, X = X S, A X = A X S
+		//-----------------------------------------------------
+		for j := range x {
+			a.MulVec(ax[j], x[j])
+		}
+		for i := 0; i < p; i++ {
+			for j := 0; j <= i; j++ {
+				t.Set(i, j, dot(x[i], ax[j]))
+			}
+		}
+		theta, s, _, err := Jacobi(t)
+		if err != nil {
+			return nil, nil, iter, err
+		}
+		idx := make([]int, p)
+		for i := range idx {
+			idx[i] = i
+		}
+		sort.SliceStable(idx, func(a, b int) bool { return math.Abs(theta[idx[a]]) > math.Abs(theta[idx[b]]) })
+		permute(theta, s, idx)
+		x = combine(x, s)
+		ax = combine(ax, s)
+		conv := true
+		for j := 0; j < k; j++ {
+			if resid(ax[j], x[j], theta[j]) > tol*math.Abs(theta[0]) {
+				conv = false
+				break
+			}
+		}
+		if conv || iter == maxIter {
+			w = theta[:k]
+			v = gnum.NewMatrix(n, k, nil)
+			for j := 0; j < k; j++ {
+				for i := 0; i < n; i++ {
+					v.Set(i, j, x[j][i])
+				}
+			}
+			if !conv {
+				err = fmt.Errorf("Not convergence in %4d iterations within %10.3e", maxIter, tol)
+			}
+			return w, v, iter, err
+		}
+		//-----------------------------------------------------
+		// next block X = orth(A X)
+		//-----------------------------------------------------
+		x, ax = ax, x
+		orthonormalize(x)
+	}
+	return nil, nil, maxIter, nil
+}
+
+// combine returns the vectors x S, x[j] being column j of x
+func combine(x [][]float64, s *gnum.Matrix) [][]float64 {
+	y := make([][]float64, len(x))
+	for j := range y {
+		y[j] = make([]float64, len(x[0]))
+		for l := range x {
+			axpy(s.At(l, j), x[l], y[j])
+		}
+	}
+	return y
+}
+
+// orthonormalize orthonormalizes the vectors x by modified Gram-Schmidt
+// twice; a vector that becomes dependent is replaced by a unit vector
+func orthonormalize(x [][]float64) {
+	for j := range x {
+		for pass := 0; pass < 2; pass++ {
+			for l := 0; l < j; l++ {
+				axpy(-dot(x[l], x[j]), x[l], x[j])
+			}
+		}
+		xn := norm2(x[j])
+		if xn == 0.0 {
+			x[j][j%len(x[j])] = 1.0
+			for l := 0; l < j; l++ {
+				axpy(-dot(x[l], x[j]), x[l], x[j])
+			}
+			xn = norm2(x[j])
+		}
+		for i := range x[j] {
+			x[j][i] /= xn
+		}
+	}
+}
+
+// square checks that a is square and x0, if not nil, matches it
+func square(a solveeqs.Operator, x0 []float64) (int, error) {
+	n, c := a.Dims()
+	if n != c {
+		return 0, fmt.Errorf("The eigenproblem requires a square matrix, got %d x %d", n, c)
+	}
+	if x0 != nil && len(x0) != n {
+		return 0, fmt.Errorf("len(x0) = %d, want %d", len(x0), n)
+	}
+	return n, nil
+}
+
+// settings fills in the default tolerance and iteration limit
+func settings(tol float64, maxIter, defIter int) (float64, int) {
+	if tol <= 0.0 {
+		tol = 1.0e-10
+	}
+	if maxIter <= 0 {
+		maxIter = defIter
+	}
+	return tol, maxIter
+}
+
+// startVector returns x0, or (1, ..., 1) if it is nil, normalized
+func startVector(n int, x0 []float64) []float64 {
+	v := make([]float64, n)
+	for i := range v {
+		v[i] = 1.0
+		if x0 != nil {
+			v[i] = x0[i]
+		}
+	}
+	if vn := norm2(v); vn != 0.0 {
+		for i := range v {
+			v[i] /= vn
+		}
+	}
+	return v
+}
+
+// resid returns ||y - lambda v||
+func resid(y, v []float64, lambda float64) float64 {
+	s := 0.0
+	for i := range y {
+		d := y[i] - lambda*v[i]
+		s += d * d
+	}
+	return math.Sqrt(s)
+}
+
+// dot returns x . y
+func dot(x, y []float64) float64 {
+	s := 0.0
+	for i := range x {
+		s += x[i] * y[i]
+	}
+	return s
+}
+
+// norm2 returns the Euclidean norm of x
+func norm2(x []float64) float64 {
+	return math.Sqrt(dot(x, x))
+}
+
+// axpy sets y = y + alpha x
+func axpy(alpha float64, x, y []float64) {
+	for i := range y {
+		y[i] += alpha * x[i]
+	}
+}
@@ -0,0 +1,99 @@
+package eigen
+
+import (
+	"math"
+	"testing"
+
+	"github.com/shyang107/gnum"
+)
+
+func TestPower(t *testing.T) {
+	tests := []struct {
+		name    string
+		a       *gnum.Matrix
+		x0      []float64
+		want    float64
+		wantErr bool
+	}{
+		{"2x2", gnum.NewMatrix(2, 2, []float64{2, 1, 1, 3}), nil, (5 + math.Sqrt(5)) / 2, false},
+		{"negative", gnum.NewMatrix(2, 2, []float64{-5, 1, 1, 1}), nil, -2 - math.Sqrt(10), false},
+		{"nonsymmetric", gnum.NewMatrix(2, 2, []float64{4, 1, 2, 3}), nil, 5, false},
+		// (1, ..., 1) is orthogonal to the dominant eigenvector
+		{"laplacian", laplacian(6), []float64{1, 2, 3, 4, 5, 6}, laplacianEig(6, 6), false},
+		{"rotation", gnum.NewMatrix(2, 2, []float64{0, -1, 1, 0}), nil, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, v, _, err := Power(Dense(tt.a), tt.x0, 1e-12, 5000)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Power() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if math.Abs(got-tt.want) > 1e-10 {
+				t.Errorf("Power() lambda = %v, want %v", got, tt.want)
+			}
+			if math.Abs(norm2(v)-1.0) > 1e-14 {
+				t.Errorf("Power() ||v|| = %v, want 1", norm2(v))
+			}
+		})
+	}
+}
+
+func TestInverseIteration(t *testing.T) {
+	n := 10
+	a := laplacian(n)
+	tests := []struct {
+		name  string
+		sigma float64
+		want  float64
+	}{
+		{"smallest", 0.0, laplacianEig(n, 1)},
+		{"interior", 1.0, laplacianEig(n, 4)},
+		{"exact shift", laplacianEig(n, 7), laplacianEig(n, 7)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, v, iter, err := InverseIteration(a, tt.sigma, nil, 0, 0)
+			if err != nil {
+				t.Fatalf("InverseIteration() error = %v", err)
+			}
+			if math.Abs(got-tt.want) > 1e-12 {
+				t.Errorf("InverseIteration() lambda = %v, want %v (%d iterations)", got, tt.want, iter)
+			}
+			w := []float64{got}
+			checkEigen(t, a, w, gnum.NewMatrix(n, 1, v), 1e-9)
+		})
+	}
+}
+
+func TestExtremal(t *testing.T) {
+	n := 20
+	a := laplacian(n)
+	tests := []struct {
+		name string
+		k    int
+	}{
+		{"one", 1},
+		{"three", 3},
+		{"all", n},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, v, _, err := Extremal(Dense(a), tt.k, 1e-10, 5000)
+			if err != nil {
+				t.Fatalf("Extremal() error = %v", err)
+			}
+			for j := 0; j < tt.k; j++ {
+				if want := laplacianEig(n, n-j); math.Abs(w[j]-want) > 1e-9 {
+					t.Errorf("Extremal() w[%d] = %v, want %v", j, w[j], want)
+				}
+			}
+			checkEigen(t, a, w, v, 1e-8)
+		})
+	}
+	if _, _, _, err := Extremal(Dense(a), 0, 0, 0); err == nil {
+		t.Errorf("Extremal() with k = 0: want an error")
+	}
+}
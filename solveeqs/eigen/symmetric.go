@@ -0,0 +1,270 @@
+// Package eigen computes eigenvalues and eigenvectors of dense real
+// matrices: implicit QL for symmetric tridiagonal matrices, Jacobi
+// rotations for small symmetric matrices, Hessenberg-QR for general
+// matrices and power/inverse iteration for a few extremal eigenpairs.
+package eigen
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/shyang107/gnum"
+)
+
+// maxQLIter is the maximum number of QL iterations for one eigenvalue
+const maxQLIter = 30
+
+// SymTridiag computes the eigenvalues of the symmetric tridiagonal
+// matrix with diagonal d and off-diagonal e by the implicit QL method
+// with Wilkinson shifts; d and e are not modified
+//
+// d	: the diagonal, length n
+// e	: the off-diagonal, e[i] couples rows i and i+1, length n-1
+// wantv	: if true the eigenvectors are computed too
+// output
+//	w	: the eigenvalues in ascending order
+//	v	: the eigenvectors as the columns of v, in the order of w;
+//		  nil if wantv is false
+func SymTridiag(d, e []float64, wantv bool) (w []float64, v *gnum.Matrix, err error) {
+	n := len(d)
+	if n > 0 && len(e) != n-1 {
+		return nil, nil, fmt.Errorf("len(e) = %d, want %d", len(e), n-1)
+	}
+	w = append([]float64(nil), d...)
+	ee := make([]float64, n)
+	copy(ee, e)
+	if wantv {
+		v = gnum.Identity(n)
+	}
+	if err := tql(w, ee, v); err != nil {
+		return nil, nil, err
+	}
+	sortEigen(w, v)
+	return w, v, nil
+}
+
+// Symmetric computes the eigenvalues and, if wantv, the eigenvectors of
+// the symmetric matrix a by Householder reduction to tridiagonal form and
+// the implicit QL method; only the lower triangle of a is used and a is
+// not modified
+//
+// output
+//	w	: the eigenvalues in ascending order
+//	v	: the orthonormal eigenvectors as the columns of v; nil if
+//		  wantv is false
+func Symmetric(a *gnum.Matrix, wantv bool) (w []float64, v *gnum.Matrix, err error) {
+	n, c := a.Dims()
+	if n != c {
+		return nil, nil, fmt.Errorf("Symmetric requires a square matrix, got %d x %d", n, c)
+	}
+	d, e, q := tridiagonalize(a, wantv)
+	if err := tql(d, e, q); err != nil {
+		return nil, nil, err
+	}
+	sortEigen(d, q)
+	return d, q, nil
+}
+
+// tridiagonalize reduces the symmetric matrix a to the tridiagonal
+// matrix T = Q^T A Q by Householder reflections. It returns the diagonal
+// d, the off-diagonal e (length n, e[n-1] = 0) and Q if wantq.
+func tridiagonalize(a *gnum.Matrix, wantq bool) (d, e []float64, q *gnum.Matrix) {
+	n, _ := a.Dims()
+	t := a.Clone()
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			t.Set(i, j, t.At(j, i))
+		}
+	}
+	h := rows(t)
+	var qr [][]float64
+	if wantq {
+		q = gnum.Identity(n)
+		qr = rows(q)
+	}
+	v := make([]float64, n)
+	p := make([]float64, n)
+	for k := 0; k < n-2; k++ {
+		//-----------------------------------------------------
+		// the reflection I - 2 v v^T / (v^T v) zeroes h[k+2:][k]
+		//-----------------------------------------------------
+		xnorm := 0.0
+		for i := k + 1; i < n; i++ {
+			xnorm = math.Hypot(xnorm, h[i][k])
+		}
+		if xnorm == 0.0 {
+			continue
+		}
+		alpha := -math.Copysign(xnorm, h[k+1][k])
+		for i := k + 1; i < n; i++ {
+			v[i] = h[i][k]
+		}
+		v[k+1] -= alpha
+		vv := 0.0
+		for i := k + 1; i < n; i++ {
+			vv += v[i] * v[i]
+		}
+		if vv == 0.0 {
+			continue
+		}
+		//-----------------------------------------------------
+		// symmetric rank-2 update of the trailing block:
+		// p = 2 H v / vv, q = p - (v^T p / vv) v,
+		// H = H - v q^T - q v^T
+		//-----------------------------------------------------
+		for i := k + 1; i < n; i++ {
+			s := 0.0
+			for j := k + 1; j < n; j++ {
+				s += h[i][j] * v[j]
+			}
+			p[i] = 2.0 * s / vv
+		}
+		kk := 0.0
+		for i := k + 1; i < n; i++ {
+			kk += v[i] * p[i]
+		}
+		kk /= vv
+		for i := k + 1; i < n; i++ {
+			p[i] -= kk * v[i]
+		}
+		for i := k + 1; i < n; i++ {
+			for j := k + 1; j < n; j++ {
+				h[i][j] -= v[i]*p[j] + p[i]*v[j]
+			}
+		}
+		h[k+1][k], h[k][k+1] = alpha, alpha
+		for i := k + 2; i < n; i++ {
+			h[i][k], h[k][i] = 0.0, 0.0
+		}
+		if wantq {
+			for i := 0; i < n; i++ {
+				s := 0.0
+				for j := k + 1; j < n; j++ {
+					s += qr[i][j] * v[j]
+				}
+				s *= 2.0 / vv
+				for j := k + 1; j < n; j++ {
+					qr[i][j] -= s * v[j]
+				}
+			}
+		}
+	}
+	d = make([]float64, n)
+	e = make([]float64, n)
+	for i := 0; i < n; i++ {
+		d[i] = h[i][i]
+		if i < n-1 {
+			e[i] = h[i+1][i]
+		}
+	}
+	return d, e, q
+}
+
+// tql computes the eigenvalues of the symmetric tridiagonal matrix (d, e)
+// in place by the implicit QL method; e has length n and is destroyed.
+// If z is not nil, the rotations are accumulated into its columns, so a
+// z = Q from tridiagonalize gives the eigenvectors of A.
+func tql(d, e []float64, z *gnum.Matrix) error {
+	n := len(d)
+	var zr [][]float64
+	if z != nil {
+		zr = rows(z)
+	}
+	const eps = 2.220446049250313e-16
+	for l := 0; l < n; l++ {
+		for iter := 0; ; iter++ {
+			m := l
+			for ; m < n-1; m++ {
+				dd := math.Abs(d[m]) + math.Abs(d[m+1])
+				if math.Abs(e[m]) <= eps*dd {
+					break
+				}
+			}
+			if m == l {
+				break
+			}
+			if iter == maxQLIter {
+				return fmt.Errorf("Not convergence in %4d iterations for eigenvalue %d", maxQLIter, l)
+			}
+			//-----------------------------------------------------
+			// Wilkinson shift, then chase the bulge from m to l
+			//-----------------------------------------------------
+			g := (d[l+1] - d[l]) / (2.0 * e[l])
+			r := math.Hypot(g, 1.0)
+			g = d[m] - d[l] + e[l]/(g+math.Copysign(r, g))
+			s, c, p := 1.0, 1.0, 0.0
+			i := m - 1
+			for ; i >= l; i-- {
+				f := s * e[i]
+				b := c * e[i]
+				r = math.Hypot(f, g)
+				e[i+1] = r
+				if r == 0.0 {
+					d[i+1] -= p
+					e[m] = 0.0
+					break
+				}
+				s = f / r
+				c = g / r
+				g = d[i+1] - p
+				r = (d[i]-g)*s + 2.0*c*b
+				p = s * r
+				d[i+1] = g + p
+				g = c*r - b
+				for k := range zr {
+					f = zr[k][i+1]
+					zr[k][i+1] = s*zr[k][i] + c*f
+					zr[k][i] = c*zr[k][i] - s*f
+				}
+			}
+			if r == 0.0 && i >= l {
+				continue
+			}
+			d[l] -= p
+			e[l] = g
+			e[m] = 0.0
+		}
+	}
+	return nil
+}
+
+// sortEigen sorts w in ascending order and permutes the columns of v, if
+// not nil, alike
+func sortEigen(w []float64, v *gnum.Matrix) {
+	idx := make([]int, len(w))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(a, b int) bool { return w[idx[a]] < w[idx[b]] })
+	permute(w, v, idx)
+}
+
+// permute reorders w and the columns of v, if not nil, so that the new
+// position i holds the old position idx[i]
+func permute(w []float64, v *gnum.Matrix, idx []int) {
+	ws := append([]float64(nil), w...)
+	for i, k := range idx {
+		w[i] = ws[k]
+	}
+	if v == nil {
+		return
+	}
+	vs := v.Clone()
+	r, _ := v.Dims()
+	for j, k := range idx {
+		for i := 0; i < r; i++ {
+			v.Set(i, j, vs.At(i, k))
+		}
+	}
+}
+
+// rows returns the rows of m as slices sharing its storage
+func rows(m *gnum.Matrix) [][]float64 {
+	r, _ := m.Dims()
+	a := make([][]float64, r)
+	for i := range a {
+		a[i] = m.Row(i)
+	}
+	return a
+}
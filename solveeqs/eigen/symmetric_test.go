@@ -0,0 +1,156 @@
+package eigen
+
+import (
+	"math"
+	"testing"
+
+	"github.com/shyang107/gnum"
+)
+
+// laplacian returns the n x n matrix tridiag(-1, 2, -1)
+func laplacian(n int) *gnum.Matrix {
+	a := gnum.NewMatrix(n, n, nil)
+	for i := 0; i < n; i++ {
+		a.Set(i, i, 2.0)
+		if i > 0 {
+			a.Set(i, i-1, -1.0)
+			a.Set(i-1, i, -1.0)
+		}
+	}
+	return a
+}
+
+// laplacianEig returns the k-th eigenvalue (k = 1..n) of laplacian(n)
+func laplacianEig(n, k int) float64 {
+	return 2.0 - 2.0*math.Cos(float64(k)*math.Pi/float64(n+1))
+}
+
+// checkEigen checks A v_j = w_j v_j and V^T V = I within tol
+func checkEigen(t *testing.T, a *gnum.Matrix, w []float64, v *gnum.Matrix, tol float64) {
+	t.Helper()
+	n, k := v.Dims()
+	for j := 0; j < k; j++ {
+		for i := 0; i < n; i++ {
+			s := -w[j] * v.At(i, j)
+			for l := 0; l < n; l++ {
+				s += a.At(i, l) * v.At(l, j)
+			}
+			if math.Abs(s) > tol {
+				t.Errorf("eigenpair %d: (A v - w v)[%d] = %v", j, i, s)
+				return
+			}
+		}
+		for l := 0; l <= j; l++ {
+			s := 0.0
+			for i := 0; i < n; i++ {
+				s += v.At(i, j) * v.At(i, l)
+			}
+			if l == j {
+				s -= 1.0
+			}
+			if math.Abs(s) > tol {
+				t.Errorf("eigenvectors %d, %d are not orthonormal: %v", l, j, s)
+				return
+			}
+		}
+	}
+}
+
+func TestSymTridiag(t *testing.T) {
+	tests := []struct {
+		name string
+		d, e []float64
+		want []float64
+	}{
+		{"1x1", []float64{3}, []float64{}, []float64{3}},
+		{"2x2", []float64{2, 2}, []float64{1}, []float64{1, 3}},
+		{"diagonal", []float64{3, -1, 2}, []float64{0, 0}, []float64{-1, 2, 3}},
+		{"3x3", []float64{1, 0, 1}, []float64{1, 1}, []float64{-1, 1, 2}},
+		{"sqrt2", []float64{0, 0, 0}, []float64{1, 1}, []float64{-math.Sqrt2, 0, math.Sqrt2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, err := SymTridiag(tt.d, tt.e, false)
+			if err != nil {
+				t.Fatalf("SymTridiag() error = %v", err)
+			}
+			for i := range got {
+				if math.Abs(got[i]-tt.want[i]) > 1e-13 {
+					t.Errorf("SymTridiag() w[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+	n := 12
+	d, e := make([]float64, n), make([]float64, n-1)
+	for i := range d {
+		d[i] = 2.0
+	}
+	for i := range e {
+		e[i] = -1.0
+	}
+	w, v, err := SymTridiag(d, e, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k := range w {
+		if want := laplacianEig(n, k+1); math.Abs(w[k]-want) > 1e-13 {
+			t.Errorf("SymTridiag() laplacian w[%d] = %v, want %v", k, w[k], want)
+		}
+	}
+	checkEigen(t, laplacian(n), w, v, 1e-12)
+	if _, _, err := SymTridiag(d, e[:3], false); err == nil {
+		t.Errorf("SymTridiag() with a short e: want an error")
+	}
+}
+
+func TestSymmetric(t *testing.T) {
+	tests := []struct {
+		name  string
+		a     *gnum.Matrix
+		trace float64
+	}{
+		{"4x4", gnum.NewMatrix(4, 4, []float64{
+			4, 1, -2, 2,
+			1, 2, 0, 1,
+			-2, 0, 3, -2,
+			2, 1, -2, -1}), 8},
+		{"laplacian", laplacian(9), 18},
+		{"rank one", gnum.NewMatrix(3, 3, []float64{
+			1, 2, 3,
+			2, 4, 6,
+			3, 6, 9}), 14},
+		{"2x2", gnum.NewMatrix(2, 2, []float64{1, 5, 5, 1}), 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, v, err := Symmetric(tt.a, true)
+			if err != nil {
+				t.Fatalf("Symmetric() error = %v", err)
+			}
+			tr := 0.0
+			for i, wi := range w {
+				tr += wi
+				if i > 0 && wi < w[i-1] {
+					t.Errorf("Symmetric() eigenvalues not ascending: %v", w)
+				}
+			}
+			if math.Abs(tr-tt.trace) > 1e-12 {
+				t.Errorf("Symmetric() sum of eigenvalues = %v, want %v", tr, tt.trace)
+			}
+			checkEigen(t, tt.a, w, v, 1e-12)
+			wonly, vnil, err := Symmetric(tt.a, false)
+			if err != nil || vnil != nil {
+				t.Fatalf("Symmetric(wantv = false) = %v, %v", vnil, err)
+			}
+			for i := range w {
+				if math.Abs(wonly[i]-w[i]) > 1e-13 {
+					t.Errorf("Symmetric(wantv = false) w[%d] = %v, want %v", i, wonly[i], w[i])
+				}
+			}
+		})
+	}
+	if _, _, err := Symmetric(gnum.NewMatrix(2, 3, nil), false); err == nil {
+		t.Errorf("Symmetric() of a 2 x 3 matrix: want an error")
+	}
+}
@@ -0,0 +1,355 @@
+package solveeqs
+
+import (
+	"fmt"
+	"math"
+)
+
+// IterSettings controls the iterative solvers CG, BiCGSTAB and GMRES; the
+// zero value uses the defaults
+type IterSettings struct {
+	Tol      float64        // stop when ||b - A x|| <= Tol ||b|| (default 1e-8)
+	MaxIter  int            // the maximum number of iterations (default 2n, at least 20)
+	Restart  int            // the restart length of GMRES (default 30)
+	Precond  Preconditioner // the preconditioner M ~ A (default none)
+	// Callback, if not nil, is called after every iteration with the
+	// relative residual; returning false stops the solver
+	Callback func(iter int, resid float64) bool
+}
+
+// IterResult reports the outcome of an iterative solver
+type IterResult struct {
+	X          []float64 // the solution
+	Iterations int       // the number of iterations
+	MatVecs    int       // the number of products with A
+	Resid      float64   // the final relative residual ||b - A x|| / ||b||
+	History    []float64 // the relative residual after each iteration
+	Converged  bool
+}
+
+// defaults returns a copy of s with the defaults filled in for an n x n
+// system
+func (s *IterSettings) defaults(n int) IterSettings {
+	var d IterSettings
+	if s != nil {
+		d = *s
+	}
+	if d.Tol <= 0.0 {
+		d.Tol = 1.0e-8
+	}
+	if d.MaxIter <= 0 {
+		d.MaxIter = 2 * n
+		if d.MaxIter < 20 {
+			d.MaxIter = 20
+		}
+	}
+	if d.Restart <= 0 {
+		d.Restart = 30
+	}
+	if d.Restart > n {
+		d.Restart = n
+	}
+	return d
+}
+
+// iteration holds the state shared by the iterative solvers
+type iteration struct {
+	s     IterSettings
+	a     Operator
+	res   IterResult
+	bnorm float64
+}
+
+// start checks the shapes, copies x0 (nil means zero) and returns the
+// initial residual r = b - A x
+func start(a Operator, b, x0 []float64, s *IterSettings) (*iteration, []float64, error) {
+	n, c := a.Dims()
+	if n != c {
+		return nil, nil, fmt.Errorf("The iterative solvers require a square matrix, got %d x %d", n, c)
+	}
+	if len(b) != n || (x0 != nil && len(x0) != n) {
+		return nil, nil, fmt.Errorf("len(b) = %d, len(x0) = %d, want %d", len(b), len(x0), n)
+	}
+	it := &iteration{s: s.defaults(n), a: a, bnorm: norm2(b)}
+	it.res.X = make([]float64, n)
+	r := append([]float64(nil), b...)
+	if x0 != nil {
+		copy(it.res.X, x0)
+		ax := make([]float64, n)
+		it.mulVec(ax, it.res.X)
+		for i := range r {
+			r[i] -= ax[i]
+		}
+	}
+	if it.bnorm == 0.0 {
+		it.bnorm = 1.0
+	}
+	return it, r, nil
+}
+
+// mulVec counts and computes dst = A x
+func (it *iteration) mulVec(dst, x []float64) {
+	it.a.MulVec(dst, x)
+	it.res.MatVecs++
+}
+
+// precond sets dst = M^-1 r, or copies r without a preconditioner
+func (it *iteration) precond(dst, r []float64) {
+	if it.s.Precond == nil {
+		copy(dst, r)
+		return
+	}
+	it.s.Precond.Apply(dst, r)
+}
+
+// record books one iteration with the residual norm rnorm and reports
+// whether the solver should stop
+func (it *iteration) record(rnorm float64) (stop bool, err error) {
+	it.res.Iterations++
+	it.res.Resid = rnorm / it.bnorm
+	it.res.History = append(it.res.History, it.res.Resid)
+	if it.res.Resid <= it.s.Tol {
+		it.res.Converged = true
+		return true, nil
+	}
+	if it.s.Callback != nil && !it.s.Callback(it.res.Iterations, it.res.Resid) {
+		return true, fmt.Errorf("Stopped by the callback at iteration %d, residual %10.3e", it.res.Iterations, it.res.Resid)
+	}
+	if math.IsNaN(it.res.Resid) {
+		return true, fmt.Errorf("Breakdown at iteration %d: the residual is NaN", it.res.Iterations)
+	}
+	if it.res.Iterations >= it.s.MaxIter {
+		return true, fmt.Errorf("Not convergence in %4d iterations within %10.3e, residual %10.3e", it.s.MaxIter, it.s.Tol, it.res.Resid)
+	}
+	return false, nil
+}
+
+// CG solves A x = b by the preconditioned conjugate gradient method; A
+// and the preconditioner must be symmetric positive definite
+//
+// x0	: the initial guess, nil means zero
+// s	: the settings, nil means the defaults
+func CG(a Operator, b, x0 []float64, s *IterSettings) (IterResult, error) {
+	it, r, err := start(a, b, x0, s)
+	if err != nil {
+		return IterResult{}, err
+	}
+	if norm2(r)/it.bnorm <= it.s.Tol {
+		it.res.Converged = true
+		return it.res, nil
+	}
+	x := it.res.X
+	n := len(b)
+	z := make([]float64, n)
+	ap := make([]float64, n)
+	it.precond(z, r)
+	p := append([]float64(nil), z...)
+	rz := dot(r, z)
+	for {
+		it.mulVec(ap, p)
+		pap := dot(p, ap)
+		if pap <= 0.0 {
+			return it.res, fmt.Errorf("Breakdown at iteration %d: the matrix is not positive definite", it.res.Iterations+1)
+		}
+		alpha := rz / pap
+		axpy(alpha, p, x)
+		axpy(-alpha, ap, r)
+		if stop, err := it.record(norm2(r)); stop {
+			return it.res, err
+		}
+		it.precond(z, r)
+		rznew := dot(r, z)
+		beta := rznew / rz
+		rz = rznew
+		for i := range p {
+			p[i] = z[i] + beta*p[i]
+		}
+	}
+}
+
+// BiCGSTAB solves A x = b by the right preconditioned biconjugate
+// gradient stabilized method for nonsymmetric A
+//
+// x0	: the initial guess, nil means zero
+// s	: the settings, nil means the defaults
+func BiCGSTAB(a Operator, b, x0 []float64, s *IterSettings) (IterResult, error) {
+	it, r, err := start(a, b, x0, s)
+	if err != nil {
+		return IterResult{}, err
+	}
+	if norm2(r)/it.bnorm <= it.s.Tol {
+		it.res.Converged = true
+		return it.res, nil
+	}
+	x := it.res.X
+	n := len(b)
+	rhat := append([]float64(nil), r...)
+	p := make([]float64, n)
+	v := make([]float64, n)
+	phat := make([]float64, n)
+	shat := make([]float64, n)
+	t := make([]float64, n)
+	rho, alpha, omega := 1.0, 1.0, 1.0
+	for {
+		rhonew := dot(rhat, r)
+		if rhonew == 0.0 || omega == 0.0 {
+			return it.res, fmt.Errorf("Breakdown at iteration %d: rho = %10.3e, omega = %10.3e", it.res.Iterations+1, rhonew, omega)
+		}
+		beta := (rhonew / rho) * (alpha / omega)
+		rho = rhonew
+		for i := range p {
+			p[i] = r[i] + beta*(p[i]-omega*v[i])
+		}
+		it.precond(phat, p)
+		it.mulVec(v, phat)
+		alpha = rho / dot(rhat, v)
+		//-----------------------------------------------------
+		// s = r - alpha v is kept in r
+		//-----------------------------------------------------
+		axpy(-alpha, v, r)
+		axpy(alpha, phat, x)
+		if snorm := norm2(r); snorm/it.bnorm <= it.s.Tol {
+			_, err := it.record(snorm)
+			return it.res, err
+		}
+		it.precond(shat, r)
+		it.mulVec(t, shat)
+		tt := dot(t, t)
+		if tt == 0.0 {
+			return it.res, fmt.Errorf("Breakdown at iteration %d: t = 0", it.res.Iterations+1)
+		}
+		omega = dot(t, r) / tt
+		axpy(omega, shat, x)
+		axpy(-omega, t, r)
+		if stop, err := it.record(norm2(r)); stop {
+			return it.res, err
+		}
+	}
+}
+
+// GMRES solves A x = b by the right preconditioned generalized minimal
+// residual method restarted every s.Restart iterations
+//
+// x0	: the initial guess, nil means zero
+// s	: the settings, nil means the defaults
+func GMRES(a Operator, b, x0 []float64, s *IterSettings) (IterResult, error) {
+	it, r, err := start(a, b, x0, s)
+	if err != nil {
+		return IterResult{}, err
+	}
+	x := it.res.X
+	n := len(b)
+	m := it.s.Restart
+	V := make([][]float64, m+1)
+	for i := range V {
+		V[i] = make([]float64, n)
+	}
+	H := make([][]float64, m+1) // H[i][j], Hessenberg
+	for i := range H {
+		H[i] = make([]float64, m)
+	}
+	cs := make([]float64, m)
+	sn := make([]float64, m)
+	g := make([]float64, m+1)
+	z := make([]float64, n)
+	w := make([]float64, n)
+	for {
+		beta := norm2(r)
+		if beta/it.bnorm <= it.s.Tol {
+			it.res.Resid = beta / it.bnorm
+			it.res.Converged = true
+			return it.res, nil
+		}
+		for i := range V[0] {
+			V[0][i] = r[i] / beta
+		}
+		for i := range g {
+			g[i] = 0.0
+		}
+		g[0] = beta
+		k := 0
+		var stop bool
+		for k < m && !stop {
+			//-----------------------------------------------------
+			// Arnoldi step with modified Gram-Schmidt
+			//-----------------------------------------------------
+			it.precond(z, V[k])
+			it.mulVec(w, z)
+			for i := 0; i <= k; i++ {
+				H[i][k] = dot(w, V[i])
+				axpy(-H[i][k], V[i], w)
+			}
+			H[k+1][k] = norm2(w)
+			if H[k+1][k] != 0.0 {
+				for i := range w {
+					V[k+1][i] = w[i] / H[k+1][k]
+				}
+			}
+			//-----------------------------------------------------
+			// apply the previous rotations, then zero H[k+1][k]
+			//-----------------------------------------------------
+			for i := 0; i < k; i++ {
+				H[i][k], H[i+1][k] = cs[i]*H[i][k]+sn[i]*H[i+1][k], -sn[i]*H[i][k]+cs[i]*H[i+1][k]
+			}
+			d := math.Hypot(H[k][k], H[k+1][k])
+			if d == 0.0 {
+				return it.res, fmt.Errorf("Breakdown at iteration %d: singular Hessenberg matrix", it.res.Iterations+1)
+			}
+			cs[k], sn[k] = H[k][k]/d, H[k+1][k]/d
+			H[k][k], H[k+1][k] = d, 0.0
+			g[k], g[k+1] = cs[k]*g[k], -sn[k]*g[k]
+			k++
+			stop, err = it.record(math.Abs(g[k]))
+		}
+		//-----------------------------------------------------
+		// x += M^-1 V y with H y = g
+		//-----------------------------------------------------
+		y := make([]float64, k)
+		for i := k - 1; i >= 0; i-- {
+			y[i] = g[i]
+			for j := i + 1; j < k; j++ {
+				y[i] -= H[i][j] * y[j]
+			}
+			y[i] /= H[i][i]
+		}
+		for i := range w {
+			w[i] = 0.0
+		}
+		for j := 0; j < k; j++ {
+			axpy(y[j], V[j], w)
+		}
+		it.precond(z, w)
+		axpy(1.0, z, x)
+		if stop {
+			return it.res, err
+		}
+		//-----------------------------------------------------
+		// restart from the true residual
+		//-----------------------------------------------------
+		it.mulVec(w, x)
+		for i := range r {
+			r[i] = b[i] - w[i]
+		}
+	}
+}
+
+// dot returns x . y
+func dot(x, y []float64) float64 {
+	s := 0.0
+	for i := range x {
+		s += x[i] * y[i]
+	}
+	return s
+}
+
+// norm2 returns the Euclidean norm of x
+func norm2(x []float64) float64 {
+	return math.Sqrt(dot(x, x))
+}
+
+// axpy sets y = y + alpha x
+func axpy(alpha float64, x, y []float64) {
+	for i := range y {
+		y[i] += alpha * x[i]
+	}
+}
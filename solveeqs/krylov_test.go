@@ -0,0 +1,79 @@
+package solveeqs
+
+import (
+	"math"
+	"testing"
+)
+
+func TestKrylov(t *testing.T) {
+	n := 100
+	spd := poisson(n)
+	nonsym := convection(n, 1.5)
+	jac, _ := NewJacobi(nonsym)
+	ilu, err := NewILU0(nonsym)
+	if err != nil {
+		t.Fatalf("NewILU0() error = %v", err)
+	}
+	spdILU, _ := NewILU0(spd)
+	xs := make([]float64, n)
+	for i := range xs {
+		xs[i] = math.Sin(float64(i))
+	}
+	type solver func(Operator, []float64, []float64, *IterSettings) (IterResult, error)
+	tests := []struct {
+		name    string
+		solve   solver
+		a       *CSR
+		s       *IterSettings
+		maxIter int
+	}{
+		{"CG", CG, spd, nil, n},
+		{"CG + ILU(0)", CG, spd, &IterSettings{Precond: spdILU}, 2},
+		{"BiCGSTAB", BiCGSTAB, nonsym, &IterSettings{MaxIter: 4 * n}, 4 * n},
+		{"BiCGSTAB + Jacobi", BiCGSTAB, nonsym, &IterSettings{Precond: jac, MaxIter: 4 * n}, 4 * n},
+		{"BiCGSTAB + ILU(0)", BiCGSTAB, nonsym, &IterSettings{Precond: ilu}, 2},
+		{"GMRES", GMRES, nonsym, &IterSettings{Restart: n}, n},
+		{"GMRES(20) + Jacobi", GMRES, nonsym, &IterSettings{Restart: 20, Precond: jac, MaxIter: 20 * n}, 20 * n},
+		{"GMRES + ILU(0)", GMRES, nonsym, &IterSettings{Precond: ilu}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := make([]float64, n)
+			tt.a.MulVec(b, xs)
+			res, err := tt.solve(tt.a, b, nil, tt.s)
+			if err != nil {
+				t.Errorf("%s error = %v", tt.name, err)
+				return
+			}
+			if !res.Converged || res.Iterations > tt.maxIter || len(res.History) != res.Iterations {
+				t.Errorf("%s converged = %v in %d iterations, %d history entries", tt.name, res.Converged, res.Iterations, len(res.History))
+			}
+			for i := range xs {
+				if math.Abs(res.X[i]-xs[i]) > 1.0e-5 {
+					t.Errorf("%s x[%d] = %v, want %v", tt.name, i, res.X[i], xs[i])
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestKrylovCallback(t *testing.T) {
+	a := poisson(50)
+	b := make([]float64, 50)
+	for i := range b {
+		b[i] = 1.0
+	}
+	calls := 0
+	s := &IterSettings{Callback: func(iter int, resid float64) bool {
+		calls++
+		return iter < 5
+	}}
+	res, err := CG(a, b, nil, s)
+	if err == nil || res.Converged {
+		t.Errorf("CG() was not stopped by the callback")
+	}
+	if calls != 5 || res.Iterations != 5 {
+		t.Errorf("CG() callback calls = %d, iterations = %d, want 5", calls, res.Iterations)
+	}
+}
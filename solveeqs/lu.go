@@ -0,0 +1,213 @@
+package solveeqs
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/shyang107/gnum"
+)
+
+// LU is the LU factorization with partial pivoting P A = L U of a square
+// matrix A; L is unit lower triangular and U upper triangular, both
+// stored in one matrix.
+//
+//	var lu solveeqs.LU
+//	if err := lu.Factorize(a); err != nil { ... }
+//	x, err := lu.Solve(b)
+type LU struct {
+	lu    *gnum.Matrix
+	piv   []int   // row i of P A is row piv[i] of A
+	sign  float64 // the determinant of P
+	anorm float64 // the 1-norm of A
+}
+
+// Factorize computes the LU factorization of the square matrix a; a is
+// not modified. An exactly singular matrix is factorized, but gives an
+// error, and Solve and Inverse fail on it.
+func (f *LU) Factorize(a *gnum.Matrix) (err error) {
+	n, c := a.Dims()
+	if n != c {
+		return fmt.Errorf("LU requires a square matrix, got %d x %d", n, c)
+	}
+	f.lu = a.Clone()
+	f.piv = make([]int, n)
+	f.sign = 1.0
+	f.anorm = a.Norm(1)
+	for i := range f.piv {
+		f.piv[i] = i
+	}
+	for k := 0; k < n; k++ {
+		//-----------------------------------------------------
+		// find the pivot in column k
+		//-----------------------------------------------------
+		p := k
+		for i := k + 1; i < n; i++ {
+			if math.Abs(f.lu.At(i, k)) > math.Abs(f.lu.At(p, k)) {
+				p = i
+			}
+		}
+		if p != k {
+			rp, rk := f.lu.Row(p), f.lu.Row(k)
+			for j := range rk {
+				rp[j], rk[j] = rk[j], rp[j]
+			}
+			f.piv[p], f.piv[k] = f.piv[k], f.piv[p]
+			f.sign = -f.sign
+		}
+		ukk := f.lu.At(k, k)
+		if ukk == 0.0 {
+			if err == nil {
+				err = fmt.Errorf("Singular matrix: zero pivot in column %d", k)
+			}
+			continue
+		}
+		//-----------------------------------------------------
+		// eliminate below the pivot
+		//-----------------------------------------------------
+		rk := f.lu.Row(k)
+		for i := k + 1; i < n; i++ {
+			ri := f.lu.Row(i)
+			m := ri[k] / ukk
+			ri[k] = m
+			if m == 0.0 {
+				continue
+			}
+			for j := k + 1; j < n; j++ {
+				ri[j] -= m * rk[j]
+			}
+		}
+	}
+	return err
+}
+
+// Solve solves A x = b
+func (f *LU) Solve(b []float64) (x []float64, err error) {
+	if err := f.singular(); err != nil {
+		return nil, err
+	}
+	n, _ := f.lu.Dims()
+	if len(b) != n {
+		return nil, fmt.Errorf("len(b) = %d, want %d", len(b), n)
+	}
+	x = make([]float64, n)
+	for i := range x {
+		x[i] = b[f.piv[i]]
+	}
+	f.solveLU(x)
+	return x, nil
+}
+
+// solveLU overwrites x = P b by the solution of L U x = P b
+func (f *LU) solveLU(x []float64) {
+	n := len(x)
+	for i := 1; i < n; i++ {
+		r := f.lu.Row(i)
+		for j := 0; j < i; j++ {
+			x[i] -= r[j] * x[j]
+		}
+	}
+	for i := n - 1; i >= 0; i-- {
+		r := f.lu.Row(i)
+		for j := i + 1; j < n; j++ {
+			x[i] -= r[j] * x[j]
+		}
+		x[i] /= r[i]
+	}
+}
+
+// solveTrans overwrites b by the solution of A^T x = b
+func (f *LU) solveTrans(b []float64) {
+	// A^T = U^T L^T P
+	n := len(b)
+	for i := 0; i < n; i++ {
+		for j := 0; j < i; j++ {
+			b[i] -= f.lu.At(j, i) * b[j]
+		}
+		b[i] /= f.lu.At(i, i)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := i + 1; j < n; j++ {
+			b[i] -= f.lu.At(j, i) * b[j]
+		}
+	}
+	w := append([]float64(nil), b...)
+	for i := range w {
+		b[f.piv[i]] = w[i]
+	}
+}
+
+// Det returns the determinant of A; it is NaN if A is not factorized
+func (f *LU) Det() float64 {
+	if f.lu == nil {
+		return math.NaN()
+	}
+	n, _ := f.lu.Dims()
+	d := f.sign
+	for i := 0; i < n; i++ {
+		d *= f.lu.At(i, i)
+	}
+	return d
+}
+
+// Inverse returns the inverse of A
+func (f *LU) Inverse() (*gnum.Matrix, error) {
+	if err := f.singular(); err != nil {
+		return nil, err
+	}
+	n, _ := f.lu.Dims()
+	inv := gnum.NewMatrix(n, n, nil)
+	x := make([]float64, n)
+	for j := 0; j < n; j++ {
+		for i := range x {
+			x[i] = 0.0
+			if f.piv[i] == j {
+				x[i] = 1.0
+			}
+		}
+		f.solveLU(x)
+		for i := range x {
+			inv.Set(i, j, x[i])
+		}
+	}
+	return inv, nil
+}
+
+// Cond returns an estimate of the 1-norm condition number
+// ||A||_1 ||A^-1||_1; it is +Inf for a singular matrix
+func (f *LU) Cond() float64 {
+	if f.singular() != nil {
+		return math.Inf(1)
+	}
+	n, _ := f.lu.Dims()
+	solve := func(x []float64) {
+		w := append([]float64(nil), x...)
+		for i := range x {
+			x[i] = w[f.piv[i]]
+		}
+		f.solveLU(x)
+	}
+	return f.anorm * normInv1(n, solve, f.solveTrans)
+}
+
+// singular returns an error if U has a zero on its diagonal
+func (f *LU) singular() error {
+	if f.lu == nil {
+		return fmt.Errorf("LU: the matrix is not factorized")
+	}
+	n, _ := f.lu.Dims()
+	for i := 0; i < n; i++ {
+		if f.lu.At(i, i) == 0.0 {
+			return fmt.Errorf("Singular matrix: zero pivot in column %d", i)
+		}
+	}
+	return nil
+}
+
+// Solve solves the square system A x = b by LU factorization
+func Solve(a *gnum.Matrix, b []float64) (x []float64, err error) {
+	var lu LU
+	if err := lu.Factorize(a); err != nil {
+		return nil, err
+	}
+	return lu.Solve(b)
+}
@@ -0,0 +1,138 @@
+package solveeqs
+
+import (
+	"math"
+	"testing"
+
+	"github.com/shyang107/gnum"
+)
+
+// mulVec returns a x
+func mulVec(a *gnum.Matrix, x []float64) []float64 {
+	r, c := a.Dims()
+	y := make([]float64, r)
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			y[i] += a.At(i, j) * x[j]
+		}
+	}
+	return y
+}
+
+// isIdentity reports whether a b = I within tol
+func isIdentity(a, b *gnum.Matrix, tol float64) bool {
+	n, _ := a.Dims()
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			s := 0.0
+			for k := 0; k < n; k++ {
+				s += a.At(i, k) * b.At(k, j)
+			}
+			if i == j {
+				s -= 1.0
+			}
+			if math.Abs(s) > tol {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// hilbert returns the n x n Hilbert matrix
+func hilbert(n int) *gnum.Matrix {
+	h := gnum.NewMatrix(n, n, nil)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			h.Set(i, j, 1.0/float64(i+j+1))
+		}
+	}
+	return h
+}
+
+func TestLU(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        *gnum.Matrix
+		x        []float64
+		wantDet  float64
+		wantCond float64
+		wantErr  bool
+	}{
+		{
+			"Case 1 : 3 x 3 with pivoting",
+			gnum.NewMatrix(3, 3, []float64{0, 2, 1, 1, 1, 1, 2, 1, 0}),
+			[]float64{1, -2, 3},
+			3.0,
+			0.0,
+			false,
+		},
+		{
+			"Case 2 : Hilbert 4 x 4",
+			hilbert(4),
+			[]float64{1, 1, 1, 1},
+			1.0 / 6048000.0,
+			28375.0,
+			false,
+		},
+		{
+			"Case 3 : singular",
+			gnum.NewMatrix(2, 2, []float64{1, 2, 2, 4}),
+			[]float64{1, 1},
+			0.0,
+			math.Inf(1),
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var lu LU
+			err := lu.Factorize(tt.a)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LU.Factorize() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got := lu.Det(); math.Abs(got-tt.wantDet) > 1.0e-12*math.Max(1.0, math.Abs(tt.wantDet)) {
+				t.Errorf("LU.Det() = %v, want %v", got, tt.wantDet)
+			}
+			if tt.wantCond != 0.0 {
+				if got := lu.Cond(); got != tt.wantCond && math.Abs(got-tt.wantCond) > 1.0e-6*tt.wantCond {
+					t.Errorf("LU.Cond() = %v, want %v", got, tt.wantCond)
+				}
+			}
+			if err != nil {
+				return
+			}
+			gotX, err := lu.Solve(mulVec(tt.a, tt.x))
+			if err != nil {
+				t.Errorf("LU.Solve() error = %v", err)
+				return
+			}
+			for i := range gotX {
+				if math.Abs(gotX[i]-tt.x[i]) > 1.0e-10 {
+					t.Errorf("LU.Solve() x[%d] = %v, want %v", i, gotX[i], tt.x[i])
+				}
+			}
+			inv, err := lu.Inverse()
+			if err != nil || !isIdentity(tt.a, inv, 1.0e-10) {
+				t.Errorf("LU.Inverse() = %v, error = %v", inv, err)
+			}
+		})
+	}
+}
+
+func TestLUNotFactorized(t *testing.T) {
+	var lu LU
+	if got := lu.Det(); !math.IsNaN(got) {
+		t.Errorf("LU.Det() = %v, want NaN", got)
+	}
+	if x, err := lu.Solve([]float64{1}); err == nil {
+		t.Errorf("LU.Solve() = %v, want an error", x)
+	}
+	if inv, err := lu.Inverse(); err == nil {
+		t.Errorf("LU.Inverse() = %v, want an error", inv)
+	}
+	if got := lu.Cond(); !math.IsInf(got, 1) {
+		t.Errorf("LU.Cond() = %v, want +Inf", got)
+	}
+}
@@ -0,0 +1,110 @@
+package solveeqs
+
+import "fmt"
+
+// Preconditioner approximates the inverse of a matrix M ~ A for the
+// iterative solvers
+type Preconditioner interface {
+	Apply(dst, r []float64) // dst = M^-1 r
+}
+
+// Jacobi is the diagonal preconditioner M = diag(A)
+type Jacobi struct {
+	inv []float64
+}
+
+// NewJacobi returns the Jacobi preconditioner of a
+func NewJacobi(a *CSR) (*Jacobi, error) {
+	d := a.Diagonal()
+	for i := range d {
+		if d[i] == 0.0 {
+			return nil, fmt.Errorf("Zero diagonal element in row %d", i)
+		}
+		d[i] = 1.0 / d[i]
+	}
+	return &Jacobi{inv: d}, nil
+}
+
+// Apply sets dst = diag(A)^-1 r
+func (p *Jacobi) Apply(dst, r []float64) {
+	for i, d := range p.inv {
+		dst[i] = d * r[i]
+	}
+}
+
+// ILU0 is the incomplete LU factorization with zero fill-in: L U has the
+// sparsity pattern of A, L is unit lower triangular
+type ILU0 struct {
+	lu   *CSR
+	diag []int // the position of the diagonal element of each row
+}
+
+// NewILU0 returns the ILU(0) preconditioner of the square matrix a; every
+// diagonal element of a must be stored and nonzero
+func NewILU0(a *CSR) (*ILU0, error) {
+	n, c := a.Dims()
+	if n != c {
+		return nil, fmt.Errorf("ILU(0) requires a square matrix, got %d x %d", n, c)
+	}
+	lu := &CSR{rows: n, cols: n, indptr: a.indptr, indices: a.indices,
+		data: append([]float64(nil), a.data...)}
+	p := &ILU0{lu: lu, diag: make([]int, n)}
+	for i := 0; i < n; i++ {
+		if p.diag[i] = lu.find(i, i); p.diag[i] < 0 {
+			return nil, fmt.Errorf("Missing diagonal element in row %d", i)
+		}
+	}
+	//-----------------------------------------------------
+	// IKJ variant restricted to the pattern of A
+	//-----------------------------------------------------
+	pos := make([]int, n)
+	for j := range pos {
+		pos[j] = -1
+	}
+	for i := 0; i < n; i++ {
+		lo, hi := lu.indptr[i], lu.indptr[i+1]
+		for k := lo; k < hi; k++ {
+			pos[lu.indices[k]] = k
+		}
+		for k := lo; k < hi && lu.indices[k] < i; k++ {
+			col := lu.indices[k]
+			dkk := lu.data[p.diag[col]]
+			if dkk == 0.0 {
+				return nil, fmt.Errorf("Zero pivot in row %d", col)
+			}
+			lu.data[k] /= dkk
+			for kk := p.diag[col] + 1; kk < lu.indptr[col+1]; kk++ {
+				if q := pos[lu.indices[kk]]; q >= 0 {
+					lu.data[q] -= lu.data[k] * lu.data[kk]
+				}
+			}
+		}
+		for k := lo; k < hi; k++ {
+			pos[lu.indices[k]] = -1
+		}
+		if lu.data[p.diag[i]] == 0.0 {
+			return nil, fmt.Errorf("Zero pivot in row %d", i)
+		}
+	}
+	return p, nil
+}
+
+// Apply sets dst = (L U)^-1 r
+func (p *ILU0) Apply(dst, r []float64) {
+	lu := p.lu
+	n := len(p.diag)
+	for i := 0; i < n; i++ {
+		s := r[i]
+		for k := lu.indptr[i]; k < p.diag[i]; k++ {
+			s -= lu.data[k] * dst[lu.indices[k]]
+		}
+		dst[i] = s
+	}
+	for i := n - 1; i >= 0; i-- {
+		s := dst[i]
+		for k := p.diag[i] + 1; k < lu.indptr[i+1]; k++ {
+			s -= lu.data[k] * dst[lu.indices[k]]
+		}
+		dst[i] = s / lu.data[p.diag[i]]
+	}
+}
@@ -0,0 +1,210 @@
+package solveeqs
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/shyang107/gnum"
+)
+
+// QR is the Householder factorization A = Q R of an m x n matrix A with
+// m >= n; Q is orthogonal and R upper triangular. It solves linear least
+// squares problems min ||A x - b||_2.
+type QR struct {
+	qr    *gnum.Matrix // R above the diagonal, the Householder vectors below
+	rdiag []float64    // the diagonal of R
+	nref  int          // the number of reflections applied
+}
+
+// Factorize computes the QR factorization of a; a is not modified
+func (f *QR) Factorize(a *gnum.Matrix) error {
+	m, n := a.Dims()
+	if m < n {
+		return fmt.Errorf("QR requires rows >= columns, got %d x %d", m, n)
+	}
+	f.qr = a.Clone()
+	f.rdiag = make([]float64, n)
+	f.nref = 0
+	for k := 0; k < n; k++ {
+		//-----------------------------------------------------
+		// the Householder vector v = x - alpha e_k of column k
+		//-----------------------------------------------------
+		nrm := 0.0
+		for i := k; i < m; i++ {
+			nrm = math.Hypot(nrm, f.qr.At(i, k))
+		}
+		if nrm == 0.0 {
+			continue
+		}
+		if f.qr.At(k, k) < 0.0 {
+			nrm = -nrm
+		}
+		for i := k; i < m; i++ {
+			f.qr.Set(i, k, f.qr.At(i, k)/nrm)
+		}
+		f.qr.Set(k, k, f.qr.At(k, k)+1.0)
+		f.nref++
+		//-----------------------------------------------------
+		// apply the reflection to the remaining columns
+		//-----------------------------------------------------
+		for j := k + 1; j < n; j++ {
+			s := 0.0
+			for i := k; i < m; i++ {
+				s += f.qr.At(i, k) * f.qr.At(i, j)
+			}
+			s = -s / f.qr.At(k, k)
+			for i := k; i < m; i++ {
+				f.qr.Set(i, j, f.qr.At(i, j)+s*f.qr.At(i, k))
+			}
+		}
+		f.rdiag[k] = -nrm
+	}
+	return nil
+}
+
+// R returns the n x n upper triangular factor, or nil if A is not
+// factorized
+func (f *QR) R() *gnum.Matrix {
+	if f.qr == nil {
+		return nil
+	}
+	_, n := f.qr.Dims()
+	r := gnum.NewMatrix(n, n, nil)
+	for i := 0; i < n; i++ {
+		r.Set(i, i, f.rdiag[i])
+		for j := i + 1; j < n; j++ {
+			r.Set(i, j, f.qr.At(i, j))
+		}
+	}
+	return r
+}
+
+// applyQT overwrites b (length m) by Q^T b
+func (f *QR) applyQT(b []float64) {
+	m, n := f.qr.Dims()
+	for k := 0; k < n; k++ {
+		if f.rdiag[k] == 0.0 {
+			continue
+		}
+		s := 0.0
+		for i := k; i < m; i++ {
+			s += f.qr.At(i, k) * b[i]
+		}
+		s = -s / f.qr.At(k, k)
+		for i := k; i < m; i++ {
+			b[i] += s * f.qr.At(i, k)
+		}
+	}
+}
+
+// solveR overwrites the first n entries of x by the solution of R x = x
+func (f *QR) solveR(x []float64) {
+	_, n := f.qr.Dims()
+	for i := n - 1; i >= 0; i-- {
+		for j := i + 1; j < n; j++ {
+			x[i] -= f.qr.At(i, j) * x[j]
+		}
+		x[i] /= f.rdiag[i]
+	}
+}
+
+// solveRT overwrites x by the solution of R^T x = x
+func (f *QR) solveRT(x []float64) {
+	_, n := f.qr.Dims()
+	for i := 0; i < n; i++ {
+		for j := 0; j < i; j++ {
+			x[i] -= f.qr.At(j, i) * x[j]
+		}
+		x[i] /= f.rdiag[i]
+	}
+}
+
+// Solve returns the least squares solution x of A x = b and the residual
+// norm ||A x - b||_2; for a square A it is the solution of A x = b
+func (f *QR) Solve(b []float64) (x []float64, resid float64, err error) {
+	if err := f.fullRank(); err != nil {
+		return nil, 0.0, err
+	}
+	m, n := f.qr.Dims()
+	if len(b) != m {
+		return nil, 0.0, fmt.Errorf("len(b) = %d, want %d", len(b), m)
+	}
+	y := append([]float64(nil), b...)
+	f.applyQT(y)
+	for i := n; i < m; i++ {
+		resid = math.Hypot(resid, y[i])
+	}
+	f.solveR(y)
+	return y[:n:n], resid, nil
+}
+
+// Det returns the determinant of a square A
+func (f *QR) Det() (float64, error) {
+	if f.qr == nil {
+		return math.NaN(), fmt.Errorf("QR: the matrix is not factorized")
+	}
+	m, n := f.qr.Dims()
+	if m != n {
+		return 0.0, fmt.Errorf("The determinant requires a square matrix, got %d x %d", m, n)
+	}
+	d := 1.0
+	if f.nref%2 == 1 {
+		d = -1.0
+	}
+	for _, r := range f.rdiag {
+		d *= r
+	}
+	return d, nil
+}
+
+// Inverse returns the inverse of a square A
+func (f *QR) Inverse() (*gnum.Matrix, error) {
+	if f.qr == nil {
+		return nil, fmt.Errorf("QR: the matrix is not factorized")
+	}
+	m, n := f.qr.Dims()
+	if m != n {
+		return nil, fmt.Errorf("The inverse requires a square matrix, got %d x %d", m, n)
+	}
+	if err := f.fullRank(); err != nil {
+		return nil, err
+	}
+	inv := gnum.NewMatrix(n, n, nil)
+	x := make([]float64, n)
+	for j := 0; j < n; j++ {
+		for i := range x {
+			x[i] = 0.0
+		}
+		x[j] = 1.0
+		f.applyQT(x)
+		f.solveR(x)
+		for i := range x {
+			inv.Set(i, j, x[i])
+		}
+	}
+	return inv, nil
+}
+
+// Cond returns an estimate of the 1-norm condition number of R, which
+// measures the sensitivity of the least squares solution; its 2-norm
+// condition number equals that of A
+func (f *QR) Cond() float64 {
+	if f.fullRank() != nil {
+		return math.Inf(1)
+	}
+	_, n := f.qr.Dims()
+	return f.R().Norm(1) * normInv1(n, f.solveR, f.solveRT)
+}
+
+// fullRank returns an error if R has a zero on its diagonal
+func (f *QR) fullRank() error {
+	if f.qr == nil {
+		return fmt.Errorf("QR: the matrix is not factorized")
+	}
+	for i, r := range f.rdiag {
+		if r == 0.0 {
+			return fmt.Errorf("Rank deficient matrix: column %d", i)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package solveeqs
+
+import (
+	"math"
+	"testing"
+
+	"github.com/shyang107/gnum"
+)
+
+func TestQR(t *testing.T) {
+	// fit y = c0 + c1 x to (0,1), (1,3), (2,4), (3,4)
+	line := gnum.NewMatrix(4, 2, []float64{1, 0, 1, 1, 1, 2, 1, 3})
+	tests := []struct {
+		name      string
+		a         *gnum.Matrix
+		b         []float64
+		wantX     []float64
+		wantResid float64
+		wantDet   float64
+	}{
+		{
+			"Case 1 : square 3 x 3",
+			gnum.NewMatrix(3, 3, []float64{0, 2, 1, 1, 1, 1, 2, 1, 0}),
+			[]float64{-1, 2, 0},
+			[]float64{1, -2, 3},
+			0.0,
+			3.0,
+		},
+		{
+			"Case 2 : least squares line",
+			line,
+			[]float64{1, 3, 4, 4},
+			[]float64{1.5, 1.0},
+			1.0,
+			math.NaN(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var qr QR
+			if err := qr.Factorize(tt.a); err != nil {
+				t.Errorf("QR.Factorize() error = %v", err)
+				return
+			}
+			gotX, gotResid, err := qr.Solve(tt.b)
+			if err != nil {
+				t.Errorf("QR.Solve() error = %v", err)
+				return
+			}
+			for i := range gotX {
+				if math.Abs(gotX[i]-tt.wantX[i]) > 1.0e-12 {
+					t.Errorf("QR.Solve() x[%d] = %v, want %v", i, gotX[i], tt.wantX[i])
+				}
+			}
+			if math.Abs(gotResid-tt.wantResid) > 1.0e-12 {
+				t.Errorf("QR.Solve() resid = %v, want %v", gotResid, tt.wantResid)
+			}
+			gotDet, err := qr.Det()
+			if math.IsNaN(tt.wantDet) {
+				if err == nil {
+					t.Errorf("QR.Det() of a %v x %v matrix should fail", len(tt.b), len(gotX))
+				}
+				return
+			}
+			if err != nil || math.Abs(gotDet-tt.wantDet) > 1.0e-12 {
+				t.Errorf("QR.Det() = %v, want %v, error = %v", gotDet, tt.wantDet, err)
+			}
+			inv, err := qr.Inverse()
+			if err != nil || !isIdentity(tt.a, inv, 1.0e-12) {
+				t.Errorf("QR.Inverse() = %v, error = %v", inv, err)
+			}
+			if got := qr.Cond(); got < 1.0 || math.IsInf(got, 0) {
+				t.Errorf("QR.Cond() = %v", got)
+			}
+		})
+	}
+}
+
+func TestQRNotFactorized(t *testing.T) {
+	var qr QR
+	if d, err := qr.Det(); err == nil || !math.IsNaN(d) {
+		t.Errorf("QR.Det() = %v, %v, want NaN and an error", d, err)
+	}
+	if r := qr.R(); r != nil {
+		t.Errorf("QR.R() = %v, want nil", r)
+	}
+	if x, _, err := qr.Solve([]float64{1}); err == nil {
+		t.Errorf("QR.Solve() = %v, want an error", x)
+	}
+	if inv, err := qr.Inverse(); err == nil {
+		t.Errorf("QR.Inverse() = %v, want an error", inv)
+	}
+	if got := qr.Cond(); !math.IsInf(got, 1) {
+		t.Errorf("QR.Cond() = %v, want +Inf", got)
+	}
+}
@@ -0,0 +1,178 @@
+package solveeqs
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/shyang107/gnum"
+)
+
+// Operator is a linear operator A used by the iterative solvers; it only
+// has to multiply vectors
+type Operator interface {
+	Dims() (r, c int)
+	MulVec(dst, x []float64) // dst = A x
+}
+
+// COO is a sparse matrix in coordinate format. It is convenient for
+// assembly; convert it with ToCSR before solving.
+type COO struct {
+	rows, cols int
+	ri, ci     []int
+	v          []float64
+}
+
+// NewCOO returns an empty r x c COO matrix
+func NewCOO(r, c int) *COO {
+	return &COO{rows: r, cols: c}
+}
+
+// Dims returns the number of rows and columns
+func (m *COO) Dims() (r, c int) {
+	return m.rows, m.cols
+}
+
+// Add adds v to element (i,j); entries added twice are summed
+func (m *COO) Add(i, j int, v float64) {
+	if i < 0 || i >= m.rows || j < 0 || j >= m.cols {
+		panic(fmt.Sprintf("solveeqs: index (%d,%d) out of range %d x %d", i, j, m.rows, m.cols))
+	}
+	m.ri = append(m.ri, i)
+	m.ci = append(m.ci, j)
+	m.v = append(m.v, v)
+}
+
+// ToCSR converts m to compressed sparse row format with sorted column
+// indices and summed duplicates
+func (m *COO) ToCSR() *CSR {
+	idx := make([]int, len(m.v))
+	for k := range idx {
+		idx[k] = k
+	}
+	sort.SliceStable(idx, func(a, b int) bool {
+		ka, kb := idx[a], idx[b]
+		if m.ri[ka] != m.ri[kb] {
+			return m.ri[ka] < m.ri[kb]
+		}
+		return m.ci[ka] < m.ci[kb]
+	})
+	c := &CSR{rows: m.rows, cols: m.cols, indptr: make([]int, m.rows+1)}
+	for n, k := range idx {
+		i, j := m.ri[k], m.ci[k]
+		if n > 0 {
+			p := idx[n-1]
+			if m.ri[p] == i && m.ci[p] == j {
+				c.data[len(c.data)-1] += m.v[k]
+				continue
+			}
+		}
+		c.indices = append(c.indices, j)
+		c.data = append(c.data, m.v[k])
+		c.indptr[i+1]++
+	}
+	for i := 0; i < m.rows; i++ {
+		c.indptr[i+1] += c.indptr[i]
+	}
+	return c
+}
+
+// CSR is a sparse matrix in compressed sparse row format: the entries of
+// row i are data[indptr[i]:indptr[i+1]] in the columns
+// indices[indptr[i]:indptr[i+1]]
+type CSR struct {
+	rows, cols int
+	indptr     []int
+	indices    []int
+	data       []float64
+}
+
+// NewCSR returns an r x c CSR matrix on the given arrays, which are not
+// copied; the column indices of each row must be increasing
+func NewCSR(r, c int, indptr, indices []int, data []float64) (*CSR, error) {
+	if len(indptr) != r+1 || indptr[0] != 0 || indptr[r] != len(indices) || len(indices) != len(data) {
+		return nil, fmt.Errorf("Inconsistent CSR arrays for a %d x %d matrix", r, c)
+	}
+	for i := 0; i < r; i++ {
+		for k := indptr[i]; k < indptr[i+1]; k++ {
+			if indices[k] < 0 || indices[k] >= c || (k > indptr[i] && indices[k] <= indices[k-1]) {
+				return nil, fmt.Errorf("Bad column index %d in row %d", indices[k], i)
+			}
+		}
+	}
+	return &CSR{rows: r, cols: c, indptr: indptr, indices: indices, data: data}, nil
+}
+
+// Dims returns the number of rows and columns
+func (m *CSR) Dims() (r, c int) {
+	return m.rows, m.cols
+}
+
+// NNZ returns the number of stored entries
+func (m *CSR) NNZ() int {
+	return len(m.data)
+}
+
+// At returns element (i,j)
+func (m *CSR) At(i, j int) float64 {
+	if k := m.find(i, j); k >= 0 {
+		return m.data[k]
+	}
+	return 0.0
+}
+
+// find returns the position of (i,j) in data, or -1 if it is not stored
+func (m *CSR) find(i, j int) int {
+	lo, hi := m.indptr[i], m.indptr[i+1]
+	k := lo + sort.SearchInts(m.indices[lo:hi], j)
+	if k < hi && m.indices[k] == j {
+		return k
+	}
+	return -1
+}
+
+// MulVec sets dst = A x
+func (m *CSR) MulVec(dst, x []float64) {
+	for i := 0; i < m.rows; i++ {
+		s := 0.0
+		for k := m.indptr[i]; k < m.indptr[i+1]; k++ {
+			s += m.data[k] * x[m.indices[k]]
+		}
+		dst[i] = s
+	}
+}
+
+// MulTransVec sets dst = A^T x
+func (m *CSR) MulTransVec(dst, x []float64) {
+	for j := range dst[:m.cols] {
+		dst[j] = 0.0
+	}
+	for i := 0; i < m.rows; i++ {
+		for k := m.indptr[i]; k < m.indptr[i+1]; k++ {
+			dst[m.indices[k]] += m.data[k] * x[i]
+		}
+	}
+}
+
+// Diagonal returns the diagonal of A
+func (m *CSR) Diagonal() []float64 {
+	n := m.rows
+	if m.cols < n {
+		n = m.cols
+	}
+	d := make([]float64, n)
+	for i := range d {
+		d[i] = m.At(i, i)
+	}
+	return d
+}
+
+// Dense returns A as a dense matrix
+func (m *CSR) Dense() *gnum.Matrix {
+	a := gnum.NewMatrix(m.rows, m.cols, nil)
+	for i := 0; i < m.rows; i++ {
+		for k := m.indptr[i]; k < m.indptr[i+1]; k++ {
+			a.Set(i, m.indices[k], m.data[k])
+		}
+	}
+	return a
+}
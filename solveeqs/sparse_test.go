@@ -0,0 +1,69 @@
+package solveeqs
+
+import "testing"
+
+// poisson returns the n x n matrix of -u'' = f, tridiag(-1, 2, -1)
+func poisson(n int) *CSR {
+	c := NewCOO(n, n)
+	for i := 0; i < n; i++ {
+		c.Add(i, i, 2.0)
+		if i > 0 {
+			c.Add(i, i-1, -1.0)
+		}
+		if i < n-1 {
+			c.Add(i, i+1, -1.0)
+		}
+	}
+	return c.ToCSR()
+}
+
+// convection returns the nonsymmetric n x n matrix of -u'' + p u' = f
+// by central differences, tridiag(-1-p/2, 2, -1+p/2)
+func convection(n int, p float64) *CSR {
+	c := NewCOO(n, n)
+	for i := 0; i < n; i++ {
+		c.Add(i, i, 2.0)
+		if i > 0 {
+			c.Add(i, i-1, -1.0-0.5*p)
+		}
+		if i < n-1 {
+			c.Add(i, i+1, -1.0+0.5*p)
+		}
+	}
+	return c.ToCSR()
+}
+
+func TestCOO(t *testing.T) {
+	c := NewCOO(3, 3)
+	c.Add(2, 0, 1.0)
+	c.Add(0, 1, 2.0)
+	c.Add(0, 1, 3.0)
+	c.Add(1, 1, 4.0)
+	c.Add(0, 0, 5.0)
+	m := c.ToCSR()
+	if m.NNZ() != 4 {
+		t.Errorf("COO.ToCSR().NNZ() = %v, want 4", m.NNZ())
+	}
+	want := []float64{5, 5, 0, 0, 4, 0, 1, 0, 0}
+	d := m.Dense()
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			if d.At(i, j) != want[3*i+j] || m.At(i, j) != want[3*i+j] {
+				t.Errorf("CSR (%d,%d) = %v, want %v", i, j, m.At(i, j), want[3*i+j])
+			}
+		}
+	}
+	x := []float64{1, 2, 3}
+	y := make([]float64, 3)
+	m.MulVec(y, x)
+	if y[0] != 15 || y[1] != 8 || y[2] != 1 {
+		t.Errorf("CSR.MulVec() = %v, want [15 8 1]", y)
+	}
+	m.MulTransVec(y, x)
+	if y[0] != 8 || y[1] != 13 || y[2] != 0 {
+		t.Errorf("CSR.MulTransVec() = %v, want [8 13 0]", y)
+	}
+	if _, err := NewCSR(2, 2, []int{0, 2, 3}, []int{1, 0, 1}, []float64{1, 2, 3}); err == nil {
+		t.Errorf("NewCSR() accepted unsorted column indices")
+	}
+}
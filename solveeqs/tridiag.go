@@ -0,0 +1,125 @@
+package solveeqs
+
+import "fmt"
+
+// Tridiag solves the tridiagonal system
+//	a[i] x[i-1] + b[i] x[i] + c[i] x[i+1] = d[i],	i = 0..n-1
+// by the Thomas algorithm in O(n); a[0] and c[n-1] are not used. There
+// is no pivoting, so the matrix should be diagonally dominant or
+// symmetric positive definite.
+//
+// a, b, c	: the sub-, main and super-diagonal, each of length n
+// d		: the right hand side
+func Tridiag(a, b, c, d []float64) (x []float64, err error) {
+	x = append([]float64(nil), d...)
+	if err := TridiagInPlace(a, b, c, x, nil); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// TridiagInPlace is Tridiag overwriting d by the solution x; a, b and c
+// are not modified
+//
+// work	: scratch space of length n, nil allocates it
+func TridiagInPlace(a, b, c, d, work []float64) error {
+	n := len(d)
+	if len(a) != n || len(b) != n || len(c) != n {
+		return fmt.Errorf("The diagonals must have length %d, got %d, %d, %d", n, len(a), len(b), len(c))
+	}
+	if n == 0 {
+		return nil
+	}
+	if work == nil {
+		work = make([]float64, n)
+	}
+	if len(work) < n {
+		return fmt.Errorf("len(work) = %d, want %d", len(work), n)
+	}
+	//-----------------------------------------------------
+	// forward elimination, work holds the modified c
+	//-----------------------------------------------------
+	if b[0] == 0.0 {
+		return fmt.Errorf("Zero pivot in row %d", 0)
+	}
+	work[0] = c[0] / b[0]
+	d[0] /= b[0]
+	for i := 1; i < n; i++ {
+		m := b[i] - a[i]*work[i-1]
+		if m == 0.0 {
+			return fmt.Errorf("Zero pivot in row %d", i)
+		}
+		work[i] = c[i] / m
+		d[i] = (d[i] - a[i]*d[i-1]) / m
+	}
+	//-----------------------------------------------------
+	// back substitution
+	//-----------------------------------------------------
+	for i := n - 2; i >= 0; i-- {
+		d[i] -= work[i] * d[i+1]
+	}
+	return nil
+}
+
+// CyclicTridiag solves the periodic tridiagonal system, which is
+// Tridiag with the corner elements a[0] at (0,n-1) and c[n-1] at
+// (n-1,0), by the Sherman-Morrison formula in O(n); n >= 3
+func CyclicTridiag(a, b, c, d []float64) (x []float64, err error) {
+	x = append([]float64(nil), d...)
+	if err := CyclicTridiagInPlace(a, b, c, x, nil); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// CyclicTridiagInPlace is CyclicTridiag overwriting d by the solution x;
+// a, b and c are not modified
+//
+// work	: scratch space of length 3n, nil allocates it
+func CyclicTridiagInPlace(a, b, c, d, work []float64) error {
+	n := len(d)
+	if n < 3 {
+		return fmt.Errorf("The cyclic system needs n >= 3, got %d", n)
+	}
+	if len(a) != n || len(b) != n || len(c) != n {
+		return fmt.Errorf("The diagonals must have length %d, got %d, %d, %d", n, len(a), len(b), len(c))
+	}
+	if work == nil {
+		work = make([]float64, 3*n)
+	}
+	if len(work) < 3*n {
+		return fmt.Errorf("len(work) = %d, want %d", len(work), 3*n)
+	}
+	bb, z, w := work[:n], work[n:2*n], work[2*n:3*n]
+	//-----------------------------------------------------
+	// A = T + u v^T with u = (gamma, 0, ..., 0, alpha),
+	// v = (1, 0, ..., 0, beta/gamma)
+	//-----------------------------------------------------
+	alpha, beta := c[n-1], a[0]
+	gamma := -b[0]
+	if gamma == 0.0 {
+		gamma = 1.0
+	}
+	copy(bb, b)
+	bb[0] = b[0] - gamma
+	bb[n-1] = b[n-1] - alpha*beta/gamma
+	if err := TridiagInPlace(a, bb, c, d, w); err != nil {
+		return err
+	}
+	for i := range z {
+		z[i] = 0.0
+	}
+	z[0], z[n-1] = gamma, alpha
+	if err := TridiagInPlace(a, bb, c, z, w); err != nil {
+		return err
+	}
+	den := 1.0 + z[0] + beta*z[n-1]/gamma
+	if den == 0.0 {
+		return fmt.Errorf("Singular cyclic tridiagonal matrix")
+	}
+	fact := (d[0] + beta*d[n-1]/gamma) / den
+	for i := range d {
+		d[i] -= fact * z[i]
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package solveeqs
+
+import (
+	"math"
+	"testing"
+)
+
+// tridiagMul returns the product of the (cyclic) tridiagonal matrix with x
+func tridiagMul(a, b, c, x []float64, cyclic bool) []float64 {
+	n := len(x)
+	y := make([]float64, n)
+	for i := 0; i < n; i++ {
+		y[i] = b[i] * x[i]
+		if i > 0 {
+			y[i] += a[i] * x[i-1]
+		}
+		if i < n-1 {
+			y[i] += c[i] * x[i+1]
+		}
+	}
+	if cyclic {
+		y[0] += a[0] * x[n-1]
+		y[n-1] += c[n-1] * x[0]
+	}
+	return y
+}
+
+func TestTridiag(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b, c []float64
+		x       []float64
+		wantErr bool
+	}{
+		{"1x1", []float64{0}, []float64{2}, []float64{0}, []float64{3}, false},
+		{"poisson", []float64{0, -1, -1, -1, -1}, []float64{2, 2, 2, 2, 2},
+			[]float64{-1, -1, -1, -1, 0}, []float64{1, -2, 3, 0.5, 4}, false},
+		{"nonsymmetric", []float64{0, 1, 2, 3}, []float64{4, 5, 6, 7},
+			[]float64{0.5, 1.5, 2.5, 0}, []float64{1, 2, 3, 4}, false},
+		{"zero pivot", []float64{0, 1}, []float64{0, 1}, []float64{1, 0}, []float64{1, 1}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := tridiagMul(tt.a, tt.b, tt.c, tt.x, false)
+			got, err := Tridiag(tt.a, tt.b, tt.c, d)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Tridiag() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			for i := range got {
+				if math.Abs(got[i]-tt.x[i]) > 1e-12 {
+					t.Errorf("Tridiag() x[%d] = %v, want %v", i, got[i], tt.x[i])
+				}
+			}
+		})
+	}
+}
+
+func TestTridiagInPlace(t *testing.T) {
+	n := 50
+	a, b, c := make([]float64, n), make([]float64, n), make([]float64, n)
+	x := make([]float64, n)
+	for i := range x {
+		a[i], b[i], c[i] = -1.0, 4.0, -1.5
+		x[i] = math.Sin(float64(i))
+	}
+	d := tridiagMul(a, b, c, x, false)
+	work := make([]float64, n)
+	rhs := append([]float64(nil), d...)
+	if n := testing.AllocsPerRun(1, func() {
+		copy(rhs, d)
+		if err := TridiagInPlace(a, b, c, rhs, work); err != nil {
+			t.Fatal(err)
+		}
+	}); n != 0 {
+		t.Errorf("TridiagInPlace() allocates %v times, want 0", n)
+	}
+	for i := range x {
+		if math.Abs(rhs[i]-x[i]) > 1e-12 {
+			t.Errorf("TridiagInPlace() x[%d] = %v, want %v", i, rhs[i], x[i])
+		}
+	}
+	if err := TridiagInPlace(a, b, c, rhs, work[:n-1]); err == nil {
+		t.Errorf("TridiagInPlace() with a short work buffer: want an error")
+	}
+}
+
+func TestCyclicTridiag(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b, c []float64
+		x       []float64
+		wantErr bool
+	}{
+		{"periodic laplacian", []float64{-1, -1, -1, -1, -1, -1}, []float64{2.5, 2.5, 2.5, 2.5, 2.5, 2.5},
+			[]float64{-1, -1, -1, -1, -1, -1}, []float64{1, 2, 3, 4, 5, 6}, false},
+		{"nonsymmetric", []float64{0.3, 1, 2, 0.5}, []float64{5, 6, 7, 8},
+			[]float64{1.5, 0.5, 2.5, -1}, []float64{-1, 2, 0.5, 3}, false},
+		{"too small", []float64{0, 1}, []float64{2, 2}, []float64{1, 0}, []float64{1, 1}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := tridiagMul(tt.a, tt.b, tt.c, tt.x, true)
+			got, err := CyclicTridiag(tt.a, tt.b, tt.c, d)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CyclicTridiag() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			for i := range got {
+				if math.Abs(got[i]-tt.x[i]) > 1e-12 {
+					t.Errorf("CyclicTridiag() x[%d] = %v, want %v", i, got[i], tt.x[i])
+				}
+			}
+		})
+	}
+}
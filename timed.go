@@ -0,0 +1,175 @@
+package gnum
+
+import (
+	"fmt"
+	"math/bits"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// nbuckets is the number of latency buckets; bucket i counts the calls
+// with latency in [2^(i-1), 2^i) ns, the last one everything longer
+const nbuckets = 48
+
+// Profile records the latency of every call of a timed callback.
+// A Profile is safe for concurrent use.
+type Profile struct {
+	name string
+
+	mu       sync.Mutex
+	count    int
+	total    time.Duration
+	min, max time.Duration
+	buckets  [nbuckets]int
+}
+
+// NewProfile returns an empty Profile labelled name
+func NewProfile(name string) *Profile {
+	return &Profile{name: name}
+}
+
+// Add records one call that took dt
+func (p *Profile) Add(dt time.Duration) {
+	i := 0
+	if dt > 0 {
+		i = bits.Len64(uint64(dt))
+	}
+	if i >= nbuckets {
+		i = nbuckets - 1
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.count == 0 || dt < p.min {
+		p.min = dt
+	}
+	if dt > p.max {
+		p.max = dt
+	}
+	p.count++
+	p.total += dt
+	p.buckets[i]++
+}
+
+// Reset clears the recorded calls
+func (p *Profile) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.count, p.total, p.min, p.max = 0, 0, 0, 0
+	p.buckets = [nbuckets]int{}
+}
+
+// Bucket is one bin of a latency histogram: Count calls took less than
+// Upper (and at least the Upper of the previous bin)
+type Bucket struct {
+	Upper time.Duration
+	Count int
+}
+
+// Stats is a snapshot of a Profile
+type Stats struct {
+	Name      string
+	Count     int
+	Total     time.Duration
+	Mean      time.Duration
+	Min       time.Duration
+	Max       time.Duration
+	Histogram []Bucket // from the first to the last non-empty bin
+}
+
+// Stats returns a snapshot of the recorded calls
+func (p *Profile) Stats() Stats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	s := Stats{Name: p.name, Count: p.count, Total: p.total, Min: p.min, Max: p.max}
+	if p.count == 0 {
+		return s
+	}
+	s.Mean = p.total / time.Duration(p.count)
+	lo, hi := -1, -1
+	for i, c := range p.buckets {
+		if c > 0 {
+			if lo < 0 {
+				lo = i
+			}
+			hi = i
+		}
+	}
+	for i := lo; i <= hi; i++ {
+		upper := time.Duration(1) << uint(i)
+		if i == nbuckets-1 {
+			upper = time.Duration(1<<63 - 1)
+		}
+		s.Histogram = append(s.Histogram, Bucket{upper, p.buckets[i]})
+	}
+	return s
+}
+
+// Quantile returns an upper bound of the q-quantile (0 <= q <= 1) of
+// the latency, read from the histogram
+func (s Stats) Quantile(q float64) time.Duration {
+	if s.Count == 0 {
+		return 0
+	}
+	rank := int(q*float64(s.Count) + 0.5)
+	if rank < 1 {
+		rank = 1
+	}
+	n := 0
+	for _, b := range s.Histogram {
+		n += b.Count
+		if n >= rank {
+			if b.Upper > s.Max {
+				return s.Max
+			}
+			return b.Upper
+		}
+	}
+	return s.Max
+}
+
+// String formats the statistics and the histogram
+func (s Stats) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s: %d calls, total %v, mean %v, min %v, max %v ---\n",
+		s.Name, s.Count, s.Total, s.Mean, s.Min, s.Max)
+	for _, b := range s.Histogram {
+		fmt.Fprintf(&sb, "%s< %-12v %8d\n", Spaces(3), b.Upper, b.Count)
+	}
+	return sb.String()
+}
+
+// Timed decorates the function f of any type F and records the latency
+// of every call in the returned Profile
+//
+//	g, prof := gnum.Timed(f)
+//	area := integrate.Romberg(0, 1, g, 1e-6)
+//	fmt.Print(prof.Stats())
+//
+// func(float64) float64 is wrapped directly; other function types go
+// through reflection, which adds roughly a microsecond per call. Timed
+// panics if F is not a function type.
+func Timed[F any](f F) (F, *Profile) {
+	v := reflect.ValueOf(f)
+	if v.Kind() != reflect.Func {
+		panic(fmt.Sprintf("gnum.Timed: %T is not a function", f))
+	}
+	p := NewProfile(GetFunctionName(f))
+	if g, ok := any(f).(func(float64) float64); ok {
+		h := func(x float64) float64 {
+			defer func(t time.Time) { p.Add(time.Since(t)) }(time.Now())
+			return g(x)
+		}
+		return any(h).(F), p
+	}
+	call := v.Call
+	if v.Type().IsVariadic() {
+		call = v.CallSlice
+	}
+	h := reflect.MakeFunc(v.Type(), func(args []reflect.Value) []reflect.Value {
+		defer func(t time.Time) { p.Add(time.Since(t)) }(time.Now())
+		return call(args)
+	})
+	return h.Interface().(F), p
+}
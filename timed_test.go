@@ -0,0 +1,77 @@
+package gnum
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestTimed(t *testing.T) {
+	g, prof := Timed(math.Sqrt)
+	for i := 0; i < 10; i++ {
+		if got, want := g(float64(i)), math.Sqrt(float64(i)); got != want {
+			t.Errorf("Timed(math.Sqrt)(%d) = %v, want %v", i, got, want)
+		}
+	}
+	s := prof.Stats()
+	if s.Count != 10 {
+		t.Errorf("Stats().Count = %v, want %v", s.Count, 10)
+	}
+	n := 0
+	for _, b := range s.Histogram {
+		n += b.Count
+	}
+	if n != s.Count {
+		t.Errorf("Stats().Histogram holds %v calls, want %v", n, s.Count)
+	}
+	if s.Min > s.Mean || s.Mean > s.Max || s.Quantile(0.5) > s.Max {
+		t.Errorf("Stats() = %+v is not ordered", s)
+	}
+	prof.Reset()
+	if prof.Stats().Count != 0 {
+		t.Errorf("Profile.Reset() left %v calls", prof.Stats().Count)
+	}
+
+	// other function types go through reflection
+	sum := func(xs ...float64) (s float64) {
+		for _, x := range xs {
+			s += x
+		}
+		return s
+	}
+	h, hprof := Timed(sum)
+	if got := h(1, 2, 3); got != 6 {
+		t.Errorf("Timed(sum)(1, 2, 3) = %v, want %v", got, 6)
+	}
+	hypot, pprof := Timed(math.Hypot)
+	if got := hypot(3, 4); got != 5 {
+		t.Errorf("Timed(math.Hypot)(3, 4) = %v, want %v", got, 5)
+	}
+	if hprof.Stats().Count != 1 || pprof.Stats().Count != 1 {
+		t.Errorf("Timed() counts = %v, %v, want 1, 1", hprof.Stats().Count, pprof.Stats().Count)
+	}
+}
+
+func TestStatsQuantile(t *testing.T) {
+	p := NewProfile("test")
+	for _, dt := range []time.Duration{3, 5, 100, 1000} {
+		p.Add(dt)
+	}
+	s := p.Stats()
+	tests := []struct {
+		name string
+		q    float64
+		want time.Duration
+	}{
+		{"min", 0.0, 4},
+		{"median", 0.5, 8},
+		{"max", 1.0, 1000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.Quantile(tt.q); got != tt.want {
+				t.Errorf("Stats.Quantile(%v) = %v, want %v", tt.q, got, tt.want)
+			}
+		})
+	}
+}
@@ -1,12 +1,10 @@
 package gnum
 
 import (
-	"fmt"
 	"math"
 	"reflect"
 	"runtime"
 	"strings"
-	"time"
 )
 
 const (
@@ -16,7 +14,7 @@ const (
 type Alignment byte
 
 const (
-	AlignLeft   Alignment = itoa // 0
+	AlignLeft   Alignment = iota // 0
 	AlignCenter                  // 1
 	AlignRight                   //2
 )
@@ -72,15 +70,3 @@ func Sign(a, b float64) float64 {
 func GetFunctionName(i interface{}) string {
 	return runtime.FuncForPC(reflect.ValueOf(i).Pointer()).Name()
 }
-
-func timedSumFunc(f SumFunc) SumFunc {
-	return func(start, end int64) int64 {
-
-		defer func(t time.Time) {
-			fmt.Printf("--- Time Elapsed (%s): %v ---\n",
-				getFunctionName(f), time.Since(t))
-		}(time.Now())
-
-		return f(start, end)
-	}
-}
@@ -0,0 +1,133 @@
+package gnum
+
+import (
+	"fmt"
+	"math"
+)
+
+// Vector is a dense vector of float64. It converts freely to and from
+// []float64; the methods write into the receiver so that callers can
+// reuse their buffers.
+type Vector []float64
+
+// NewVector returns a zero vector of length n
+func NewVector(n int) Vector {
+	return make(Vector, n)
+}
+
+// Clone returns a copy of v with its own storage
+func (v Vector) Clone() Vector {
+	return append(Vector(nil), v...)
+}
+
+// Zero sets all the elements of v to zero
+func (v Vector) Zero() {
+	for i := range v {
+		v[i] = 0.0
+	}
+}
+
+// Add sets v = a + b; v may be a or b
+func (v Vector) Add(a, b Vector) {
+	checkLen(len(a), len(v))
+	checkLen(len(b), len(v))
+	for i := range v {
+		v[i] = a[i] + b[i]
+	}
+}
+
+// Sub sets v = a - b; v may be a or b
+func (v Vector) Sub(a, b Vector) {
+	checkLen(len(a), len(v))
+	checkLen(len(b), len(v))
+	for i := range v {
+		v[i] = a[i] - b[i]
+	}
+}
+
+// Scale sets v = s * a; v may be a
+func (v Vector) Scale(s float64, a Vector) {
+	checkLen(len(a), len(v))
+	for i := range v {
+		v[i] = s * a[i]
+	}
+}
+
+// AddScaled sets v = v + alpha * x
+func (v Vector) AddScaled(alpha float64, x Vector) {
+	checkLen(len(x), len(v))
+	for i := range v {
+		v[i] += alpha * x[i]
+	}
+}
+
+// MulVec sets v = a x; v must not share storage with x
+func (v Vector) MulVec(a *Matrix, x Vector) {
+	checkLen(len(x), a.cols)
+	checkLen(len(v), a.rows)
+	for i := range v {
+		s := 0.0
+		for j, aij := range a.Row(i) {
+			s += aij * x[j]
+		}
+		v[i] = s
+	}
+}
+
+// MulTransVec sets v = a^T x; v must not share storage with x
+func (v Vector) MulTransVec(a *Matrix, x Vector) {
+	checkLen(len(x), a.rows)
+	checkLen(len(v), a.cols)
+	v.Zero()
+	for i, xi := range x {
+		if xi == 0.0 {
+			continue
+		}
+		for j, aij := range a.Row(i) {
+			v[j] += aij * xi
+		}
+	}
+}
+
+// Dot returns the inner product of v and x
+func (v Vector) Dot(x Vector) float64 {
+	checkLen(len(x), len(v))
+	s := 0.0
+	for i := range v {
+		s += v[i] * x[i]
+	}
+	return s
+}
+
+// Norm returns the vector norm of v selected by ord
+//
+// ord	= 1		: the sum of the absolute values
+//		= 2		: the Euclidean norm
+//		= +Inf	: the maximum absolute value
+func (v Vector) Norm(ord float64) float64 {
+	nrm := 0.0
+	switch {
+	case ord == 1:
+		for _, x := range v {
+			nrm += math.Abs(x)
+		}
+	case ord == 2:
+		for _, x := range v {
+			nrm = math.Hypot(nrm, x)
+		}
+	case math.IsInf(ord, 1):
+		for _, x := range v {
+			nrm = math.Max(nrm, math.Abs(x))
+		}
+	default:
+		panic(fmt.Sprintf("gnum: unsupported vector norm %v", ord))
+	}
+	return nrm
+}
+
+// checkLen panics if n != want
+func checkLen(n, want int) {
+	if n != want {
+		panic(fmt.Sprintf("gnum: length mismatch %d, want %d", n, want))
+	}
+}
@@ -0,0 +1,51 @@
+package gnum
+
+import (
+	"math"
+	"testing"
+)
+
+func TestVector(t *testing.T) {
+	a := Vector{1, 2, 3}
+	b := Vector{4, -5, 6}
+	v := NewVector(3)
+	v.Add(a, b)
+	if v[0] != 5 || v[1] != -3 || v[2] != 9 {
+		t.Errorf("Vector.Add() = %v", v)
+	}
+	v.Sub(v, b)
+	v.AddScaled(2, a)
+	if v[0] != 3 || v[1] != 6 || v[2] != 9 {
+		t.Errorf("Vector.Sub(), AddScaled() = %v", v)
+	}
+	if got := a.Dot(b); got != 12 {
+		t.Errorf("Vector.Dot() = %v, want 12", got)
+	}
+	tests := []struct {
+		name string
+		ord  float64
+		want float64
+	}{
+		{"1-norm", 1, 15},
+		{"2-norm", 2, math.Sqrt(77)},
+		{"inf-norm", math.Inf(1), 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := b.Norm(tt.ord); math.Abs(got-tt.want) > 1e-14 {
+				t.Errorf("Vector.Norm(%v) = %v, want %v", tt.ord, got, tt.want)
+			}
+		})
+	}
+	m := NewMatrix(2, 3, []float64{1, 2, 3, 4, 5, 6})
+	y := NewVector(2)
+	y.MulVec(m, a)
+	if y[0] != 14 || y[1] != 32 {
+		t.Errorf("Vector.MulVec() = %v", y)
+	}
+	z := NewVector(3)
+	z.MulTransVec(m, Vector{1, 1})
+	if z[0] != 5 || z[1] != 7 || z[2] != 9 {
+		t.Errorf("Vector.MulTransVec() = %v", z)
+	}
+}